@@ -0,0 +1,461 @@
+//go:build acceptance
+// +build acceptance
+
+package main
+
+import (
+	"os"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+	"github.com/pactflow/terraform/client"
+)
+
+// The acceptance suite applies every resource to a real broker, and checks that it reads back without a
+// diff, updates in place, imports to the same state and is removed from state when deleted outside of
+// Terraform. By default it runs against the broker in docker-compose.yml:
+//
+//	docker-compose up -d
+//	TF_ACC=1 go test -tags acceptance -run TestAcc -v .
+//
+// Set ACCEPTANCE_PACT_BROKER_BASE_URL and ACCEPTANCE_PACT_BROKER_TOKEN to run it against a PactFlow account
+// instead, which also covers the resources only PactFlow supports
+
+// accTestCase is a resource to apply each of the steps of in turn, normally a create followed by an update
+type accTestCase struct {
+	resource string
+	steps    []map[string]interface{}
+
+	// requires are created before the steps, and destroyed after them. Steps refer to their attributes as
+	// "${<resource>.<attribute>}"
+	requires []accResource
+
+	// pactflow is set when the resource is only supported by PactFlow
+	pactflow bool
+
+	// persistent is set for account settings and the like, that deleting only resets. They can't be deleted
+	// outside of Terraform, so are not checked for it
+	persistent bool
+
+	// importIgnore are attributes the broker doesn't return, so are only in state when they are configured
+	importIgnore []string
+}
+
+type accResource struct {
+	resource string
+	config   map[string]interface{}
+}
+
+var accReference = regexp.MustCompile(`^\$\{([a-z_]+)\.([a-z_]+)\}$`)
+
+func accTestCases() []accTestCase {
+	suffix := acctest.RandString(8)
+	name := "tf-acc-" + suffix
+
+	webhookRequest := func(description string, events ...interface{}) map[string]interface{} {
+		return map[string]interface{}{
+			"description": description,
+			"events":      events,
+			"request": []interface{}{map[string]interface{}{
+				"url":      "https://example.com/hooks/" + suffix,
+				"method":   "POST",
+				"username": "hooks",
+				"password": "password1",
+				"headers":  map[string]interface{}{"Content-Type": "application/json"},
+				"body":     `{"pact": "${pactbroker.pactUrl}"}`,
+			}},
+		}
+	}
+	systemAccount := accResource{"pact_user", map[string]interface{}{
+		"name":  name,
+		"email": name + "@example.com",
+		"type":  "system",
+	}}
+	provider := accResource{"pact_pacticipant", map[string]interface{}{
+		"name": name + "-provider",
+	}}
+
+	return []accTestCase{
+		{
+			resource: "pact_pacticipant",
+			steps: []map[string]interface{}{
+				{"name": name + "-consumer", "display_name": "Consumer", "repository_url": "https://github.com/example/consumer"},
+				{"name": name + "-consumer", "display_name": "Consumer UI", "repository_url": "https://github.com/example/consumer-ui", "main_branch": "main"},
+			},
+		},
+		{
+			resource: "pact_application",
+			steps: []map[string]interface{}{
+				{"name": name + "-application", "display_name": "Application"},
+				{"name": name + "-application", "display_name": "Application API", "main_branch": "main"},
+			},
+		},
+		{
+			resource: "pact_pacticipant_version",
+			requires: []accResource{provider},
+			steps: []map[string]interface{}{
+				{"pacticipant": "${pact_pacticipant.name}", "version": "1.0.0", "build_url": "https://ci.example.com/1", "tags": []interface{}{"dev"}},
+				{"pacticipant": "${pact_pacticipant.name}", "version": "1.0.0", "build_url": "https://ci.example.com/2", "tags": []interface{}{"dev", "prod"}},
+			},
+		},
+		{
+			resource: "pact_webhook",
+			steps: []map[string]interface{}{
+				webhookRequest("Notify the team", "contract_published"),
+				webhookRequest("Notify the team when a contract changes", "contract_published", "contract_content_changed"),
+			},
+			importIgnore: []string{"request.0.password"},
+		},
+		{
+			resource: "pact_environment",
+			steps: []map[string]interface{}{
+				{"name": name + "-staging", "display_name": "Staging"},
+				{"name": name + "-staging", "display_name": "Staging (EU)", "production": true},
+			},
+		},
+		{
+			resource: "pact_role",
+			pactflow: true,
+			steps: []map[string]interface{}{
+				{"name": name, "scopes": []interface{}{"user:read:*", "team:read:*"}},
+				{"name": name, "scopes": []interface{}{"user:read:*", "team:read:*", "webhook:manage:*"}},
+			},
+		},
+		{
+			resource: "pact_user",
+			pactflow: true,
+			steps: []map[string]interface{}{
+				{"name": name, "email": name + "@example.com", "type": "system"},
+				{"name": name + "-renamed", "email": name + "@example.com", "type": "system", "active": false},
+			},
+		},
+		{
+			resource: "pact_role_v1",
+			pactflow: true,
+			requires: []accResource{systemAccount},
+			steps: []map[string]interface{}{
+				{"role": administratorRole, "user": "${pact_user.uuid}"},
+			},
+			// It has no read, so whether the role was removed outside of Terraform can't be detected
+			persistent: true,
+		},
+		{
+			resource: "pact_team",
+			pactflow: true,
+			requires: []accResource{systemAccount},
+			steps: []map[string]interface{}{
+				{"name": name},
+				{"name": name + "-renamed", "users": []interface{}{"${pact_user.uuid}"}},
+			},
+		},
+		{
+			resource: "pact_secret",
+			pactflow: true,
+			steps: []map[string]interface{}{
+				{"name": "TfAcc" + suffix, "description": "A secret", "value": "super secret thing"},
+				{"name": "TfAcc" + suffix, "description": "A rotated secret", "value": "another secret thing"},
+			},
+			importIgnore: []string{"value"},
+		},
+		{
+			resource: "pact_token",
+			pactflow: true,
+			// Only the read only token, as regenerating the read write one would replace the token the
+			// suite is using
+			steps: []map[string]interface{}{
+				{"name": "Read only token", "type": "read-only"},
+				{"name": "Regenerated read only token", "type": "read-only"},
+			},
+			persistent:   true,
+			importIgnore: []string{"name", "type"},
+		},
+		{
+			resource: "pact_authentication",
+			pactflow: true,
+			steps: []map[string]interface{}{
+				{"github_organizations": []interface{}{"pactflow"}},
+				{"github_organizations": []interface{}{"pactflow"}, "google_domains": []interface{}{"example.com"}},
+			},
+			persistent: true,
+		},
+		{
+			resource: "pact_badge_settings",
+			pactflow: true,
+			steps: []map[string]interface{}{
+				{"public_read_access": true},
+				{"public_read_access": false},
+			},
+			persistent: true,
+		},
+		{
+			resource: "pact_notification_settings",
+			pactflow: true,
+			steps: []map[string]interface{}{
+				{"verification_failure_digest": true, "recipients": []interface{}{name + "@example.com"}},
+				{"verification_failure_digest": true, "digest_frequency": "weekly", "recipients": []interface{}{name + "@example.com"}},
+			},
+			persistent: true,
+		},
+		{
+			resource: "pact_announcement",
+			pactflow: true,
+			steps: []map[string]interface{}{
+				{"message": "Scheduled maintenance tonight"},
+				{"message": "Scheduled maintenance in progress", "level": "warning"},
+			},
+			persistent: true,
+		},
+		{
+			resource: "pact_chat_integration",
+			pactflow: true,
+			steps: []map[string]interface{}{
+				{"type": "slack", "channel": "#builds", "webhook_url": "https://hooks.slack.com/services/T000/B000/" + suffix, "events": []interface{}{"contract_published"}},
+				{"type": "slack", "channel": "#contracts", "webhook_url": "https://hooks.slack.com/services/T000/B000/" + suffix, "events": []interface{}{"contract_published"}, "enabled": false},
+			},
+			importIgnore: []string{"webhook_url"},
+		},
+		{
+			resource: "pact_provider_contract",
+			pactflow: true,
+			requires: []accResource{provider},
+			steps: []map[string]interface{}{
+				{
+					"provider_name":        "${pact_pacticipant.name}",
+					"version":              "1.0.0",
+					"content":              "openapi: 3.0.0\ninfo:\n  title: Provider\n  version: 1.0.0\npaths: {}\n",
+					"verification_success": true,
+					"verification_results": "All tests passed",
+					"verifier":             "terraform",
+					"verifier_version":     "1.0.0",
+				},
+			},
+		},
+	}
+}
+
+func TestAccResources(t *testing.T) {
+	if os.Getenv("TF_ACC") == "" {
+		t.Skip("set TF_ACC to run the acceptance tests against a broker")
+	}
+
+	provider := Provider()
+	if err := provider.Configure(terraform.NewResourceConfigRaw(accProviderConfig())); err != nil {
+		t.Fatalf("error configuring the provider: %s", err)
+	}
+	meta := provider.Meta()
+	pactflow := meta.(*client.Client).Config.BrokerType == pactflowBrokerType
+
+	tested := map[string]bool{}
+	for _, c := range accTestCases() {
+		c := c
+		tested[c.resource] = true
+
+		t.Run(c.resource, func(t *testing.T) {
+			if c.pactflow && !pactflow {
+				t.Skip("only supported by PactFlow")
+			}
+			testAccResource(t, provider, meta, c)
+		})
+	}
+
+	for name := range provider.ResourcesMap {
+		if !tested[name] {
+			t.Errorf("no acceptance test for %s", name)
+		}
+	}
+}
+
+func accProviderConfig() map[string]interface{} {
+	host := os.Getenv("ACCEPTANCE_PACT_BROKER_BASE_URL")
+	if host == "" {
+		host = "http://localhost"
+	}
+
+	if token := os.Getenv("ACCEPTANCE_PACT_BROKER_TOKEN"); token != "" {
+		return map[string]interface{}{"host": host, "access_token": token}
+	}
+
+	// The credentials of the broker in docker-compose.yml
+	return map[string]interface{}{
+		"host":                host,
+		"basic_auth_username": "pact_broker",
+		"basic_auth_password": "pact_broker",
+	}
+}
+
+func testAccResource(t *testing.T, provider *schema.Provider, meta interface{}, c accTestCase) {
+	r := provider.ResourcesMap[c.resource]
+	references := map[string]*terraform.InstanceState{}
+
+	for _, required := range c.requires {
+		dependency := provider.ResourcesMap[required.resource]
+		state := accApply(t, dependency, nil, required.config, meta)
+		references[required.resource] = state
+		defer accDestroy(t, dependency, state, meta)
+	}
+
+	var state *terraform.InstanceState
+	defer func() {
+		if state != nil {
+			accDestroy(t, r, state, meta)
+		}
+	}()
+
+	for i, step := range c.steps {
+		config := accResolve(t, step, references)
+
+		previous := state
+		state = accApply(t, r, state, config, meta)
+		if previous != nil && previous.ID != state.ID {
+			t.Fatalf("step %d: expected an in place update, but %s was replaced (%s to %s)", i+1, c.resource, previous.ID, state.ID)
+		}
+	}
+
+	if r.Importer != nil {
+		accImport(t, r, state, meta, c.importIgnore)
+	}
+
+	if !c.persistent {
+		accDestroy(t, r, state.DeepCopy(), meta)
+
+		refreshed, err := r.Refresh(state, meta)
+		if err != nil {
+			t.Fatalf("error refreshing %s after deleting it outside of Terraform: %s", c.resource, err)
+		}
+		if refreshed != nil && refreshed.ID != "" {
+			t.Fatalf("expected %s deleted outside of Terraform to be removed from state, got ID %q", c.resource, refreshed.ID)
+		}
+		state = nil
+	}
+}
+
+// accApply plans and applies config as Terraform would, then checks that a refresh leaves nothing to change
+func accApply(t *testing.T, r *schema.Resource, state *terraform.InstanceState, config map[string]interface{}, meta interface{}) *terraform.InstanceState {
+	t.Helper()
+
+	c := terraform.NewResourceConfigRaw(config)
+	if warns, errs := r.Validate(c); len(errs) > 0 {
+		t.Fatalf("invalid config %v: %v (warnings %v)", config, errs, warns)
+	}
+
+	diff, err := r.Diff(state, c, meta)
+	if err != nil {
+		t.Fatalf("error planning %v: %s", config, err)
+	}
+	if diff != nil && !diff.Empty() {
+		applied, err := r.Apply(state, diff, meta)
+		if err != nil {
+			t.Fatalf("error applying %v: %s", config, err)
+		}
+		state = applied
+	}
+
+	state, err = r.Refresh(state, meta)
+	if err != nil {
+		t.Fatalf("error refreshing %v: %s", config, err)
+	}
+	if state == nil || state.ID == "" {
+		t.Fatalf("expected %v to exist after it was applied", config)
+	}
+
+	diff, err = r.Diff(state, c, meta)
+	if err != nil {
+		t.Fatalf("error planning %v after it was applied: %s", config, err)
+	}
+	if diff != nil && !diff.Empty() {
+		t.Fatalf("expected an empty plan after applying %v, got %v", config, diff.Attributes)
+	}
+
+	return state
+}
+
+func accDestroy(t *testing.T, r *schema.Resource, state *terraform.InstanceState, meta interface{}) {
+	t.Helper()
+
+	if _, err := r.Apply(state, &terraform.InstanceDiff{Destroy: true}, meta); err != nil {
+		t.Errorf("error destroying %s: %s", state.ID, err)
+	}
+}
+
+// accImport imports the resource by its ID, and checks that it reads back the same state as was applied
+func accImport(t *testing.T, r *schema.Resource, state *terraform.InstanceState, meta interface{}, ignore []string) {
+	t.Helper()
+
+	imported, err := r.Importer.State(r.Data(&terraform.InstanceState{ID: state.ID}), meta)
+	if err != nil {
+		t.Fatalf("error importing %s: %s", state.ID, err)
+	}
+	if len(imported) != 1 {
+		t.Fatalf("expected importing %s to return a single resource, got %d", state.ID, len(imported))
+	}
+
+	refreshed, err := r.Refresh(imported[0].State(), meta)
+	if err != nil {
+		t.Fatalf("error reading imported %s: %s", state.ID, err)
+	}
+	if refreshed == nil {
+		t.Fatalf("expected imported %s to exist", state.ID)
+	}
+
+	applied, got := accImportable(state.Attributes, ignore), accImportable(refreshed.Attributes, ignore)
+	if !reflect.DeepEqual(applied, got) {
+		t.Fatalf("expected importing %s to read the applied state\napplied:  %v\nimported: %v", state.ID, applied, got)
+	}
+}
+
+func accImportable(attributes map[string]string, ignore []string) map[string]string {
+	importable := map[string]string{}
+
+attributes:
+	for k, v := range attributes {
+		for _, prefix := range append(ignore, "timeouts") {
+			if k == prefix || strings.HasPrefix(k, prefix+".") {
+				continue attributes
+			}
+		}
+		importable[k] = v
+	}
+
+	return importable
+}
+
+// accResolve replaces references to the attributes of required resources in config
+func accResolve(t *testing.T, config map[string]interface{}, references map[string]*terraform.InstanceState) map[string]interface{} {
+	t.Helper()
+
+	resolve := func(v interface{}) interface{} {
+		s, ok := v.(string)
+		if !ok {
+			return v
+		}
+		match := accReference.FindStringSubmatch(s)
+		if match == nil {
+			return v
+		}
+		state, ok := references[match[1]]
+		if !ok {
+			t.Fatalf("%s refers to %s, which is not required", s, match[1])
+		}
+
+		return state.Attributes[match[2]]
+	}
+
+	resolved := map[string]interface{}{}
+	for k, v := range config {
+		if list, ok := v.([]interface{}); ok {
+			values := make([]interface{}, len(list))
+			for i, item := range list {
+				values[i] = resolve(item)
+			}
+			v = values
+		}
+		resolved[k] = resolve(v)
+	}
+
+	return resolved
+}
@@ -0,0 +1,15 @@
+package broker
+
+// Announcement is the banner message displayed at the top of the broker UI
+type Announcement struct {
+	Message string `json:"message"`
+	Level   string `json:"level,omitempty"`
+	Enabled bool   `json:"enabled"`
+}
+
+// PUT /admin/tenant/announcement
+// {
+//   "message": "Scheduled maintenance on Saturday 10:00-11:00 UTC",
+//   "level": "warning",
+//   "enabled": true
+// }
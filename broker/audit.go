@@ -0,0 +1,45 @@
+package broker
+
+// AuditActor is the user or system account that performed an audited action
+type AuditActor struct {
+	UUID  string `json:"uuid"`
+	Name  string `json:"name,omitempty"`
+	Email string `json:"email,omitempty"`
+}
+
+// AuditEvent is a single administrative action recorded in the audit log
+type AuditEvent struct {
+	UUID        string      `json:"uuid"`
+	Type        string      `json:"type"`
+	Description string      `json:"description,omitempty"`
+	Actor       *AuditActor `json:"actor,omitempty"`
+	CreatedAt   string      `json:"createdAt"`
+}
+
+// AuditEventsResponse is the response body for listing audit events
+type AuditEventsResponse struct {
+	Embedded struct {
+		Events []AuditEvent `json:"events"`
+	} `json:"_embedded"`
+	HalDoc
+}
+
+// GET /audit?from=2023-03-01T00:00:00Z&to=2023-03-31T00:00:00Z
+// {
+//   "_embedded": {
+//     "events": [
+//       {
+//         "uuid": "0b6f5a3c-6a3e-4a8c-8a0e-2f5f6d7b1c9e",
+//         "type": "user.invited",
+//         "description": "Invited user jo@example.com",
+//         "actor": {
+//           "uuid": "5e4b3a2c-1d0f-4e8a-9b7c-6a5d4c3b2a1f",
+//           "name": "Admin",
+//           "email": "admin@example.com"
+//         },
+//         "createdAt": "2023-03-17T01:11:10+00:00"
+//       }
+//     ]
+//   },
+//   "_links": { ... }
+// }
@@ -0,0 +1,11 @@
+package broker
+
+// BadgeSettings controls access to the badges (e.g. for READMEs) on a Pactflow account
+type BadgeSettings struct {
+	PublicReadAccess bool `json:"publicReadAccess"`
+}
+
+// GET /admin/tenant/badge-settings
+// {
+//   "publicReadAccess": true
+// }
@@ -0,0 +1,31 @@
+package broker
+
+// Branch is a branch of a pacticipant's repository that versions have been published from
+type Branch struct {
+	Name      string `json:"name"`
+	CreatedAt string `json:"createdAt,omitempty"`
+	UpdatedAt string `json:"updatedAt,omitempty"`
+}
+
+// BranchesResponse is the response body for listing a pacticipant's branches
+type BranchesResponse struct {
+	Embedded struct {
+		Branches []Branch `json:"branches"`
+	} `json:"_embedded"`
+	HalDoc
+}
+
+// GET /pacticipants/:name/branches
+// {
+//   "_embedded": {
+//     "branches": [
+//       {
+//         "name": "main",
+//         "createdAt": "2023-03-17T01:11:10+00:00",
+//         "updatedAt": "2023-03-20T04:12:47+00:00",
+//         "_links": { ... }
+//       }
+//     ]
+//   },
+//   "_links": { ... }
+// }
@@ -0,0 +1,29 @@
+package broker
+
+// ChatIntegration sends notifications for broker events to a Slack or Microsoft Teams channel
+type ChatIntegration struct {
+	UUID       string         `json:"uuid,omitempty"`
+	Type       string         `json:"type"`
+	Workspace  string         `json:"workspace,omitempty"`
+	Channel    string         `json:"channel"`
+	WebhookURL string         `json:"webhookUrl,omitempty"`
+	TeamUUID   string         `json:"teamUuid,omitempty"`
+	Enabled    bool           `json:"enabled"`
+	Events     []WebhookEvent `json:"events"`
+}
+
+// ChatIntegrationResponse is the response body for any CRU methods
+type ChatIntegrationResponse struct {
+	ChatIntegration
+	HalDoc
+}
+
+// POST /admin/chat-integrations
+// {
+//   "type": "slack",
+//   "workspace": "pactflow",
+//   "channel": "#contract-testing",
+//   "webhookUrl": "https://hooks.slack.com/services/...",
+//   "enabled": true,
+//   "events": [{ "name": "provider_verification_failed" }]
+// }
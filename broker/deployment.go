@@ -0,0 +1,88 @@
+package broker
+
+// DeployedVersion is a pacticipant version deployed to (or released in) an environment
+type DeployedVersion struct {
+	UUID                string                       `json:"uuid"`
+	CurrentlyDeployed   bool                         `json:"currentlyDeployed,omitempty"`
+	CurrentlySupported  bool                         `json:"currentlySupported,omitempty"`
+	ApplicationInstance string                       `json:"applicationInstance,omitempty"`
+	CreatedAt           string                       `json:"createdAt,omitempty"`
+	Embedded            DeployedVersionEmbeddedItems `json:"_embedded"`
+}
+
+// RecordDeploymentRequest is the request body for recording the deployment of a pacticipant version
+type RecordDeploymentRequest struct {
+	ApplicationInstance string `json:"applicationInstance,omitempty"`
+}
+
+// DeployedVersionEmbeddedItems are the pacticipant and version of the deployment
+type DeployedVersionEmbeddedItems struct {
+	Pacticipant Pacticipant `json:"pacticipant"`
+	Version     Version     `json:"version"`
+}
+
+// DeployedVersionsResponse is the response body for listing the currently deployed versions
+type DeployedVersionsResponse struct {
+	Embedded struct {
+		DeployedVersions []DeployedVersion `json:"deployedVersions"`
+	} `json:"_embedded"`
+	HalDoc
+}
+
+// ReleasedVersionsResponse is the response body for listing the currently supported released versions
+type ReleasedVersionsResponse struct {
+	Embedded struct {
+		ReleasedVersions []DeployedVersion `json:"releasedVersions"`
+	} `json:"_embedded"`
+	HalDoc
+}
+
+// POST /pacticipants/:name/versions/:number/deployed-versions/environment/:uuid
+// {
+//   "applicationInstance": "customer-1"
+// }
+
+// GET /environments/:uuid/deployed-versions/currently-deployed
+// {
+//   "_embedded": {
+//     "deployedVersions": [
+//       {
+//         "uuid": "ff3adecf-cfc5-4653-a4e3-f1861092f8e0",
+//         "currentlyDeployed": true,
+//         "applicationInstance": "customer-1",
+//         "createdAt": "2021-09-08T05:08:13+00:00",
+//         "_embedded": {
+//           "pacticipant": {
+//             "name": "Foo"
+//           },
+//           "version": {
+//             "number": "1.2.3",
+//             "branch": "main"
+//           }
+//         }
+//       }
+//     ]
+//   }
+// }
+
+// GET /environments/:uuid/released-versions/currently-supported
+// {
+//   "_embedded": {
+//     "releasedVersions": [
+//       {
+//         "uuid": "fb4ac6b2-3c8c-4be5-8b8d-0f0e1f7a7b61",
+//         "currentlySupported": true,
+//         "createdAt": "2021-09-08T05:08:13+00:00",
+//         "_embedded": {
+//           "pacticipant": {
+//             "name": "Foo"
+//           },
+//           "version": {
+//             "number": "1.2.3",
+//             "branch": "main"
+//           }
+//         }
+//       }
+//     ]
+//   }
+// }
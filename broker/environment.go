@@ -8,9 +8,17 @@ type Environment struct {
 	CreatedAt   string                   `json:"createdAt,omitempty"`
 	UpdatedAt   string                   `json:"updatedAt,omitempty"`
 	UUID        string                   `json:"uuid,omitempty"`
+	Contacts    []EnvironmentContact     `json:"contacts,omitempty"`
 	Embedded    EnvironmentEmbeddedItems `json:"_embedded,omitempty"`
 }
 
+// EnvironmentContact is a person or team responsible for an environment. The details
+// are free form (e.g. emailAddress, slack) so are kept as arbitrary JSON
+type EnvironmentContact struct {
+	Name    string                 `json:"name"`
+	Details map[string]interface{} `json:"details,omitempty"`
+}
+
 type EnvironmentCreateOrUpdateRequest struct {
 	UUID        string   `json:"-"`
 	DisplayName string   `json:"displayName,omitempty"`
@@ -34,6 +42,14 @@ type EnvironmentEmbeddedItems struct {
 	Teams []Team `json:"teams,omitempty"`
 }
 
+// EnvironmentsResponse is the response body for listing environments
+type EnvironmentsResponse struct {
+	Embedded struct {
+		Environments []Environment `json:"environments"`
+	} `json:"_embedded"`
+	HalDoc
+}
+
 // POST environments
 //  {"uuid":"2739c79b-a6ba-4398-be7a-85ec96f79fbe","name":"test1","displayName":"test1 with teams","production":false,"createdAt":"2022-03-07T12:22:05+00:00","teamUuids":["6d746ad5-919f-49e3-84c0-648cafc5d912"],"_embedded":{"teams":[{"uuid":"6d746ad5-919f-49e3-84c0-648cafc5d912","name":"Pactflow Demos","_links":{"self":{"title":"Team","href":"https://testdemo.pactflow.io/admin/teams/6d746ad5-919f-49e3-84c0-648cafc5d912"}}}]},"_links":{"self":{"title":"Environment","name":"test1","href":"https://testdemo.pactflow.io/environments/2739c79b-a6ba-4398-be7a-85ec96f79fbe"},"pb:currently-deployed-deployed-versions":{"title":"Versions currently deployed to test1 with teams environment","href":"https://testdemo.pactflow.io/environments/2739c79b-a6ba-4398-be7a-85ec96f79fbe/deployed-versions/currently-deployed"},"pb:currently-supported-released-versions":{"title":"Versions released and supported in test1 with teams environment","href":"https://testdemo.pactflow.io/environments/2739c79b-a6ba-4398-be7a-85ec96f79fbe/released-versions/currently-supported"},"pb:environments":{"title":"Environments","href":"https://testdemo.pactflow.io/environments"}}}
 
@@ -48,6 +64,15 @@ type EnvironmentEmbeddedItems struct {
 // 	"production": true,
 // 	"updatedAt": "2022-03-02T09:29:02+00:00",
 // 	"createdAt": "2022-03-02T09:28:34+00:00",
+// 	"contacts": [
+// 			{
+// 					"name": "Team Awesome",
+// 					"details": {
+// 							"emailAddress": "awesome@example.com",
+// 							"slack": "#team-awesome"
+// 					}
+// 			}
+// 	],
 // 	"_embedded": {
 // 			"teams": [
 // 					{
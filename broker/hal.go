@@ -1,5 +1,7 @@
 package broker
 
+import "encoding/json"
+
 type Headers map[string]string
 
 // Link represents a link to a resource
@@ -12,7 +14,37 @@ type Link struct {
 // HalLinks represents the _links key in a HAL document.
 type HalLinks map[string]Link
 
+// UnmarshalJSON skips relations that are a list of links (e.g. curies), rather than failing to decode
+// the whole document
+func (l *HalLinks) UnmarshalJSON(data []byte) error {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	links := make(HalLinks, len(raw))
+	for rel, value := range raw {
+		var link Link
+		if json.Unmarshal(value, &link) == nil {
+			links[rel] = link
+		}
+	}
+	*l = links
+
+	return nil
+}
+
 // HalDoc is a simple representation of the HAL response from a Pact Broker.
 type HalDoc struct {
 	Links HalLinks `json:"_links"`
 }
+
+// Page is one page of a collection. The broker links each page to the one after it with a next relation
+type Page interface {
+	NextPage() string
+}
+
+// NextPage returns the href of the next page of the collection, or "" on the last page
+func (h HalDoc) NextPage() string {
+	return h.Links["next"].Href
+}
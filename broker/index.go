@@ -0,0 +1,57 @@
+package broker
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// Index is the API index at the root of the broker. Its relations describe the features the broker
+// supports. Most relations are a single link, but some (e.g. curies) are a list, so they are decoded
+// on demand
+type Index struct {
+	Links map[string]json.RawMessage `json:"_links"`
+}
+
+// Link returns the link for a relation, and whether the index has a single link for it
+func (i Index) Link(rel string) (Link, bool) {
+	var link Link
+	raw, ok := i.Links[rel]
+	if !ok || json.Unmarshal(raw, &link) != nil {
+		return link, false
+	}
+
+	return link, true
+}
+
+// IsPactflow reports whether the index is from PactFlow rather than an OSS Pact Broker. PactFlow adds its
+// own relations, with a pf: prefix, to the ones it shares with the OSS broker
+func (i Index) IsPactflow() bool {
+	for rel := range i.Links {
+		if strings.HasPrefix(rel, "pf:") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// GET /
+// {
+//   "_links": {
+//     "self": {
+//       "href": "https://broker.example.com",
+//       "title": "Index"
+//     },
+//     "pb:pacticipants": {
+//       "href": "https://broker.example.com/pacticipants",
+//       "title": "Pacticipants"
+//     },
+//     "curies": [
+//       {
+//         "name": "pb",
+//         "href": "https://broker.example.com/doc/{rel}?context=index",
+//         "templated": true
+//       }
+//     ]
+//   }
+// }
@@ -0,0 +1,33 @@
+package broker
+
+// Integration is a consumer and provider pair that have a pact between them
+type Integration struct {
+	Consumer              Pacticipant `json:"consumer"`
+	Provider              Pacticipant `json:"provider"`
+	VerificationStatus    string      `json:"verificationStatus,omitempty"`
+	LatestPactPublishedAt string      `json:"latestPactPublishedAt,omitempty"`
+}
+
+// IntegrationsResponse is the response body for listing integrations
+type IntegrationsResponse struct {
+	Embedded struct {
+		Integrations []Integration `json:"integrations"`
+	} `json:"_embedded"`
+	HalDoc
+}
+
+// GET /integrations
+// {
+//   "_embedded": {
+//     "integrations": [
+//       {
+//         "consumer": { "name": "Foo", "_links": { ... } },
+//         "provider": { "name": "Bar", "_links": { ... } },
+//         "verificationStatus": "success",
+//         "latestPactPublishedAt": "2023-03-17T01:11:10+00:00",
+//         "_links": { ... }
+//       }
+//     ]
+//   },
+//   "_links": { ... }
+// }
@@ -0,0 +1,101 @@
+package broker
+
+// MatrixSelector selects the pacticipant versions to query the matrix for
+type MatrixSelector struct {
+	Pacticipant string
+	Version     string
+	Branch      string
+	Tag         string
+	Latest      bool
+	MainBranch  bool
+}
+
+// MatrixQuery is a query against the matrix of consumer and provider versions
+type MatrixQuery struct {
+	Selectors   []MatrixSelector
+	LatestBy    string
+	Environment string
+	Limit       int
+}
+
+// MatrixVersion is the version of a pacticipant in a matrix row
+type MatrixVersion struct {
+	Number string `json:"number"`
+	Branch string `json:"branch,omitempty"`
+}
+
+// MatrixPacticipant is a pacticipant and version in a matrix row
+type MatrixPacticipant struct {
+	Name    string        `json:"name"`
+	Version MatrixVersion `json:"version"`
+}
+
+// MatrixPact is the pact in a matrix row
+type MatrixPact struct {
+	CreatedAt string `json:"createdAt"`
+}
+
+// MatrixVerificationResult is the verification result in a matrix row, if any
+type MatrixVerificationResult struct {
+	Success    bool   `json:"success"`
+	VerifiedAt string `json:"verifiedAt"`
+}
+
+// MatrixRow is a single consumer version/provider version pair
+type MatrixRow struct {
+	Consumer           MatrixPacticipant         `json:"consumer"`
+	Provider           MatrixPacticipant         `json:"provider"`
+	Pact               MatrixPact                `json:"pact"`
+	VerificationResult *MatrixVerificationResult `json:"verificationResult"`
+}
+
+// MatrixSummary summarises the rows, and whether the selected versions are deployable
+type MatrixSummary struct {
+	Deployable *bool  `json:"deployable"`
+	Reason     string `json:"reason"`
+	Success    int    `json:"success"`
+	Failed     int    `json:"failed"`
+	Unknown    int    `json:"unknown"`
+}
+
+// MatrixResponse is the response body for a matrix query
+type MatrixResponse struct {
+	Summary MatrixSummary `json:"summary"`
+	Matrix  []MatrixRow   `json:"matrix"`
+}
+
+// GET /matrix?q[][pacticipant]=Foo&q[][version]=1.2.3&q[][pacticipant]=Bar&q[][latest]=true&latestby=cvpv
+// {
+//   "summary": {
+//     "deployable": true,
+//     "reason": "All required verification results are published and successful",
+//     "success": 1,
+//     "failed": 0,
+//     "unknown": 0
+//   },
+//   "matrix": [
+//     {
+//       "consumer": {
+//         "name": "Foo",
+//         "version": {
+//           "number": "1.2.3",
+//           "branch": "main"
+//         }
+//       },
+//       "provider": {
+//         "name": "Bar",
+//         "version": {
+//           "number": "4.5.6",
+//           "branch": "main"
+//         }
+//       },
+//       "pact": {
+//         "createdAt": "2023-03-17T01:11:10+00:00"
+//       },
+//       "verificationResult": {
+//         "success": true,
+//         "verifiedAt": "2023-03-17T01:15:10+00:00"
+//       }
+//     }
+//   ]
+// }
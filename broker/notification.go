@@ -0,0 +1,17 @@
+package broker
+
+// NotificationSettings configures the email notifications sent by Pactflow, either for the
+// whole account or (when TeamUUID is set) for a single team
+type NotificationSettings struct {
+	TeamUUID                  string   `json:"-"`
+	VerificationFailureDigest bool     `json:"verificationFailureDigest"`
+	DigestFrequency           string   `json:"digestFrequency,omitempty"`
+	Recipients                []string `json:"recipients"`
+}
+
+// GET /admin/tenant/notification-settings
+// {
+//   "verificationFailureDigest": true,
+//   "digestFrequency": "daily",
+//   "recipients": ["platform@example.com"]
+// }
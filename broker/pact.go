@@ -0,0 +1,56 @@
+package broker
+
+import "encoding/json"
+
+// Pact is a published pact document. Only the metadata is decoded, the interactions
+// (or messages, for message pacts) are kept raw so they can be counted
+type Pact struct {
+	Consumer     Pacticipant       `json:"consumer"`
+	Provider     Pacticipant       `json:"provider"`
+	Interactions []json.RawMessage `json:"interactions,omitempty"`
+	Messages     []json.RawMessage `json:"messages,omitempty"`
+	Metadata     struct {
+		PactSpecification struct {
+			Version string `json:"version"`
+		} `json:"pactSpecification"`
+	} `json:"metadata"`
+	CreatedAt string `json:"createdAt,omitempty"`
+	HalDoc
+}
+
+// PactVersionsResponse is the response body for listing the versions of the pact between a consumer and provider
+type PactVersionsResponse struct {
+	Links struct {
+		PactVersions []Link `json:"pb:pact-versions"`
+		Next         Link   `json:"next"`
+	} `json:"_links"`
+}
+
+// NextPage returns the href of the next page of pact versions, or "" on the last page
+func (p PactVersionsResponse) NextPage() string {
+	return p.Links.Next.Href
+}
+
+// GET /pacts/provider/:provider/consumer/:consumer/versions
+// {
+//   "_links": {
+//     "self": { ... },
+//     "pb:pact-versions": [
+//       {
+//         "href": "https://broker/pacts/provider/Bar/consumer/Foo/version/1.0.1",
+//         "title": "Pact version",
+//         "name": "1.0.1"
+//       }
+//     ]
+//   }
+// }
+
+// GET /pacts/provider/:provider/consumer/:consumer/version/:version
+// {
+//   "consumer": { "name": "Foo" },
+//   "provider": { "name": "Bar" },
+//   "interactions": [ ... ],
+//   "metadata": { "pactSpecification": { "version": "2.0.0" } },
+//   "createdAt": "2023-03-17T01:11:10+00:00",
+//   "_links": { ... }
+// }
@@ -1,8 +1,52 @@
 package broker
 
 type Pacticipant struct {
-	Name          string `json:"name,omitempty" pact:"example=terraform-client"`
-	RepositoryURL string `json:"repositoryUrl,omitempty" pact:"example=https://github.com/pactflow/terraform-provider-pact"`
-	MainBranch    string `json:"mainBranch,omitempty" pact:"example=main"`
-	DisplayName   string `json:"displayName,omitempty" pact:"example=terraform client"`
+	Name          string                    `json:"name,omitempty" pact:"example=terraform-client"`
+	RepositoryURL string                    `json:"repositoryUrl,omitempty" pact:"example=https://github.com/pactflow/terraform-provider-pact"`
+	MainBranch    string                    `json:"mainBranch,omitempty" pact:"example=main"`
+	DisplayName   string                    `json:"displayName,omitempty" pact:"example=terraform client"`
+	Embedded      *PacticipantEmbeddedItems `json:"_embedded,omitempty"`
 }
+
+// PacticipantResponse is a pacticipant as read from the broker, with its links and, on brokers that assign
+// one, its UUID
+type PacticipantResponse struct {
+	Pacticipant
+	UUID string `json:"uuid,omitempty"`
+	HalDoc
+}
+
+type PacticipantEmbeddedItems struct {
+	Labels []Label `json:"labels,omitempty"`
+}
+
+// Label is a free form tag applied to a pacticipant (e.g. a team or domain name)
+type Label struct {
+	Name string `json:"name"`
+}
+
+// PacticipantsResponse is the response body for listing pacticipants
+type PacticipantsResponse struct {
+	Embedded struct {
+		Pacticipants []Pacticipant `json:"pacticipants"`
+	} `json:"_embedded"`
+	HalDoc
+}
+
+// GET /pacticipants
+// {
+//   "_embedded": {
+//     "pacticipants": [
+//       {
+//         "name": "AdminUI",
+//         "displayName": "Admin UI",
+//         "repositoryUrl": "github.com/foo/admin",
+//         "mainBranch": "main",
+//         "_embedded": {
+//           "labels": [{ "name": "frontend" }]
+//         }
+//       }
+//     ]
+//   },
+//   "_links": { ... }
+// }
@@ -0,0 +1,80 @@
+package broker
+
+// ConsumerVersionSelector selects the consumer versions whose pacts should be verified
+type ConsumerVersionSelector struct {
+	MainBranch         bool   `json:"mainBranch,omitempty"`
+	Branch             string `json:"branch,omitempty"`
+	MatchingBranch     bool   `json:"matchingBranch,omitempty"`
+	FallbackBranch     string `json:"fallbackBranch,omitempty"`
+	Consumer           string `json:"consumer,omitempty"`
+	Tag                string `json:"tag,omitempty"`
+	Latest             bool   `json:"latest,omitempty"`
+	DeployedOrReleased bool   `json:"deployedOrReleased,omitempty"`
+	Deployed           bool   `json:"deployed,omitempty"`
+	Released           bool   `json:"released,omitempty"`
+	Environment        string `json:"environment,omitempty"`
+}
+
+// PactsForVerificationRequest is the request body for the pacts for verification query
+type PactsForVerificationRequest struct {
+	Provider                 string                    `json:"-"`
+	ConsumerVersionSelectors []ConsumerVersionSelector `json:"consumerVersionSelectors"`
+	ProviderVersionBranch    string                    `json:"providerVersionBranch,omitempty"`
+	IncludePendingStatus     bool                      `json:"includePendingStatus"`
+	IncludeWipPactsSince     string                    `json:"includeWipPactsSince,omitempty"`
+}
+
+// VerificationNotice is a message about why a pact was selected for verification
+type VerificationNotice struct {
+	When string `json:"when"`
+	Text string `json:"text"`
+}
+
+// VerificationProperties describe how the verification of a pact should be treated
+type VerificationProperties struct {
+	Pending bool                 `json:"pending"`
+	Wip     bool                 `json:"wip"`
+	Notices []VerificationNotice `json:"notices,omitempty"`
+}
+
+// PactForVerification is a pact selected for verification
+type PactForVerification struct {
+	ShortDescription       string                 `json:"shortDescription"`
+	VerificationProperties VerificationProperties `json:"verificationProperties"`
+	HalDoc
+}
+
+// PactsForVerificationResponse is the response body for the pacts for verification query
+type PactsForVerificationResponse struct {
+	Embedded struct {
+		Pacts []PactForVerification `json:"pacts"`
+	} `json:"_embedded"`
+	HalDoc
+}
+
+// POST /pacts/provider/Bar/for-verification
+// {"consumerVersionSelectors":[{"mainBranch":true},{"deployedOrReleased":true}],"providerVersionBranch":"main","includePendingStatus":true}
+// {
+//   "_embedded": {
+//     "pacts": [
+//       {
+//         "shortDescription": "latest from main branch",
+//         "verificationProperties": {
+//           "pending": false,
+//           "notices": [
+//             {
+//               "when": "before_verification",
+//               "text": "The pact at ... is being verified because it matches the following configured selection criterion: latest pact for a consumer version from the main branch"
+//             }
+//           ]
+//         },
+//         "_links": {
+//           "self": {
+//             "href": "https://testdemo.pactflow.io/pacts/provider/Bar/consumer/Foo/pact-version/1234/metadata/Y3ZuPWUxNWRhNDVk",
+//             "name": "Pact between Foo (e15da45d) and Bar"
+//           }
+//         }
+//       }
+//     ]
+//   }
+// }
@@ -0,0 +1,54 @@
+package broker
+
+// ProviderContractPublishRequest publishes a provider contract (e.g. an OAS) for bi-directional contract testing
+type ProviderContractPublishRequest struct {
+	Provider                 string           `json:"-"`
+	PacticipantVersionNumber string           `json:"pacticipantVersionNumber"`
+	Branch                   string           `json:"branch,omitempty"`
+	Tags                     []string         `json:"tags,omitempty"`
+	BuildURL                 string           `json:"buildUrl,omitempty"`
+	Contract                 ProviderContract `json:"contract"`
+}
+
+// ProviderContract is the contract document and the results of the provider verifying itself against it.
+// Content is base64 encoded when published
+type ProviderContract struct {
+	Content                 string                   `json:"content"`
+	ContentType             string                   `json:"contentType"`
+	Specification           string                   `json:"specification"`
+	SelfVerificationResults *SelfVerificationResults `json:"selfVerificationResults,omitempty"`
+}
+
+// SelfVerificationResults describes how the provider verified itself against its contract
+type SelfVerificationResults struct {
+	Success         bool   `json:"success"`
+	Content         string `json:"content,omitempty"`
+	ContentType     string `json:"contentType,omitempty"`
+	Format          string `json:"format,omitempty"`
+	Verifier        string `json:"verifier,omitempty"`
+	VerifierVersion string `json:"verifierVersion,omitempty"`
+}
+
+// ProviderContractResponse is the response body for reading a published provider contract
+type ProviderContractResponse struct {
+	ProviderContract
+	HalDoc
+}
+
+// POST /provider-contracts/provider/:provider/publish
+// {
+//   "pacticipantVersionNumber": "1.0.0",
+//   "branch": "main",
+//   "buildUrl": "https://ci/builds/1234",
+//   "contract": {
+//     "content": "b3BlbmFwaTogMy4wLjEK...",
+//     "contentType": "application/yaml",
+//     "specification": "oas",
+//     "selfVerificationResults": {
+//       "success": true,
+//       "content": "VGVzdHMgcGFzc2VkCg==",
+//       "contentType": "text/plain",
+//       "verifier": "schemathesis"
+//     }
+//   }
+// }
@@ -0,0 +1,24 @@
+package broker
+
+// ProviderState is a provider state declared by one or more of the latest pacts for a provider
+type ProviderState struct {
+	Name      string                 `json:"name"`
+	Params    map[string]interface{} `json:"params,omitempty"`
+	Consumers []string               `json:"consumers,omitempty"`
+}
+
+// ProviderStatesResponse is the response body for the aggregated provider states of a provider
+type ProviderStatesResponse struct {
+	ProviderStates []ProviderState `json:"providerStates"`
+}
+
+// GET /pacts/provider/:provider/provider-states
+// {
+//   "providerStates": [
+//     {
+//       "name": "an order with id 1 exists",
+//       "params": { "id": 1 },
+//       "consumers": ["Foo", "Bar"]
+//     }
+//   ]
+// }
@@ -6,6 +6,12 @@ type Role struct {
 	Permissions []Permission `json:"permissions,omitempty"`
 }
 
+// RolesResponse is the response body for listing roles
+type RolesResponse struct {
+	Roles []Role `json:"roles"`
+	HalDoc
+}
+
 type Permission struct {
 	Name        string `json:"name,omitempty"`
 	Scope       string `json:"scope,omitempty"`
@@ -5,7 +5,7 @@ type Secret struct {
 	TeamUUID    string `json:"teamUuid,omitempty"`
 	Name        string `json:"name"`
 	Description string `json:"description"`
-	Value       string `json:"value"`
+	Value       string `json:"value,omitempty"`
 }
 
 // curl 'https://dius.pact.dius.com.au/secrets' '{"name":"foo","description":"bar","value":"baz"}' --compressed
@@ -13,3 +13,11 @@ type SecretResponse struct {
 	Secret
 	HalDoc
 }
+
+// SecretsResponse is the response body for listing secrets. Secret values are never returned
+type SecretsResponse struct {
+	Embedded struct {
+		Secrets []SecretResponse `json:"secrets"`
+	} `json:"_embedded"`
+	HalDoc
+}
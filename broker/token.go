@@ -5,6 +5,9 @@ type APIToken struct {
 	UUID        string `json:"uuid,omitempty"`
 	Description string `json:"description,omitempty"`
 	Value       string `json:"value,omitempty"`
+	CreatedAt   string `json:"createdAt,omitempty"`
+	LastUsedAt  string `json:"lastUsedAt,omitempty"`
+	ExpiresAt   string `json:"expiresAt,omitempty"`
 }
 
 // APITokensEmbedded contains the embedded links in the resource
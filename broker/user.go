@@ -34,6 +34,7 @@ type User struct {
 // Users is a list of User objects to manage
 type Users struct {
 	Users []User `json:"users"`
+	HalDoc
 }
 
 // SetUserRolesRequest is used to set roles to a given user
@@ -0,0 +1,18 @@
+package broker
+
+// VerificationResult is the result of a provider verifying a pact
+type VerificationResult struct {
+	Success                    bool   `json:"success"`
+	ProviderApplicationVersion string `json:"providerApplicationVersion"`
+	BuildURL                   string `json:"buildUrl,omitempty"`
+	VerificationDate           string `json:"verificationDate,omitempty"`
+}
+
+// GET /pacts/provider/Bar/consumer/Foo/pact-version/:sha/verification-results/latest
+// {
+//   "success": true,
+//   "providerApplicationVersion": "4.5.6",
+//   "buildUrl": "https://ci.example.com/builds/1234",
+//   "verificationDate": "2023-03-17T01:15:10+00:00",
+//   "_links": { ... }
+// }
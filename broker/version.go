@@ -0,0 +1,69 @@
+package broker
+
+// Version is a version of a pacticipant (application)
+type Version struct {
+	Number    string `json:"number"`
+	Branch    string `json:"branch,omitempty"`
+	BuildURL  string `json:"buildUrl,omitempty"`
+	CreatedAt string `json:"createdAt,omitempty"`
+
+	Embedded *VersionEmbeddedItems `json:"_embedded,omitempty"`
+}
+
+// VersionEmbeddedItems are the tags applied to a version
+type VersionEmbeddedItems struct {
+	Tags []Tag `json:"tags,omitempty"`
+}
+
+// VersionCreateOrUpdateRequest is the request body for publishing a pacticipant version
+type VersionCreateOrUpdateRequest struct {
+	Branch   string `json:"branch,omitempty"`
+	BuildURL string `json:"buildUrl,omitempty"`
+}
+
+// Tag is a (legacy) label applied to a pacticipant version, superseded by branches and environments
+type Tag struct {
+	Name string `json:"name"`
+}
+
+// VersionsResponse is the response body for listing a pacticipant's versions, newest first
+type VersionsResponse struct {
+	Embedded struct {
+		Versions []Version `json:"versions"`
+	} `json:"_embedded"`
+	HalDoc
+}
+
+// GET /pacticipants/:name/latest-version
+// {
+//   "number": "e15da45d3943bf10793a6d04cfb9f5dabe430fe2",
+//   "branch": "main",
+//   "buildUrl": "https://github.com/pactflow/example-consumer/actions/runs/1234",
+//   "createdAt": "2023-03-17T01:11:10+00:00",
+//   "_links": { ... }
+// }
+
+// PUT /pacticipants/:name/versions/:number
+// {
+//   "branch": "main",
+//   "buildUrl": "https://github.com/pactflow/example-consumer/actions/runs/1234"
+// }
+
+// GET /pacticipants/:name/versions
+// {
+//   "_embedded": {
+//     "versions": [
+//       {
+//         "number": "e15da45d3943bf10793a6d04cfb9f5dabe430fe2",
+//         "createdAt": "2023-03-17T01:11:10+00:00",
+//         "_embedded": {
+//           "tags": [
+//             {
+//               "name": "prod"
+//             }
+//           ]
+//         }
+//       }
+//     ]
+//   }
+// }
@@ -32,4 +32,42 @@ type Webhook struct {
 type WebhookResponse struct {
 	Webhook
 	HalDoc
+
+	// ETag identifies the version of the webhook that was returned, if the broker supports it. It comes
+	// from the response headers rather than the body
+	ETag string `json:"-"`
+}
+
+// WebhooksResponse is the response body for listing webhooks. The broker returns links to each webhook,
+// and some brokers also embed the webhooks themselves
+type WebhooksResponse struct {
+	Links struct {
+		Webhooks []Link `json:"pb:webhooks"`
+		Next     Link   `json:"next"`
+	} `json:"_links"`
+	Embedded struct {
+		Webhooks []WebhookResponse `json:"webhooks,omitempty"`
+	} `json:"_embedded"`
 }
+
+// NextPage returns the href of the next page of webhooks, or "" on the last page
+func (w WebhooksResponse) NextPage() string {
+	return w.Links.Next.Href
+}
+
+// GET /webhooks
+// {
+//   "_links": {
+//     "self": {
+//       "title": "Webhooks",
+//       "href": "https://testdemo.pactflow.io/webhooks"
+//     },
+//     "pb:webhooks": [
+//       {
+//         "title": "A webhook for the pact between terraform-client and terraform-provider",
+//         "name": "notify the team",
+//         "href": "https://testdemo.pactflow.io/webhooks/2e9a0a2e-ebd5-4e3e-a9a8-0d5d8d8d8d8d"
+//       }
+//     ]
+//   }
+// }
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/pactflow/terraform/broker"
+	"github.com/pactflow/terraform/client"
+)
+
+// The types of broker the provider can be configured for with broker_type
+const (
+	pactflowBrokerType = "pactflow"
+	ossBrokerType      = "oss"
+)
+
+var allowedBrokerTypes = []string{
+	pactflowBrokerType,
+	ossBrokerType,
+}
+
+func isOSSBroker(meta interface{}) bool {
+	c, ok := meta.(*client.Client)
+	return ok && c.Config.BrokerType == ossBrokerType
+}
+
+func notSupportedByBroker(kind string, meta interface{}) error {
+	if c := meta.(*client.Client); c.Config.BrokerTypeDetected {
+		return fmt.Errorf("%s is not supported by this broker: it is only available in PactFlow, but %s is an OSS Pact Broker (detected from its API index, set broker_type = %q if this is wrong)", kind, c.Config.BaseURL, pactflowBrokerType)
+	}
+
+	return fmt.Errorf("%s is not supported by this broker: it is only available in PactFlow, but the provider is configured for an OSS Pact Broker (broker_type = %q)", kind, ossBrokerType)
+}
+
+// detectBrokerType sets the broker type from the relations in the API index when broker_type isn't
+// configured, so that resources only PactFlow supports fail to plan against an OSS broker without it
+func detectBrokerType(c *client.Client, index *broker.Index) {
+	detected := ossBrokerType
+	if index.IsPactflow() {
+		detected = pactflowBrokerType
+	}
+
+	switch c.Config.BrokerType {
+	case "":
+		logInfo("", nil, "detected the broker type from the API index", "broker_type", detected)
+		c.Config.BrokerType, c.Config.BrokerTypeDetected = detected, true
+	case detected:
+	default:
+		logWarn("", nil, "the configured broker_type doesn't match the type detected from the API index", "broker_type", c.Config.BrokerType, "detected", detected)
+	}
+}
+
+// pactflowOnly gates a resource or data source that only PactFlow supports, so that using it against an
+// OSS Pact Broker fails the plan with a clear error instead of a 404 from the broker. Only plans to create
+// or update a resource are gated, so that existing state can still be refreshed and destroyed
+func pactflowOnly(kind string, r *schema.Resource) *schema.Resource {
+	check := func(meta interface{}) error {
+		if isOSSBroker(meta) {
+			return notSupportedByBroker(kind, meta)
+		}
+		return nil
+	}
+
+	if read := r.Read; read != nil && r.Create == nil {
+		r.Read = func(d *schema.ResourceData, meta interface{}) error {
+			if err := check(meta); err != nil {
+				return err
+			}
+			return read(d, meta)
+		}
+	}
+
+	if create := r.Create; create != nil {
+		r.Create = func(d *schema.ResourceData, meta interface{}) error {
+			if err := check(meta); err != nil {
+				return err
+			}
+			return create(d, meta)
+		}
+
+		customizeDiff := r.CustomizeDiff
+		r.CustomizeDiff = func(d *schema.ResourceDiff, meta interface{}) error {
+			if err := check(meta); err != nil {
+				return err
+			}
+			if customizeDiff != nil {
+				return customizeDiff(d, meta)
+			}
+			return nil
+		}
+	}
+
+	return r
+}
+
+// pactflowOnlyAttributes fails the plan when any of the given attributes, which only PactFlow supports,
+// are set while the provider is configured for an OSS Pact Broker
+func pactflowOnlyAttributes(keys ...string) schema.CustomizeDiffFunc {
+	return func(d *schema.ResourceDiff, meta interface{}) error {
+		if !isOSSBroker(meta) {
+			return nil
+		}
+
+		for _, k := range keys {
+			if _, ok := d.GetOk(k); ok {
+				return notSupportedByBroker(fmt.Sprintf("%q", k), meta)
+			}
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,326 @@
+// Package brokertest provides an in-memory Pact Broker, so that resources can be tested end to end through
+// the real client without a live broker.
+//
+// It implements enough of the broker API for pacticipants and webhooks, and lets tests change the stored
+// entities behind the provider's back to simulate drift:
+//
+//	server := brokertest.NewServer()
+//	defer server.Close()
+//
+//	server.DeleteWebhook(uuid) // the next refresh should remove the webhook from state
+package brokertest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/pactflow/terraform/broker"
+)
+
+// Server is an in-memory broker served over HTTP. It is safe for concurrent use
+type Server struct {
+	*httptest.Server
+
+	mu           sync.Mutex
+	pacticipants map[string]broker.Pacticipant
+	webhooks     map[string]broker.Webhook
+	versions     map[string]int
+	nextID       int
+}
+
+// NewServer starts an empty broker. Close it when the test is done
+func NewServer() *Server {
+	s := &Server{
+		pacticipants: map[string]broker.Pacticipant{},
+		webhooks:     map[string]broker.Webhook{},
+		versions:     map[string]int{},
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+
+	return s
+}
+
+// Pacticipant returns the stored pacticipant with the given name
+func (s *Server) Pacticipant(name string) (broker.Pacticipant, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	p, ok := s.pacticipants[name]
+	return p, ok
+}
+
+// PutPacticipant stores a pacticipant, replacing any with the same name
+func (s *Server) PutPacticipant(p broker.Pacticipant) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.pacticipants[p.Name] = p
+}
+
+// DeletePacticipant removes a pacticipant, e.g. to simulate it being deleted outside of Terraform
+func (s *Server) DeletePacticipant(name string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.pacticipants, name)
+}
+
+// Webhook returns the stored webhook with the given UUID, including its password
+func (s *Server) Webhook(uuid string) (broker.Webhook, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	w, ok := s.webhooks[uuid]
+	return w, ok
+}
+
+// PutWebhook stores a webhook under the given UUID, replacing any already there. Its ETag changes, as
+// it would when the webhook is edited in the UI
+func (s *Server) PutWebhook(uuid string, w broker.Webhook) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	w.ID = uuid
+	s.webhooks[uuid] = w
+	s.versions[uuid]++
+}
+
+// DeleteWebhook removes a webhook, e.g. to simulate it being deleted outside of Terraform
+func (s *Server) DeleteWebhook(uuid string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.webhooks, uuid)
+	delete(s.versions, uuid)
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	segments := strings.Split(strings.Trim(r.URL.EscapedPath(), "/"), "/")
+	for i, segment := range segments {
+		segments[i], _ = url.PathUnescape(segment)
+	}
+
+	switch {
+	case len(segments) == 1 && segments[0] == "":
+		s.index(w, r)
+	case segments[0] == "pacticipants" && len(segments) == 1:
+		s.pacticipantCollection(w, r)
+	case segments[0] == "pacticipants" && len(segments) == 2:
+		s.pacticipant(w, r, segments[1])
+	case segments[0] == "webhooks" && len(segments) == 1:
+		s.webhookCollection(w, r)
+	case segments[0] == "webhooks" && len(segments) == 2:
+		s.webhook(w, r, segments[1])
+	default:
+		notFound(w)
+	}
+}
+
+func (s *Server) index(w http.ResponseWriter, r *http.Request) {
+	respond(w, http.StatusOK, map[string]interface{}{
+		"_links": map[string]broker.Link{
+			"self":            {Href: s.URL},
+			"pb:pacticipants": {Href: s.URL + "/pacticipants"},
+			"pb:pacticipant":  {Href: s.URL + "/pacticipants/{pacticipant}"},
+			"pb:webhooks":     {Href: s.URL + "/webhooks"},
+			"pb:webhook":      {Href: s.URL + "/webhooks/{uuid}"},
+		},
+	})
+}
+
+func (s *Server) pacticipantCollection(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		names := make([]string, 0, len(s.pacticipants))
+		for name := range s.pacticipants {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		res := broker.PacticipantsResponse{}
+		for _, name := range names {
+			res.Embedded.Pacticipants = append(res.Embedded.Pacticipants, s.pacticipants[name])
+		}
+		respond(w, http.StatusOK, res)
+	case http.MethodPost:
+		var p broker.Pacticipant
+		if !decode(w, r, &p) {
+			return
+		}
+		if _, ok := s.pacticipants[p.Name]; ok {
+			respond(w, http.StatusConflict, errorsBody("name", fmt.Sprintf("A pacticipant with name '%s' already exists", p.Name)))
+			return
+		}
+		s.pacticipants[p.Name] = p
+		respond(w, http.StatusCreated, p)
+	default:
+		methodNotAllowed(w)
+	}
+}
+
+func (s *Server) pacticipant(w http.ResponseWriter, r *http.Request, name string) {
+	existing, ok := s.pacticipants[name]
+	if !ok {
+		notFound(w)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		respond(w, http.StatusOK, existing)
+	case http.MethodPatch:
+		var p broker.Pacticipant
+		if !decode(w, r, &p) {
+			return
+		}
+		if p.Name == "" {
+			p.Name = name
+		}
+		delete(s.pacticipants, name)
+		s.pacticipants[p.Name] = p
+		respond(w, http.StatusOK, p)
+	case http.MethodDelete:
+		delete(s.pacticipants, name)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		methodNotAllowed(w)
+	}
+}
+
+func (s *Server) webhookCollection(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		uuids := make([]string, 0, len(s.webhooks))
+		for uuid := range s.webhooks {
+			uuids = append(uuids, uuid)
+		}
+		sort.Strings(uuids)
+
+		res := broker.WebhooksResponse{}
+		for _, uuid := range uuids {
+			res.Links.Webhooks = append(res.Links.Webhooks, broker.Link{
+				Href:  s.webhookURL(uuid),
+				Title: s.webhooks[uuid].Description,
+			})
+		}
+		respond(w, http.StatusOK, res)
+	case http.MethodPost:
+		var webhook broker.Webhook
+		if !decode(w, r, &webhook) {
+			return
+		}
+		s.nextID++
+		webhook.ID = fmt.Sprintf("00000000-0000-0000-0000-%012d", s.nextID)
+		s.webhooks[webhook.ID] = webhook
+		s.versions[webhook.ID]++
+		s.setETag(w, webhook.ID)
+		respond(w, http.StatusCreated, s.webhookResponse(webhook))
+	default:
+		methodNotAllowed(w)
+	}
+}
+
+func (s *Server) webhook(w http.ResponseWriter, r *http.Request, uuid string) {
+	existing, ok := s.webhooks[uuid]
+	if !ok {
+		notFound(w)
+		return
+	}
+
+	if match := r.Header.Get("If-Match"); match != "" && match != s.etag(uuid) {
+		respond(w, http.StatusPreconditionFailed, errorMessage("The webhook has been modified since it was read"))
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		s.setETag(w, uuid)
+		respond(w, http.StatusOK, s.webhookResponse(existing))
+	case http.MethodPut:
+		var webhook broker.Webhook
+		if !decode(w, r, &webhook) {
+			return
+		}
+		webhook.ID = uuid
+		s.webhooks[uuid] = webhook
+		s.versions[uuid]++
+		s.setETag(w, uuid)
+		respond(w, http.StatusOK, s.webhookResponse(webhook))
+	case http.MethodDelete:
+		delete(s.webhooks, uuid)
+		delete(s.versions, uuid)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		methodNotAllowed(w)
+	}
+}
+
+// webhookResponse is a webhook as the broker returns it: linked to itself, and without the password
+func (s *Server) webhookResponse(webhook broker.Webhook) broker.WebhookResponse {
+	webhook.Request.Password = ""
+
+	return broker.WebhookResponse{
+		Webhook: webhook,
+		HalDoc: broker.HalDoc{
+			Links: broker.HalLinks{
+				"self": {Href: s.webhookURL(webhook.ID), Title: webhook.Description},
+			},
+		},
+	}
+}
+
+func (s *Server) etag(uuid string) string {
+	return fmt.Sprintf(`"%d"`, s.versions[uuid])
+}
+
+func (s *Server) setETag(w http.ResponseWriter, uuid string) {
+	w.Header().Set("ETag", s.etag(uuid))
+}
+
+func (s *Server) webhookURL(uuid string) string {
+	return s.URL + "/webhooks/" + uuid
+}
+
+func decode(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		respond(w, http.StatusBadRequest, errorsBody("body", err.Error()))
+		return false
+	}
+
+	return true
+}
+
+func errorsBody(key, message string) map[string]interface{} {
+	return map[string]interface{}{
+		"errors": map[string][]string{key: {message}},
+	}
+}
+
+func errorMessage(message string) map[string]interface{} {
+	return map[string]interface{}{
+		"error": map[string]string{"message": message},
+	}
+}
+
+func notFound(w http.ResponseWriter) {
+	respond(w, http.StatusNotFound, errorMessage("The requested document was not found on this server."))
+}
+
+func methodNotAllowed(w http.ResponseWriter) {
+	respond(w, http.StatusMethodNotAllowed, errorMessage("Method not allowed"))
+}
+
+func respond(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/hal+json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
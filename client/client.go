@@ -2,22 +2,27 @@ package client
 
 import (
 	"bytes"
+	"context"
 	"crypto/tls"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"io/ioutil"
-	"log"
+	"mime"
 	"net/http"
 	"net/url"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/pactflow/terraform/broker"
 	"github.com/pactflow/terraform/version"
 )
 
 const (
-	userAgent                           = "go-pact/" + version.LIBRARY_VERSION
+	userAgent                           = "terraform-provider-pact/" + version.LIBRARY_VERSION
+	problemJSONMediaType                = "application/problem+json"
 	defaultBaseURL                      = "http://localhost"
 	webhookReadUpdateDeleteTemplate     = "/webhooks/%s"
 	webhookCreateTemplate               = "/webhooks"
@@ -43,6 +48,33 @@ const (
 	metadataTemplate                    = "/"
 	environmentCreateTemplate           = "/environments"
 	environmentReadUpdateDeleteTemplate = "/environments/%s"
+	tenantNotificationSettingsTemplate  = "/admin/tenant/notification-settings"
+	teamNotificationSettingsTemplate    = "/admin/teams/%s/notification-settings"
+	providerContractPublishTemplate     = "/provider-contracts/provider/%s/publish"
+	providerContractReadDeleteTemplate  = "/contracts/provider/%s/version/%s"
+	tenantBadgeSettingsTemplate         = "/admin/tenant/badge-settings"
+	chatCreateTemplate                  = "/admin/chat-integrations"
+	chatReadUpdateDeleteTemplate        = "/admin/chat-integrations/%s"
+	tenantAnnouncementTemplate          = "/admin/tenant/announcement"
+	pacticipantLatestVersionTemplate    = "/pacticipants/%s/latest-version"
+	branchLatestVersionTemplate         = "/pacticipants/%s/branches/%s/latest-version"
+	tagLatestVersionTemplate            = "/pacticipants/%s/latest-version/%s"
+	matrixTemplate                      = "/matrix"
+	deployedVersionsTemplate            = "/environments/%s/deployed-versions/currently-deployed"
+	releasedVersionsTemplate            = "/environments/%s/released-versions/currently-supported"
+	latestPactTemplate                  = "/pacts/provider/%s/consumer/%s/latest"
+	pacticipantBranchesTemplate         = "/pacticipants/%s/branches"
+	pacticipantVersionsTemplate         = "/pacticipants/%s/versions"
+	pacticipantVersionTemplate          = "/pacticipants/%s/versions/%s"
+	pacticipantVersionTagTemplate       = "/pacticipants/%s/versions/%s/tags/%s"
+	recordDeploymentTemplate            = "/pacticipants/%s/versions/%s/deployed-versions/environment/%s"
+	pactsForVerificationTemplate        = "/pacts/provider/%s/for-verification"
+	userListTemplate                    = "/admin/users"
+	auditEventsTemplate                 = "/audit"
+	providerStatesTemplate              = "/pacts/provider/%s/provider-states"
+	integrationsTemplate                = "/integrations"
+	pactVersionsTemplate                = "/pacts/provider/%s/consumer/%s/versions"
+	pactVersionTemplate                 = "/pacts/provider/%s/consumer/%s/version/%s"
 )
 
 const (
@@ -62,6 +94,50 @@ type Config struct {
 	BasicAuthPassword string
 	BaseURL           *url.URL
 	CustomTLSConfig   *tls.Config
+
+	// RequestTimeout limits how long each request to the broker may take, including reading the response.
+	// Zero means no limit other than the deadline of the operation (see WithContext)
+	RequestTimeout time.Duration
+
+	// MaxRetries is how many times a request is retried when the broker responds with a 429, 502, 503 or
+	// 504 (only a 429, or a 503 with Retry-After, for a POST). Retries back off exponentially from
+	// RetryMinWait (default 1s) up to RetryMaxWait (default 30s), unless the response says how long to wait
+	// with a Retry-After header, which is also capped at RetryMaxWait
+	MaxRetries   int
+	RetryMinWait time.Duration
+	RetryMaxWait time.Duration
+
+	// UserAgentSuffix is appended to the User-Agent sent with every request, e.g. to identify the pipeline
+	// making changes in the broker's logs
+	UserAgentSuffix string
+
+	// DefaultHeaders are sent with every request. Headers the client sets itself (e.g. Authorization and
+	// Accept) take precedence
+	DefaultHeaders map[string]string
+
+	// ProxyURL routes requests through a proxy. When not set, the HTTPS_PROXY, HTTP_PROXY and NO_PROXY
+	// environment variables are used
+	ProxyURL *url.URL
+
+	// DryRun logs requests that would change the broker instead of sending them. Reads are still sent,
+	// including queries that are POSTed
+	DryRun bool
+
+	// AdoptExistingResources is not used by the client itself, it tells resources to take over
+	// existing broker content when a create conflicts with it
+	AdoptExistingResources bool
+
+	// RequireHTTPSWebhooks is not used by the client itself, it makes webhooks with a non HTTPS URL
+	// fail to plan
+	RequireHTTPSWebhooks bool
+
+	// BrokerType is not used by the client itself, it makes resources only PactFlow supports fail to
+	// plan against an OSS Pact Broker
+	BrokerType string
+
+	// BrokerTypeDetected is set when BrokerType was detected from the broker's API index, rather than
+	// configured
+	BrokerTypeDetected bool
 }
 
 // Client is the main Broker API interface.
@@ -70,6 +146,10 @@ type Client struct {
 	client    http.Client
 	Config    Config
 	UserAgent string
+	ctx       context.Context
+	index     *broker.Index
+	ifMatch   string
+	readOnly  bool
 }
 
 // NewClient creates a new Broker API client with sensible but overridable defaults
@@ -78,78 +158,247 @@ func NewClient(httpClient *http.Client, config Config) *Client {
 		httpClient = http.DefaultClient
 	}
 
-	if config.CustomTLSConfig != nil && config.CustomTLSConfig.InsecureSkipVerify {
-		httpClient.Transport = &http.Transport{
-			TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+	// Work on a copy, so that the TLS and proxy settings of one client (or provider instance) never leak
+	// into http.DefaultClient or the client passed in
+	c := *httpClient
+	if config.RequestTimeout > 0 {
+		c.Timeout = config.RequestTimeout
+	}
+	if config.CustomTLSConfig != nil || config.ProxyURL != nil {
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+		if t, ok := c.Transport.(*http.Transport); ok {
+			transport = t.Clone()
+		}
+		if config.CustomTLSConfig != nil {
+			transport.TLSClientConfig = config.CustomTLSConfig
+		}
+		if config.ProxyURL != nil {
+			transport.Proxy = http.ProxyURL(config.ProxyURL)
+		} else if transport.Proxy == nil {
+			transport.Proxy = http.ProxyFromEnvironment
 		}
+		c.Transport = transport
 	}
 
 	client := Client{
-		client:    *httpClient,
+		client:    c,
 		Config:    config,
 		UserAgent: userAgent,
 	}
+	if config.UserAgentSuffix != "" {
+		client.UserAgent = fmt.Sprintf("%s %s", userAgent, config.UserAgentSuffix)
+	}
 
 	return &client
 }
 
+// WithContext returns a copy of the client whose requests are bound to ctx, e.g. to enforce a deadline
+func (c *Client) WithContext(ctx context.Context) *Client {
+	copy := *c
+	copy.ctx = ctx
+
+	return &copy
+}
+
+// Context returns the context the client's requests are bound to, so that waiting between requests (e.g.
+// for a new resource to become readable) stops when it is done
+func (c *Client) Context() context.Context {
+	if c.ctx == nil {
+		return context.Background()
+	}
+
+	return c.ctx
+}
+
+// WithIfMatch returns a copy of the client that sends the ETag with its updates and deletes, so that the
+// broker rejects them with ErrPreconditionFailed if the entity has changed since the ETag was read. An
+// empty ETag (e.g. from a broker that doesn't return them) sends nothing
+func (c *Client) WithIfMatch(etag string) *Client {
+	copy := *c
+	copy.ifMatch = etag
+
+	return &copy
+}
+
+// asQuery returns a copy of the client whose requests only read from the broker, whatever their method
+// (e.g. a query POSTed because its parameters don't fit in a URL), so they are still sent in dry run mode
+func (c *Client) asQuery() *Client {
+	copy := *c
+	copy.readOnly = true
+
+	return &copy
+}
+
 // ReadWebhook returns a Webhook or an error for a given ID
-func (c *Client) ReadWebhook(id string) (*broker.Webhook, error) {
-	res, err := c.doCrud("GET", urlEncodeTemplate(webhookReadUpdateDeleteTemplate, id), nil, new(broker.Webhook))
-	return res.(*broker.Webhook), err
+func (c *Client) ReadWebhook(id string) (*broker.WebhookResponse, error) {
+	res := new(broker.WebhookResponse)
+	etag, err := c.doWithETag("GET", c.path(webhookReadUpdateDeleteTemplate, id), nil, res)
+	res.ETag = etag
+	return res, err
+}
+
+// ListWebhooks returns links to all webhooks in the broker, and the webhooks themselves if the broker embeds them
+func (c *Client) ListWebhooks() (*broker.WebhooksResponse, error) {
+	all := new(broker.WebhooksResponse)
+	err := c.doList(c.path(webhookCreateTemplate), func() broker.Page { return new(broker.WebhooksResponse) }, func(page broker.Page) {
+		all.Links.Webhooks = append(all.Links.Webhooks, page.(*broker.WebhooksResponse).Links.Webhooks...)
+		all.Embedded.Webhooks = append(all.Embedded.Webhooks, page.(*broker.WebhooksResponse).Embedded.Webhooks...)
+	})
+	return all, err
 }
 
 // CreateWebhook creates a new webhook
 func (c *Client) CreateWebhook(w broker.Webhook) (*broker.WebhookResponse, error) {
-	res, err := c.doCrud("POST", webhookCreateTemplate, w, new(broker.WebhookResponse))
-	return res.(*broker.WebhookResponse), err
+	res := new(broker.WebhookResponse)
+	etag, err := c.doWithETag("POST", c.path(webhookCreateTemplate), w, res)
+	res.ETag = etag
+	return res, err
 }
 
 // UpdateWebhook updates an existing webhook. Not all properties are mutable
 func (c *Client) UpdateWebhook(w broker.Webhook) (*broker.WebhookResponse, error) {
-	res, err := c.doCrud("PUT", urlEncodeTemplate(webhookReadUpdateDeleteTemplate, w.ID), w, new(broker.WebhookResponse))
-	return res.(*broker.WebhookResponse), err
+	res := new(broker.WebhookResponse)
+	etag, err := c.doWithETag("PUT", c.path(webhookReadUpdateDeleteTemplate, w.ID), w, res)
+	res.ETag = etag
+	return res, err
 }
 
 // DeleteWebhook removes an existing webhook
 func (c *Client) DeleteWebhook(w broker.Webhook) error {
-	_, err := c.doCrud("DELETE", urlEncodeTemplate(webhookReadUpdateDeleteTemplate, w.ID), nil, nil)
+	_, err := c.doCrud("DELETE", c.path(webhookReadUpdateDeleteTemplate, w.ID), nil, nil)
 	return err
 }
 
 // ReadPacticipant gets a pacticipant
-func (c *Client) ReadPacticipant(name string) (*broker.Pacticipant, error) {
-	res, err := c.doCrud("GET", urlEncodeTemplate(pacticipantReadUpdateDeleteTemplate, name), nil, new(broker.Pacticipant))
-	return res.(*broker.Pacticipant), err
+func (c *Client) ReadPacticipant(name string) (*broker.PacticipantResponse, error) {
+	res, err := c.doCrud("GET", c.path(pacticipantReadUpdateDeleteTemplate, name), nil, new(broker.PacticipantResponse))
+	return res.(*broker.PacticipantResponse), err
+}
+
+// ListPacticipants returns all pacticipants in the broker
+func (c *Client) ListPacticipants() (*broker.PacticipantsResponse, error) {
+	all := new(broker.PacticipantsResponse)
+	err := c.doList(c.path(pacticipantCreateTemplate), func() broker.Page { return new(broker.PacticipantsResponse) }, func(page broker.Page) {
+		all.Embedded.Pacticipants = append(all.Embedded.Pacticipants, page.(*broker.PacticipantsResponse).Embedded.Pacticipants...)
+	})
+	return all, err
+}
+
+// ReadLatestPacticipantVersion gets the most recently created version of a pacticipant
+func (c *Client) ReadLatestPacticipantVersion(name string) (*broker.Version, error) {
+	res, err := c.doCrud("GET", c.path(pacticipantLatestVersionTemplate, name), nil, new(broker.Version))
+	return res.(*broker.Version), err
+}
+
+// ListPacticipantVersions returns the versions of a pacticipant, newest first
+func (c *Client) ListPacticipantVersions(name string) (*broker.VersionsResponse, error) {
+	all := new(broker.VersionsResponse)
+	err := c.doList(c.path(pacticipantVersionsTemplate, name), func() broker.Page { return new(broker.VersionsResponse) }, func(page broker.Page) {
+		all.Embedded.Versions = append(all.Embedded.Versions, page.(*broker.VersionsResponse).Embedded.Versions...)
+	})
+	return all, err
+}
+
+// ListPacticipantBranches returns the branches versions of a pacticipant have been published from
+func (c *Client) ListPacticipantBranches(name string) (*broker.BranchesResponse, error) {
+	all := new(broker.BranchesResponse)
+	err := c.doList(c.path(pacticipantBranchesTemplate, name), func() broker.Page { return new(broker.BranchesResponse) }, func(page broker.Page) {
+		all.Embedded.Branches = append(all.Embedded.Branches, page.(*broker.BranchesResponse).Embedded.Branches...)
+	})
+	return all, err
+}
+
+// ReadLatestPacticipantVersionForBranch gets the most recently created version of a pacticipant on a branch
+func (c *Client) ReadLatestPacticipantVersionForBranch(name, branch string) (*broker.Version, error) {
+	res, err := c.doCrud("GET", c.path(branchLatestVersionTemplate, name, branch), nil, new(broker.Version))
+	return res.(*broker.Version), err
+}
+
+// ReadLatestPacticipantVersionForTag gets the most recently created version of a pacticipant with a tag
+func (c *Client) ReadLatestPacticipantVersionForTag(name, tag string) (*broker.Version, error) {
+	res, err := c.doCrud("GET", c.path(tagLatestVersionTemplate, name, tag), nil, new(broker.Version))
+	return res.(*broker.Version), err
+}
+
+// ReadIndex gets the API index, e.g. to check that the broker can be reached with the configured credentials
+func (c *Client) ReadIndex() (*broker.Index, error) {
+	res, err := c.doCrud("GET", c.path(metadataTemplate), nil, new(broker.Index))
+	return res.(*broker.Index), err
+}
+
+// ReadPacticipantVersion gets a version of a pacticipant, including its tags
+func (c *Client) ReadPacticipantVersion(name, number string) (*broker.Version, error) {
+	res, err := c.doCrud("GET", c.path(pacticipantVersionTemplate, name, number), nil, new(broker.Version))
+	return res.(*broker.Version), err
+}
+
+// CreateOrUpdatePacticipantVersion publishes a version of a pacticipant. The branch of an existing version
+// cannot be changed
+func (c *Client) CreateOrUpdatePacticipantVersion(name, number string, r broker.VersionCreateOrUpdateRequest) (*broker.Version, error) {
+	res, err := c.doCrud("PUT", c.path(pacticipantVersionTemplate, name, number), r, new(broker.Version))
+	return res.(*broker.Version), err
+}
+
+// TagPacticipantVersion applies a tag to a version of a pacticipant
+func (c *Client) TagPacticipantVersion(name, number, tag string) error {
+	_, err := c.doCrud("PUT", c.path(pacticipantVersionTagTemplate, name, number, tag), struct{}{}, new(broker.Tag))
+	return err
+}
+
+// DeletePacticipantVersionTag removes a tag from a version of a pacticipant
+func (c *Client) DeletePacticipantVersionTag(name, number, tag string) error {
+	_, err := c.doCrud("DELETE", c.path(pacticipantVersionTagTemplate, name, number, tag), nil, nil)
+	return err
+}
+
+// RecordDeployment records a version of a pacticipant as deployed to an environment
+func (c *Client) RecordDeployment(name, number, environmentUUID string, r broker.RecordDeploymentRequest) (*broker.DeployedVersion, error) {
+	res, err := c.doCrud("POST", c.path(recordDeploymentTemplate, name, number, environmentUUID), r, new(broker.DeployedVersion))
+	return res.(*broker.DeployedVersion), err
 }
 
 // CreatePacticipant creates a new Pacticipant
 func (c *Client) CreatePacticipant(p broker.Pacticipant) (*broker.Pacticipant, error) {
-	res, err := c.doCrud("POST", pacticipantCreateTemplate, p, new(broker.Pacticipant))
+	res, err := c.doCrud("POST", c.path(pacticipantCreateTemplate), p, new(broker.Pacticipant))
 	return res.(*broker.Pacticipant), err
 }
 
 // UpdatePacticipant updates an existing Pacticipant
 func (c *Client) UpdatePacticipant(p broker.Pacticipant) (*broker.Pacticipant, error) {
-	res, err := c.doCrud("PATCH", urlEncodeTemplate(pacticipantReadUpdateDeleteTemplate, p.Name), p, new(broker.Pacticipant))
+	res, err := c.doCrud("PATCH", c.path(pacticipantReadUpdateDeleteTemplate, p.Name), p, new(broker.Pacticipant))
+	return res.(*broker.Pacticipant), err
+}
+
+// RenamePacticipant updates the Pacticipant currently called name, changing its name to p.Name
+func (c *Client) RenamePacticipant(name string, p broker.Pacticipant) (*broker.Pacticipant, error) {
+	res, err := c.doCrud("PATCH", c.path(pacticipantReadUpdateDeleteTemplate, name), p, new(broker.Pacticipant))
 	return res.(*broker.Pacticipant), err
 }
 
 // DeletePacticipant removes an existing Pacticipant
 func (c *Client) DeletePacticipant(p broker.Pacticipant) error {
-	_, err := c.doCrud("DELETE", urlEncodeTemplate(pacticipantReadUpdateDeleteTemplate, p.Name), nil, nil)
+	_, err := c.doCrud("DELETE", c.path(pacticipantReadUpdateDeleteTemplate, p.Name), nil, nil)
 	return err
 }
 
 // ReadTeam gets a Team
 func (c *Client) ReadTeam(t broker.Team) (*broker.Team, error) {
-	res, err := c.doCrud("GET", urlEncodeTemplate(teamReadUpdateDeleteTemplate, t.UUID), nil, new(broker.Team))
+	res, err := c.doCrud("GET", c.path(teamReadUpdateDeleteTemplate, t.UUID), nil, new(broker.Team))
 	return res.(*broker.Team), err
 }
 
+// ListTeams returns all Teams in the account
+func (c *Client) ListTeams() (*broker.TeamsResponse, error) {
+	all := new(broker.TeamsResponse)
+	err := c.doList(c.path(teamCreateTemplate), func() broker.Page { return new(broker.TeamsResponse) }, func(page broker.Page) {
+		all.Teams = append(all.Teams, page.(*broker.TeamsResponse).Teams...)
+	})
+	return all, err
+}
+
 // CreateTeam creates a Team
 func (c *Client) CreateTeam(t broker.TeamCreateOrUpdateRequest) (*broker.Team, error) {
-	res, err := c.doCrud("POST", teamCreateTemplate, t, new(broker.TeamsResponse))
+	res, err := c.doCrud("POST", c.path(teamCreateTemplate), t, new(broker.TeamsResponse))
 
 	if err != nil {
 		return nil, err
@@ -170,13 +419,13 @@ func (c *Client) CreateTeam(t broker.TeamCreateOrUpdateRequest) (*broker.Team, e
 
 // ReadTeamAssignments finds all users currently in a team
 func (c *Client) ReadTeamAssignments(t broker.Team) (*broker.TeamsAssignmentResponse, error) {
-	res, err := c.doCrud("GET", urlEncodeTemplate(teamAssignmentTemplate, t.UUID), t, new(broker.TeamsAssignmentResponse))
+	res, err := c.doCrud("GET", c.path(teamAssignmentTemplate, t.UUID), t, new(broker.TeamsAssignmentResponse))
 	return res.(*broker.TeamsAssignmentResponse), err
 }
 
 // UpdateTeamAssignments sets the users for a given team, removing any existing users not in the specified request
 func (c *Client) UpdateTeamAssignments(r broker.TeamsAssignmentRequest) (*broker.TeamsAssignmentResponse, error) {
-	res, err := c.doCrud("PUT", urlEncodeTemplate(teamAssignmentTemplate, r.UUID), r, new(broker.TeamsAssignmentResponse))
+	res, err := c.doCrud("PUT", c.path(teamAssignmentTemplate, r.UUID), r, new(broker.TeamsAssignmentResponse))
 
 	if err != nil {
 		return nil, err
@@ -193,7 +442,7 @@ func (c *Client) UpdateTeamAssignments(r broker.TeamsAssignmentRequest) (*broker
 
 // AppendTeamAssignments adds users to an existing Team (does not remove absent ones)
 func (c *Client) AppendTeamAssignments(r broker.TeamsAssignmentRequest) (*broker.TeamsAssignmentResponse, error) {
-	res, err := c.doCrud("POST", urlEncodeTemplate(teamAssignmentTemplate, r.UUID), r, new(broker.TeamsAssignmentResponse))
+	res, err := c.doCrud("POST", c.path(teamAssignmentTemplate, r.UUID), r, new(broker.TeamsAssignmentResponse))
 
 	if err != nil {
 		return nil, err
@@ -210,7 +459,7 @@ func (c *Client) AppendTeamAssignments(r broker.TeamsAssignmentRequest) (*broker
 
 // DeleteTeamAssignment removes a single user from a team
 func (c *Client) DeleteTeamAssignment(t broker.Team, u broker.User) error {
-	_, err := c.doCrud("DELETE", urlEncodeTemplate(teamUserTemplate, t.UUID, u.UUID), nil, nil)
+	_, err := c.doCrud("DELETE", c.path(teamUserTemplate, t.UUID, u.UUID), nil, nil)
 
 	return err
 }
@@ -218,7 +467,7 @@ func (c *Client) DeleteTeamAssignment(t broker.Team, u broker.User) error {
 // DeleteTeamAssignments removes specified users from the team
 func (c *Client) DeleteTeamAssignments(t broker.TeamsAssignmentRequest) error {
 	if len(t.Users) > 0 {
-		_, err := c.doCrud("DELETE", urlEncodeTemplate(teamAssignmentTemplate, t.UUID), t, nil)
+		_, err := c.doCrud("DELETE", c.path(teamAssignmentTemplate, t.UUID), t, nil)
 		return err
 	}
 	return nil
@@ -226,51 +475,69 @@ func (c *Client) DeleteTeamAssignments(t broker.TeamsAssignmentRequest) error {
 
 // UpdateTeam updates the team
 func (c *Client) UpdateTeam(t broker.TeamCreateOrUpdateRequest) (*broker.Team, error) {
-	res, err := c.doCrud("PUT", urlEncodeTemplate(teamReadUpdateDeleteTemplate, t.UUID), t, new(broker.Team))
+	res, err := c.doCrud("PUT", c.path(teamReadUpdateDeleteTemplate, t.UUID), t, new(broker.Team))
 	return res.(*broker.Team), err
 }
 
 // DeleteTeam deletes the Team
 func (c *Client) DeleteTeam(t broker.Team) error {
-	_, err := c.doCrud("DELETE", urlEncodeTemplate(teamReadUpdateDeleteTemplate, t.UUID), nil, nil)
+	_, err := c.doCrud("DELETE", c.path(teamReadUpdateDeleteTemplate, t.UUID), nil, nil)
 
 	return err
 }
 
 // ReadRole gets a Role
 func (c *Client) ReadRole(uuid string) (*broker.Role, error) {
-	res, err := c.doCrud("GET", urlEncodeTemplate(roleReadUpdateDeleteTemplate, uuid), nil, new(broker.Role))
+	res, err := c.doCrud("GET", c.path(roleReadUpdateDeleteTemplate, uuid), nil, new(broker.Role))
 	return res.(*broker.Role), err
 }
 
+// ListRoles returns all built-in and custom Roles
+func (c *Client) ListRoles() (*broker.RolesResponse, error) {
+	all := new(broker.RolesResponse)
+	err := c.doList(c.path(roleCreateTemplate), func() broker.Page { return new(broker.RolesResponse) }, func(page broker.Page) {
+		all.Roles = append(all.Roles, page.(*broker.RolesResponse).Roles...)
+	})
+	return all, err
+}
+
 // CreateRole creates a Role
 func (c *Client) CreateRole(p broker.Role) (*broker.Role, error) {
-	res, err := c.doCrud("POST", roleCreateTemplate, p, new(broker.Role))
+	res, err := c.doCrud("POST", c.path(roleCreateTemplate), p, new(broker.Role))
 	return res.(*broker.Role), err
 }
 
 // UpdateRole updates an existing Role
 func (c *Client) UpdateRole(p broker.Role) (*broker.Role, error) {
-	res, err := c.doCrud("PUT", urlEncodeTemplate(roleReadUpdateDeleteTemplate, p.UUID), p, new(broker.Role))
+	res, err := c.doCrud("PUT", c.path(roleReadUpdateDeleteTemplate, p.UUID), p, new(broker.Role))
 	return res.(*broker.Role), err
 }
 
 // DeleteRole removes a role
 func (c *Client) DeleteRole(p broker.Role) error {
-	_, err := c.doCrud("DELETE", urlEncodeTemplate(roleReadUpdateDeleteTemplate, p.UUID), nil, nil)
+	_, err := c.doCrud("DELETE", c.path(roleReadUpdateDeleteTemplate, p.UUID), nil, nil)
 
 	return err
 }
 
 // ReadUser gets a User
 func (c *Client) ReadUser(uuid string) (*broker.User, error) {
-	res, err := c.doCrud("GET", urlEncodeTemplate(userReadUpdateDeleteTemplate, uuid), nil, new(broker.User))
+	res, err := c.doCrud("GET", c.path(userReadUpdateDeleteTemplate, uuid), nil, new(broker.User))
 	return res.(*broker.User), err
 }
 
+// ListUsers returns all users and system accounts in the account
+func (c *Client) ListUsers() (*broker.Users, error) {
+	all := new(broker.Users)
+	err := c.doList(c.path(userListTemplate), func() broker.Page { return new(broker.Users) }, func(page broker.Page) {
+		all.Users = append(all.Users, page.(*broker.Users).Users...)
+	})
+	return all, err
+}
+
 // CreateUser creates a user or a system account
 func (c *Client) CreateUser(u broker.User) (*broker.User, error) {
-	template := userCreateTemplate
+	template := c.path(userCreateTemplate)
 	if u.Type == broker.SystemAccount {
 		return c.CreateSystemAccount(u)
 	}
@@ -278,9 +545,18 @@ func (c *Client) CreateUser(u broker.User) (*broker.User, error) {
 	return res.(*broker.User), err
 }
 
+// ListSystemAccounts returns all system accounts in the account
+func (c *Client) ListSystemAccounts() (*broker.Users, error) {
+	all := new(broker.Users)
+	err := c.doList(c.path(systemAccountCreateTemplate), func() broker.Page { return new(broker.Users) }, func(page broker.Page) {
+		all.Users = append(all.Users, page.(*broker.Users).Users...)
+	})
+	return all, err
+}
+
 // CreateUser creates a user or a system account
 func (c *Client) CreateSystemAccount(u broker.User) (*broker.User, error) {
-	res, err := c.doCrud("POST", systemAccountCreateTemplate, u, nil)
+	res, err := c.doCrud("POST", c.path(systemAccountCreateTemplate), u, nil)
 
 	if err != nil {
 		return nil, err
@@ -299,7 +575,7 @@ func (c *Client) CreateSystemAccount(u broker.User) (*broker.User, error) {
 // UpdateUser updates an existing User
 // currently only supports modifying the "active" property
 func (c *Client) UpdateUser(p broker.User) (*broker.User, error) {
-	res, err := c.doCrud("PUT", urlEncodeTemplate(userReadUpdateDeleteTemplate, p.UUID), p, new(broker.User))
+	res, err := c.doCrud("PUT", c.path(userReadUpdateDeleteTemplate, p.UUID), p, new(broker.User))
 	return res.(*broker.User), err
 }
 
@@ -314,62 +590,69 @@ func (c *Client) DeleteUser(p broker.User) error {
 
 // AddAdminRoleToUser converts a user to an administrator
 func (c *Client) AddAdminRoleToUser(p broker.User) (*broker.User, error) {
-	res, err := c.doCrud("PUT", urlEncodeTemplate(userAdminUpdateTemplate, p.UUID), p, new(broker.User))
+	res, err := c.doCrud("PUT", c.path(userAdminUpdateTemplate, p.UUID), p, new(broker.User))
 	return res.(*broker.User), err
 }
 
 // RemoveAdminRoleFromUser removes the administrator role from a user
 func (c *Client) RemoveAdminRoleFromUser(p broker.User) (*broker.User, error) {
-	res, err := c.doCrud("DELETE", urlEncodeTemplate(userAdminUpdateTemplate, p.UUID), p, new(broker.User))
+	res, err := c.doCrud("DELETE", c.path(userAdminUpdateTemplate, p.UUID), p, new(broker.User))
 	return res.(*broker.User), err
 }
 
 // ReadSecret gets the current Secret information (the actual secret is not returned)
 func (c *Client) ReadSecret(uuid string) (*broker.SecretResponse, error) {
-	res, err := c.doCrud("GET", urlEncodeTemplate(secretReadUpdateDeleteTemplate, uuid), nil, new(broker.SecretResponse))
+	res, err := c.doCrud("GET", c.path(secretReadUpdateDeleteTemplate, uuid), nil, new(broker.SecretResponse))
 	return res.(*broker.SecretResponse), err
 }
 
+// ListSecrets returns all secrets (without their values)
+func (c *Client) ListSecrets() (*broker.SecretsResponse, error) {
+	all := new(broker.SecretsResponse)
+	err := c.doList(c.path(secretCreateTemplate), func() broker.Page { return new(broker.SecretsResponse) }, func(page broker.Page) {
+		all.Embedded.Secrets = append(all.Embedded.Secrets, page.(*broker.SecretsResponse).Embedded.Secrets...)
+	})
+	return all, err
+}
+
 // CreateSecret creates a new secret
 // TODO: better response message for OSS broker vs Pactflow
 func (c *Client) CreateSecret(s broker.Secret) (*broker.SecretResponse, error) {
-	res, err := c.doCrud("POST", secretCreateTemplate, s, new(broker.SecretResponse))
+	res, err := c.doCrud("POST", c.path(secretCreateTemplate), s, new(broker.SecretResponse))
 	return res.(*broker.SecretResponse), err
 }
 
 // UpdateSecret updates an existing secret. All values may be changed
 func (c *Client) UpdateSecret(s broker.Secret) (*broker.SecretResponse, error) {
-	res, err := c.doCrud("PUT", urlEncodeTemplate(secretReadUpdateDeleteTemplate, s.UUID), s, new(broker.SecretResponse))
+	res, err := c.doCrud("PUT", c.path(secretReadUpdateDeleteTemplate, s.UUID), s, new(broker.SecretResponse))
 	return res.(*broker.SecretResponse), err
 }
 
 // DeleteSecret removes an existing secret
 func (c *Client) DeleteSecret(s broker.Secret) error {
-	_, err := c.doCrud("DELETE", urlEncodeTemplate(secretReadUpdateDeleteTemplate, s.UUID), nil, nil)
+	_, err := c.doCrud("DELETE", c.path(secretReadUpdateDeleteTemplate, s.UUID), nil, nil)
 	return err
 }
 
 // ReadTokens lists all tokens for the given user principal
 func (c *Client) ReadTokens() (*broker.APITokensResponse, error) {
-	res, err := c.doCrud("GET", listTokensTemplate, nil, new(broker.APITokensResponse))
+	res, err := c.doCrud("GET", c.path(listTokensTemplate), nil, new(broker.APITokensResponse))
 	return res.(*broker.APITokensResponse), err
 }
 
 // ReadToken finds an API token given a UUID
 func (c *Client) ReadToken(uuid string) (*broker.APIToken, error) {
 	tokens, err := c.ReadTokens()
-	log.Println("[DEBUG] have tokens", tokens)
 
 	if err != nil {
 		return nil, err
 	}
 	for _, t := range tokens.Embedded.Items {
-		log.Println("[DEBUG] have token", t)
 		if t.UUID == uuid {
 			return &t, nil
 		}
 	}
-	return nil, fmt.Errorf("token with uuid '%s' not found", uuid)
+	return nil, fmt.Errorf("token with uuid '%s': %w", uuid, ErrNotFound)
 }
 
 // FindTokenByType finds a token given it's s
@@ -380,13 +663,11 @@ func (c *Client) FindTokenByType(tokenType string) (*broker.APIToken, error) {
 	}
 
 	tokens, err := c.ReadTokens()
-	log.Println("[DEBUG] have tokens", tokens)
 
 	if err != nil {
 		return nil, err
 	}
 	for _, t := range tokens.Embedded.Items {
-		log.Println("[DEBUG] have token", t)
 		if t.Description == tokenTypes[tokenType] {
 			return &t, nil
 		}
@@ -396,57 +677,307 @@ func (c *Client) FindTokenByType(tokenType string) (*broker.APIToken, error) {
 
 // RegenerateToken generates a new API Token for the given UUID
 func (c *Client) RegenerateToken(t broker.APIToken) (*broker.APITokenResponse, error) {
-	res, err := c.doCrud("POST", urlEncodeTemplate(tokenRegenerateTemplate, t.UUID), nil, new(broker.APITokenResponse))
+	res, err := c.doCrud("POST", c.path(tokenRegenerateTemplate, t.UUID), nil, new(broker.APITokenResponse))
 	return res.(*broker.APITokenResponse), err
 }
 
 // SetUserRoles sets the roles for a given user, removing any not given and adding those that were provided
 func (c *Client) SetUserRoles(uuid string, r broker.SetUserRolesRequest) error {
-	_, err := c.doCrud("PUT", urlEncodeTemplate(userRolesUpdateTemplate, uuid), r, nil)
+	_, err := c.doCrud("PUT", c.path(userRolesUpdateTemplate, uuid), r, nil)
 	return err
 }
 
 // ReadTenantAuthenticationSettings configures the authentication settings on a given Pactflow account
 func (c *Client) ReadTenantAuthenticationSettings() (*broker.AuthenticationSettings, error) {
-	res, err := c.doCrud("GET", tenantAuthenticationTemplate, nil, new(broker.AuthenticationSettings))
+	res, err := c.doCrud("GET", c.path(tenantAuthenticationTemplate), nil, new(broker.AuthenticationSettings))
 
 	return res.(*broker.AuthenticationSettings), err
 }
 
 // SetTenantAuthenticationSettings configures the authentication settings on a given Pactflow account
 func (c *Client) SetTenantAuthenticationSettings(r broker.AuthenticationSettings) (*broker.AuthenticationSettings, error) {
-	res, err := c.doCrud("PUT", tenantAuthenticationTemplate, r, new(broker.AuthenticationSettings))
+	res, err := c.doCrud("PUT", c.path(tenantAuthenticationTemplate), r, new(broker.AuthenticationSettings))
 
 	return res.(*broker.AuthenticationSettings), err
 }
 
 // ReadEnvironment gets an Environment
 func (c *Client) ReadEnvironment(uuid string) (*broker.Environment, error) {
-	res, err := c.doCrud("GET", urlEncodeTemplate(environmentReadUpdateDeleteTemplate, uuid), nil, new(broker.Environment))
+	res, err := c.doCrud("GET", c.path(environmentReadUpdateDeleteTemplate, uuid), nil, new(broker.Environment))
 	return res.(*broker.Environment), err
 }
 
+// ListEnvironments returns all environments, or only the environment with the given name if one is provided
+func (c *Client) ListEnvironments(name string) (*broker.EnvironmentsResponse, error) {
+	path := c.path(environmentCreateTemplate)
+	if name != "" {
+		path += "?name=" + url.QueryEscape(name)
+	}
+	all := new(broker.EnvironmentsResponse)
+	err := c.doList(path, func() broker.Page { return new(broker.EnvironmentsResponse) }, func(page broker.Page) {
+		all.Embedded.Environments = append(all.Embedded.Environments, page.(*broker.EnvironmentsResponse).Embedded.Environments...)
+	})
+	return all, err
+}
+
 // CreateEnvironment creates an Environment
 func (c *Client) CreateEnvironment(p broker.EnvironmentCreateOrUpdateRequest) (*broker.EnvironmentCreateOrUpdateResponse, error) {
-	res, err := c.doCrud("POST", environmentCreateTemplate, p, new(broker.EnvironmentCreateOrUpdateResponse))
+	res, err := c.doCrud("POST", c.path(environmentCreateTemplate), p, new(broker.EnvironmentCreateOrUpdateResponse))
 	return res.(*broker.EnvironmentCreateOrUpdateResponse), err
 }
 
 // UpdateEnvironment updates an Environment
 func (c *Client) UpdateEnvironment(p broker.EnvironmentCreateOrUpdateRequest) (*broker.EnvironmentCreateOrUpdateResponse, error) {
-	res, err := c.doCrud("PUT", urlEncodeTemplate(environmentReadUpdateDeleteTemplate, p.UUID), p, new(broker.EnvironmentCreateOrUpdateResponse))
+	res, err := c.doCrud("PUT", c.path(environmentReadUpdateDeleteTemplate, p.UUID), p, new(broker.EnvironmentCreateOrUpdateResponse))
 	return res.(*broker.EnvironmentCreateOrUpdateResponse), err
 }
 
 // DeleteEnvironment removes an Environment
 func (c *Client) DeleteEnvironment(p broker.Environment) error {
-	_, err := c.doCrud("DELETE", urlEncodeTemplate(environmentReadUpdateDeleteTemplate, p.UUID), nil, nil)
+	_, err := c.doCrud("DELETE", c.path(environmentReadUpdateDeleteTemplate, p.UUID), nil, nil)
+
+	return err
+}
+
+// ReadNotificationSettings gets the email notification settings for the account, or for a team if a team UUID is given
+func (c *Client) ReadNotificationSettings(teamUUID string) (*broker.NotificationSettings, error) {
+	res, err := c.doCrud("GET", c.notificationSettingsPath(teamUUID), nil, new(broker.NotificationSettings))
+	return res.(*broker.NotificationSettings), err
+}
+
+// SetNotificationSettings configures the email notification settings for the account, or for a team if a team UUID is given
+func (c *Client) SetNotificationSettings(s broker.NotificationSettings) (*broker.NotificationSettings, error) {
+	res, err := c.doCrud("PUT", c.notificationSettingsPath(s.TeamUUID), s, new(broker.NotificationSettings))
+	return res.(*broker.NotificationSettings), err
+}
+
+// PublishProviderContract publishes a provider contract (and optional self verification results) for a provider version
+func (c *Client) PublishProviderContract(r broker.ProviderContractPublishRequest) error {
+	_, err := c.doCrud("POST", c.path(providerContractPublishTemplate, r.Provider), r, nil)
+	return err
+}
+
+// ReadProviderContract gets the provider contract published for a given provider version
+func (c *Client) ReadProviderContract(provider string, version string) (*broker.ProviderContractResponse, error) {
+	res, err := c.doCrud("GET", c.path(providerContractReadDeleteTemplate, provider, version), nil, new(broker.ProviderContractResponse))
+	return res.(*broker.ProviderContractResponse), err
+}
+
+// DeleteProviderContract removes the provider contract published for a given provider version
+func (c *Client) DeleteProviderContract(provider string, version string) error {
+	_, err := c.doCrud("DELETE", c.path(providerContractReadDeleteTemplate, provider, version), nil, nil)
+	return err
+}
+
+// ReadBadgeSettings gets the badge access settings on a given Pactflow account
+func (c *Client) ReadBadgeSettings() (*broker.BadgeSettings, error) {
+	res, err := c.doCrud("GET", c.path(tenantBadgeSettingsTemplate), nil, new(broker.BadgeSettings))
+	return res.(*broker.BadgeSettings), err
+}
+
+// SetBadgeSettings configures the badge access settings on a given Pactflow account
+func (c *Client) SetBadgeSettings(s broker.BadgeSettings) (*broker.BadgeSettings, error) {
+	res, err := c.doCrud("PUT", c.path(tenantBadgeSettingsTemplate), s, new(broker.BadgeSettings))
+	return res.(*broker.BadgeSettings), err
+}
+
+// ReadChatIntegration gets a Slack or Microsoft Teams integration
+func (c *Client) ReadChatIntegration(uuid string) (*broker.ChatIntegrationResponse, error) {
+	res, err := c.doCrud("GET", c.path(chatReadUpdateDeleteTemplate, uuid), nil, new(broker.ChatIntegrationResponse))
+	return res.(*broker.ChatIntegrationResponse), err
+}
+
+// CreateChatIntegration creates a Slack or Microsoft Teams integration
+func (c *Client) CreateChatIntegration(i broker.ChatIntegration) (*broker.ChatIntegrationResponse, error) {
+	res, err := c.doCrud("POST", c.path(chatCreateTemplate), i, new(broker.ChatIntegrationResponse))
+	return res.(*broker.ChatIntegrationResponse), err
+}
+
+// UpdateChatIntegration updates an existing Slack or Microsoft Teams integration
+func (c *Client) UpdateChatIntegration(i broker.ChatIntegration) (*broker.ChatIntegrationResponse, error) {
+	res, err := c.doCrud("PUT", c.path(chatReadUpdateDeleteTemplate, i.UUID), i, new(broker.ChatIntegrationResponse))
+	return res.(*broker.ChatIntegrationResponse), err
+}
 
+// DeleteChatIntegration removes a Slack or Microsoft Teams integration
+func (c *Client) DeleteChatIntegration(i broker.ChatIntegration) error {
+	_, err := c.doCrud("DELETE", c.path(chatReadUpdateDeleteTemplate, i.UUID), nil, nil)
 	return err
 }
 
+// ReadAnnouncement gets the announcement banner shown in the broker UI
+func (c *Client) ReadAnnouncement() (*broker.Announcement, error) {
+	res, err := c.doCrud("GET", c.path(tenantAnnouncementTemplate), nil, new(broker.Announcement))
+	return res.(*broker.Announcement), err
+}
+
+// SetAnnouncement sets the announcement banner shown in the broker UI
+func (c *Client) SetAnnouncement(a broker.Announcement) (*broker.Announcement, error) {
+	res, err := c.doCrud("PUT", c.path(tenantAnnouncementTemplate), a, new(broker.Announcement))
+	return res.(*broker.Announcement), err
+}
+
+// QueryMatrix queries the matrix of consumer and provider versions
+func (c *Client) QueryMatrix(q broker.MatrixQuery) (*broker.MatrixResponse, error) {
+	res, err := c.doCrud("GET", c.path(matrixTemplate)+"?"+matrixQueryString(q), nil, new(broker.MatrixResponse))
+	return res.(*broker.MatrixResponse), err
+}
+
+// matrixQueryString encodes the query by hand, as url.Values sorts its keys and
+// the broker relies on the order of the q[][] parameters to group each selector
+func matrixQueryString(q broker.MatrixQuery) string {
+	params := make([]string, 0)
+	add := func(key, value string) {
+		params = append(params, url.QueryEscape(key)+"="+url.QueryEscape(value))
+	}
+
+	for _, s := range q.Selectors {
+		add("q[][pacticipant]", s.Pacticipant)
+		if s.Version != "" {
+			add("q[][version]", s.Version)
+		}
+		if s.Branch != "" {
+			add("q[][branch]", s.Branch)
+		}
+		if s.Tag != "" {
+			add("q[][tag]", s.Tag)
+		}
+		if s.Latest {
+			add("q[][latest]", "true")
+		}
+		if s.MainBranch {
+			add("q[][mainBranch]", "true")
+		}
+	}
+	if q.LatestBy != "" {
+		add("latestby", q.LatestBy)
+	}
+	if q.Environment != "" {
+		add("environment", q.Environment)
+	}
+	if q.Limit > 0 {
+		add("limit", strconv.Itoa(q.Limit))
+	}
+
+	return strings.Join(params, "&")
+}
+
+// ListCurrentlyDeployedVersions returns the pacticipant versions currently deployed to an environment
+func (c *Client) ListCurrentlyDeployedVersions(environmentUUID string) (*broker.DeployedVersionsResponse, error) {
+	res, err := c.doCrud("GET", c.path(deployedVersionsTemplate, environmentUUID), nil, new(broker.DeployedVersionsResponse))
+	return res.(*broker.DeployedVersionsResponse), err
+}
+
+// ListCurrentlySupportedReleasedVersions returns the released pacticipant versions currently supported in an environment
+func (c *Client) ListCurrentlySupportedReleasedVersions(environmentUUID string) (*broker.ReleasedVersionsResponse, error) {
+	res, err := c.doCrud("GET", c.path(releasedVersionsTemplate, environmentUUID), nil, new(broker.ReleasedVersionsResponse))
+	return res.(*broker.ReleasedVersionsResponse), err
+}
+
+// ReadLatestVerificationResult gets the verification result for the latest pact between a consumer and provider,
+// by following the pb:latest-verification-results relation of the latest pact. A nil result is returned if the
+// pact has not yet been verified
+func (c *Client) ReadLatestVerificationResult(consumer, provider string) (*broker.VerificationResult, error) {
+	res, err := c.doCrud("GET", c.path(latestPactTemplate, provider, consumer), nil, new(broker.HalDoc))
+
+	if err != nil {
+		return nil, err
+	}
+
+	link, ok := res.(*broker.HalDoc).Links["pb:latest-verification-results"]
+	if !ok || link.Href == "" {
+		return nil, nil
+	}
+
+	path, err := linkPath(link.Href)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err = c.doCrud("GET", path, nil, new(broker.VerificationResult))
+
+	if errors.Is(err, ErrNotFound) {
+		return nil, nil
+	}
+
+	return res.(*broker.VerificationResult), err
+}
+
+// ReadPactsForVerification returns the pacts a provider should verify, for the given consumer version selectors
+func (c *Client) ReadPactsForVerification(r broker.PactsForVerificationRequest) (*broker.PactsForVerificationResponse, error) {
+	res, err := c.asQuery().doCrud("POST", c.path(pactsForVerificationTemplate, r.Provider), r, new(broker.PactsForVerificationResponse))
+	return res.(*broker.PactsForVerificationResponse), err
+}
+
+// ListPactVersions returns links to every version of the pact between a consumer and provider, newest first
+func (c *Client) ListPactVersions(consumer, provider string) (*broker.PactVersionsResponse, error) {
+	all := new(broker.PactVersionsResponse)
+	err := c.doList(c.path(pactVersionsTemplate, provider, consumer), func() broker.Page { return new(broker.PactVersionsResponse) }, func(page broker.Page) {
+		all.Links.PactVersions = append(all.Links.PactVersions, page.(*broker.PactVersionsResponse).Links.PactVersions...)
+	})
+	return all, err
+}
+
+// ReadPact gets the pact between a consumer and provider, for the given consumer version
+func (c *Client) ReadPact(consumer, provider, version string) (*broker.Pact, error) {
+	res, err := c.doCrud("GET", c.path(pactVersionTemplate, provider, consumer, version), nil, new(broker.Pact))
+	return res.(*broker.Pact), err
+}
+
+// ListIntegrations returns every consumer and provider pair known to the broker
+func (c *Client) ListIntegrations() (*broker.IntegrationsResponse, error) {
+	all := new(broker.IntegrationsResponse)
+	err := c.doList(c.path(integrationsTemplate), func() broker.Page { return new(broker.IntegrationsResponse) }, func(page broker.Page) {
+		all.Embedded.Integrations = append(all.Embedded.Integrations, page.(*broker.IntegrationsResponse).Embedded.Integrations...)
+	})
+	return all, err
+}
+
+// ListProviderStates returns the provider states declared across the latest pacts for a provider
+func (c *Client) ListProviderStates(provider string) (*broker.ProviderStatesResponse, error) {
+	res, err := c.doCrud("GET", c.path(providerStatesTemplate, provider), nil, new(broker.ProviderStatesResponse))
+	return res.(*broker.ProviderStatesResponse), err
+}
+
+// ListAuditEvents returns the audit events recorded between from and to (RFC3339 timestamps, either may be empty)
+func (c *Client) ListAuditEvents(from, to string) (*broker.AuditEventsResponse, error) {
+	query := url.Values{}
+	if from != "" {
+		query.Set("from", from)
+	}
+	if to != "" {
+		query.Set("to", to)
+	}
+
+	path := c.path(auditEventsTemplate)
+	if len(query) > 0 {
+		path = path + "?" + query.Encode()
+	}
+
+	all := new(broker.AuditEventsResponse)
+	err := c.doList(path, func() broker.Page { return new(broker.AuditEventsResponse) }, func(page broker.Page) {
+		all.Embedded.Events = append(all.Embedded.Events, page.(*broker.AuditEventsResponse).Embedded.Events...)
+	})
+	return all, err
+}
+
+func (c *Client) notificationSettingsPath(teamUUID string) string {
+	if teamUUID != "" {
+		return c.path(teamNotificationSettingsTemplate, teamUUID)
+	}
+	return c.path(tenantNotificationSettingsTemplate)
+}
+
 func (c *Client) newRequest(method, path string, body interface{}) (*http.Request, error) {
-	rel := &url.URL{Path: path}
+	// Parsing (rather than assigning to Path) keeps escaped segments such as branch
+	// names containing a "/" intact, and splits out any query string
+	rel, err := url.Parse(path)
+	if err != nil {
+		return nil, err
+	}
+	if c.Config.BaseURL == nil {
+		return nil, fmt.Errorf("%w, unable to %s %s", ErrNotConfigured, method, rel.Path)
+	}
 	u := c.Config.BaseURL.ResolveReference(rel)
 	var buf = new(bytes.Buffer)
 	if body != nil {
@@ -454,60 +985,160 @@ func (c *Client) newRequest(method, path string, body interface{}) (*http.Reques
 		if err != nil {
 			return nil, err
 		}
-
-		log.Printf("[INFO] raw body to be sent over wire: '%s'", buf.String())
 	}
 	req, err := http.NewRequest(method, u.String(), buf)
 	if err != nil {
 		return nil, err
 	}
+	for name, value := range c.Config.DefaultHeaders {
+		req.Header.Set(name, value)
+	}
 	if body != nil {
 		req.Header.Set("Content-Type", "application/json")
 	}
 
 	if c.Config.AccessToken != "" {
-		req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", c.Config.AccessToken))
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.Config.AccessToken))
 	} else if c.Config.BasicAuthUsername != "" {
 		req.SetBasicAuth(c.Config.BasicAuthUsername, c.Config.BasicAuthPassword)
 	}
 
-	req.Header.Set("Accept", "application/hal+json, application/json")
+	if c.ifMatch != "" && method != http.MethodGet && method != http.MethodHead {
+		req.Header.Set("If-Match", c.ifMatch)
+	}
+
+	req.Header.Set("Accept", "application/hal+json, application/json, "+problemJSONMediaType)
 	req.Header.Set("User-Agent", c.UserAgent)
 
-	log.Println("[DEBUG] creating new request", req)
+	if c.ctx != nil {
+		req = req.WithContext(c.ctx)
+	}
+
 	return req, nil
 }
 
 func handleError(err error, req *http.Request, resp *http.Response) (*http.Response, error) {
 	bodyBytes, _ := ioutil.ReadAll(resp.Body)
 	resp.Body.Close() //  must close
-	log.Println("[DEBUG] handling error response:", string(bodyBytes))
+	LogEntry("DEBUG", "error response", "method", req.Method, "path", req.URL.Path, "status", resp.StatusCode, "body", redactBody(bodyBytes))
 
-	// TODO: decode the multiple concrete error types here
 	var e error
 
+	request := req.Method + " " + req.URL.Path
+	excerpt := errorExcerpt(redactBody(bodyBytes))
+
+	if mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type")); mediaType == problemJSONMediaType {
+		e = &problemResponse{
+			err:     err,
+			request: request,
+			excerpt: excerpt,
+		}
+		if decodingErr := json.Unmarshal(bodyBytes, e); decodingErr != nil {
+			LogEntry("DEBUG", "error response isn't a valid problem", "method", req.Method, "path", req.URL.Path, "error", decodingErr)
+		}
+
+		return resp, e
+	}
+
 	e = &apiErrorResponse{
-		err: err,
+		err:     err,
+		request: request,
+		excerpt: excerpt,
 	}
 	decodingErr := json.NewDecoder(bytes.NewBuffer(bodyBytes)).Decode(e)
 	if decodingErr != nil {
-		log.Println("[DEBUG] error decoding APIErrorResponse from response for", req.Method, req.URL.Path, ". Error", decodingErr)
+		LogEntry("DEBUG", "error response isn't a keyed error", "method", req.Method, "path", req.URL.Path, "error", decodingErr)
 
 		e = &apiArrayErrorResponse{
-			err: err,
+			err:     err,
+			request: request,
+			excerpt: excerpt,
 		}
 		decodingErr = json.NewDecoder(bytes.NewBuffer(bodyBytes)).Decode(e)
 		if decodingErr != nil {
-			log.Println("[DEBUG] error decoding APIArrayErrorResponse from response for", req.Method, req.URL.Path, ". Error", decodingErr)
+			LogEntry("DEBUG", "error response isn't a list of errors", "method", req.Method, "path", req.URL.Path, "error", decodingErr)
 		}
 	}
 
 	return resp, e
 }
 
+// sensitiveBodyKeys are the (lower cased, without underscores) body keys and log fields whose values are
+// redacted from logs and dry run output
+var sensitiveBodyKeys = map[string]bool{
+	"password":      true,
+	"value":         true,
+	"token":         true,
+	"secret":        true,
+	"webhookurl":    true,
+	"accesstoken":   true,
+	"authorization": true,
+}
+
+// redactBody masks sensitive values in a JSON body. Non-JSON bodies are omitted entirely, as there is no
+// way of knowing what they contain
+func redactBody(body []byte) string {
+	if len(bytes.TrimSpace(body)) == 0 {
+		return ""
+	}
+
+	var v interface{}
+	if err := json.Unmarshal(body, &v); err != nil {
+		return "<non-JSON body omitted>"
+	}
+
+	redacted, _ := json.Marshal(redactValue(v))
+
+	return string(redacted)
+}
+
+func redactValue(v interface{}) interface{} {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		for k, val := range t {
+			if isSensitiveKey(k) {
+				t[k] = "*****"
+			} else {
+				t[k] = redactValue(val)
+			}
+		}
+	case []interface{}:
+		for i, val := range t {
+			t[i] = redactValue(val)
+		}
+	}
+
+	return v
+}
+
+// requestBody is the body of a request with its sensitive values masked, for logging
+func requestBody(req *http.Request) string {
+	var body []byte
+	if req.GetBody != nil {
+		if rc, err := req.GetBody(); err == nil {
+			body, _ = ioutil.ReadAll(rc)
+		}
+	}
+
+	return redactBody(body)
+}
+
+// dryRun describes the request that would have been sent, in both the log and the returned error so
+// that it is visible in the apply output
+func dryRun(req *http.Request) error {
+	message := strings.TrimSpace(fmt.Sprintf("%s %s %s", req.Method, req.URL, requestBody(req)))
+	LogEntry("INFO", "dry run, request not sent", "request", message)
+
+	return fmt.Errorf("%w: %s", ErrDryRun, message)
+}
+
 func (c *Client) do(req *http.Request, v interface{}) (*http.Response, error) {
-	log.Println("[DEBUG] sending body for request", req)
-	resp, err := c.client.Do(req)
+	if c.Config.DryRun && !c.readOnly && req.Method != http.MethodGet && req.Method != http.MethodHead {
+		return nil, dryRun(req)
+	}
+
+	LogEntry("DEBUG", "sending request", "method", req.Method, "path", req.URL.Path, "body", requestBody(req))
+	resp, err := c.send(req)
 	if err != nil {
 		return nil, err
 	}
@@ -518,7 +1149,7 @@ func (c *Client) do(req *http.Request, v interface{}) (*http.Response, error) {
 		io.Copy(ioutil.Discard, resp.Body)
 		resp.Body.Close()
 	}()
-	log.Println("[DEBUG] response for request:", req, "resp:", resp)
+	LogEntry("DEBUG", "received response", "method", req.Method, "path", req.URL.Path, "status", resp.StatusCode)
 
 	if resp.StatusCode >= 500 {
 		return handleError(ErrSystemUnavailable, req, resp)
@@ -532,6 +1163,22 @@ func (c *Client) do(req *http.Request, v interface{}) (*http.Response, error) {
 		return handleError(ErrForbidden, req, resp)
 	}
 
+	if resp.StatusCode == 404 {
+		return handleError(ErrNotFound, req, resp)
+	}
+
+	if resp.StatusCode == 409 {
+		return handleError(ErrConflict, req, resp)
+	}
+
+	if resp.StatusCode == 412 {
+		return handleError(ErrPreconditionFailed, req, resp)
+	}
+
+	if resp.StatusCode == 429 {
+		return handleError(ErrRateLimited, req, resp)
+	}
+
 	if resp.StatusCode >= 400 && resp.StatusCode < 500 {
 		return handleError(ErrBadRequest, req, resp)
 	}
@@ -539,10 +1186,10 @@ func (c *Client) do(req *http.Request, v interface{}) (*http.Response, error) {
 	if v != nil {
 		err = json.NewDecoder(resp.Body).Decode(v)
 		if err != nil {
-			log.Println("[DEBUG] error decoding response for", req.URL.Path, ". Error", err)
+			LogEntry("DEBUG", "unable to decode response", "method", req.Method, "path", req.URL.Path, "error", err)
 			return resp, err
 		}
-		log.Printf("[DEBUG] Response body: %+v \n", v)
+		LogEntry("DEBUG", "response body", "method", req.Method, "path", req.URL.Path, "body", v)
 	}
 
 	return resp, err
@@ -559,8 +1206,8 @@ func (c *Client) doCrud(method string, path string, requestEntity interface{}, r
 		resp, err = c.do(req, nil)
 
 		// 201 -> extract the location header if the expectation is a string value
-		if resp.StatusCode == 201 {
-			log.Println("[DEBUG] have 201, returning Location header", resp.Header)
+		if resp != nil && resp.StatusCode == 201 {
+			LogEntry("DEBUG", "created", "method", req.Method, "path", req.URL.Path, "location", resp.Header.Get("Location"))
 			return resp.Header.Get("Location"), err
 		}
 	} else {
@@ -570,6 +1217,69 @@ func (c *Client) doCrud(method string, path string, requestEntity interface{}, r
 	return responseEntity, err
 }
 
+// doWithETag is doCrud for entities the broker versions with an ETag, which is returned along with the
+// decoded response
+func (c *Client) doWithETag(method string, path string, requestEntity interface{}, responseEntity interface{}) (string, error) {
+	req, err := c.newRequest(method, path, requestEntity)
+	if err != nil {
+		return "", err
+	}
+
+	resp, err := c.do(req, responseEntity)
+	if resp == nil {
+		return "", err
+	}
+
+	return resp.Header.Get("ETag"), err
+}
+
+// doList GETs every page of a collection, following the next links to the last page so that lists aren't
+// silently truncated on large brokers. newPage allocates each page, and collect appends its items to the
+// result
+func (c *Client) doList(path string, newPage func() broker.Page, collect func(broker.Page)) error {
+	seen := map[string]bool{}
+
+	for path != "" && !seen[path] {
+		seen[path] = true
+
+		res, err := c.doCrud("GET", path, nil, newPage())
+		if err != nil {
+			return err
+		}
+		page := res.(broker.Page)
+		collect(page)
+
+		if path, err = nextPagePath(page); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// nextPagePath is the path and query of a page's next link. Only these are followed, so that the
+// credentials are always sent to the configured broker, even if it returns links with another host
+// (e.g. its internal name behind a load balancer)
+func nextPagePath(page broker.Page) (string, error) {
+	next := page.NextPage()
+	if next == "" {
+		return "", nil
+	}
+
+	return linkPath(next)
+}
+
+// linkPath returns the path (and query) of a link in a broker response. Only the path is used, so that
+// credentials are only ever sent to the configured broker, wherever the link points
+func linkPath(href string) (string, error) {
+	u, err := url.Parse(href)
+	if err != nil {
+		return "", fmt.Errorf("unable to parse the link %q: %w", href, err)
+	}
+
+	return u.RequestURI(), nil
+}
+
 func urlEncodeTemplate(template string, parameters ...string) string {
 	encodedParams := make([]interface{}, len(parameters))
 
@@ -119,6 +119,38 @@ func TestTerraformClientPact(t *testing.T) {
 			assert.NoError(t, err)
 		})
 
+		t.Run("RenamePacticipant", func(t *testing.T) {
+			renamed := broker.Pacticipant{
+				Name:          "terraform-client-renamed",
+				RepositoryURL: "https://github.com/pactflow/terraform-provider-pact",
+				MainBranch:    "main",
+				DisplayName:   "terraform client",
+			}
+
+			mockProvider.
+				AddInteraction().
+				Given("a pacticipant with name terraform-client exists").
+				UponReceiving("a request to rename a pacticipant").
+				WithRequest("PATCH", S("/pacticipants/terraform-client")).
+				WithHeader("Content-Type", S("application/json")).
+				WithHeader("Authorization", Like("Bearer 1234")).
+				WithJSONBody(Like(renamed)).
+				WillRespondWith(200).
+				WithHeader("Content-Type", S("application/hal+json")).
+				WithJSONBody(Like(renamed))
+
+			err = mockProvider.ExecuteTest(t, func(config MockServerConfig) error {
+				client := clientForPact(config)
+
+				res, e := client.RenamePacticipant("terraform-client", renamed)
+				assert.NoError(t, e)
+				assert.Equal(t, "terraform-client-renamed", res.Name)
+
+				return e
+			})
+			assert.NoError(t, err)
+		})
+
 		t.Run("DeletePacticipant", func(t *testing.T) {
 			newPacticipant := broker.Pacticipant{
 				Name:          "terraform-client",
@@ -140,6 +172,146 @@ func TestTerraformClientPact(t *testing.T) {
 			})
 			assert.NoError(t, err)
 		})
+
+		t.Run("ListPacticipants", func(t *testing.T) {
+			labelled := pacticipant
+			labelled.Embedded = &broker.PacticipantEmbeddedItems{
+				Labels: []broker.Label{
+					{
+						Name: "terraform",
+					},
+				},
+			}
+
+			mockProvider.
+				AddInteraction().
+				Given("a pacticipant with name terraform-client exists").
+				UponReceiving("a request to list pacticipants").
+				WithRequest("GET", S("/pacticipants")).
+				WithHeader("Authorization", Like("Bearer 1234")).
+				WillRespondWith(200).
+				WithHeader("Content-Type", S("application/hal+json")).
+				WithJSONBody(Like(map[string]interface{}{
+					"_embedded": map[string]interface{}{
+						"pacticipants": EachLike(labelled, 1),
+					},
+				}))
+
+			err = mockProvider.ExecuteTest(t, func(config MockServerConfig) error {
+				client := clientForPact(config)
+
+				res, e := client.ListPacticipants()
+				assert.NoError(t, e)
+				assert.Len(t, res.Embedded.Pacticipants, 1)
+				assert.Equal(t, "terraform", res.Embedded.Pacticipants[0].Embedded.Labels[0].Name)
+
+				return e
+			})
+			assert.NoError(t, err)
+		})
+
+		t.Run("ListPacticipantBranches", func(t *testing.T) {
+			branch := broker.Branch{
+				Name:      "main",
+				CreatedAt: "2023-03-17T01:11:10+00:00",
+				UpdatedAt: "2023-03-20T04:12:47+00:00",
+			}
+
+			mockProvider.
+				AddInteraction().
+				Given("a pacticipant with name terraform-client exists with a version on branch main").
+				UponReceiving("a request to list the branches of a pacticipant").
+				WithRequest("GET", S("/pacticipants/terraform-client/branches")).
+				WithHeader("Authorization", Like("Bearer 1234")).
+				WillRespondWith(200).
+				WithHeader("Content-Type", S("application/hal+json")).
+				WithJSONBody(Like(map[string]interface{}{
+					"_embedded": map[string]interface{}{
+						"branches": EachLike(branch, 1),
+					},
+				}))
+
+			err = mockProvider.ExecuteTest(t, func(config MockServerConfig) error {
+				client := clientForPact(config)
+
+				res, e := client.ListPacticipantBranches("terraform-client")
+				assert.NoError(t, e)
+				assert.Len(t, res.Embedded.Branches, 1)
+				assert.Equal(t, "main", res.Embedded.Branches[0].Name)
+
+				return e
+			})
+			assert.NoError(t, err)
+		})
+
+		t.Run("ListPacticipantVersions", func(t *testing.T) {
+			version := broker.Version{
+				Number:    "e15da45d3943bf10793a6d04cfb9f5dabe430fe2",
+				CreatedAt: "2023-03-17T01:11:10+00:00",
+				Embedded: &broker.VersionEmbeddedItems{
+					Tags: []broker.Tag{
+						{
+							Name: "prod",
+						},
+					},
+				},
+			}
+
+			mockProvider.
+				AddInteraction().
+				Given("a pacticipant with name terraform-client exists with a version tagged prod").
+				UponReceiving("a request to list the versions of a pacticipant").
+				WithRequest("GET", S("/pacticipants/terraform-client/versions")).
+				WithHeader("Authorization", Like("Bearer 1234")).
+				WillRespondWith(200).
+				WithHeader("Content-Type", S("application/hal+json")).
+				WithJSONBody(Like(map[string]interface{}{
+					"_embedded": map[string]interface{}{
+						"versions": EachLike(version, 1),
+					},
+				}))
+
+			err = mockProvider.ExecuteTest(t, func(config MockServerConfig) error {
+				client := clientForPact(config)
+
+				res, e := client.ListPacticipantVersions("terraform-client")
+				assert.NoError(t, e)
+				assert.Len(t, res.Embedded.Versions, 1)
+				assert.Equal(t, "prod", res.Embedded.Versions[0].Embedded.Tags[0].Name)
+
+				return e
+			})
+			assert.NoError(t, err)
+		})
+
+		t.Run("ReadLatestPacticipantVersionForBranch", func(t *testing.T) {
+			version := broker.Version{
+				Number:    "e15da45d3943bf10793a6d04cfb9f5dabe430fe2",
+				Branch:    "feature/foo",
+				CreatedAt: "2023-03-17T01:11:10+00:00",
+			}
+
+			mockProvider.
+				AddInteraction().
+				Given("a pacticipant with name terraform-client exists with a version on branch feature/foo").
+				UponReceiving("a request to get the latest version of a pacticipant on a branch").
+				WithRequest("GET", S("/pacticipants/terraform-client/branches/feature%2Ffoo/latest-version")).
+				WithHeader("Authorization", Like("Bearer 1234")).
+				WillRespondWith(200).
+				WithHeader("Content-Type", S("application/hal+json")).
+				WithJSONBody(Like(version))
+
+			err = mockProvider.ExecuteTest(t, func(config MockServerConfig) error {
+				client := clientForPact(config)
+
+				res, e := client.ReadLatestPacticipantVersionForBranch("terraform-client", "feature/foo")
+				assert.NoError(t, e)
+				assert.Equal(t, version.Number, res.Number)
+
+				return e
+			})
+			assert.NoError(t, err)
+		})
 	})
 
 	t.Run("Team", func(t *testing.T) {
@@ -184,6 +356,33 @@ func TestTerraformClientPact(t *testing.T) {
 			EnvironmentUUIDs:   []string{"8000883c-abf0-4b4c-b993-426f607092a9"},
 		}
 
+		t.Run("ListTeams", func(t *testing.T) {
+			mockProvider.
+				AddInteraction().
+				Given("a team with uuid 99643109-adb0-4e68-b25f-7b14d6bcae16 exists").
+				UponReceiving("a request to list teams").
+				WithRequest("GET", S("/admin/teams")).
+				WithHeader("Authorization", Like("Bearer 1234")).
+				WillRespondWith(200).
+				WithHeader("Content-Type", S("application/hal+json")).
+				WithJSONBody(Like(map[string]interface{}{
+					"teams": EachLike(created, 1),
+				}))
+
+			err = mockProvider.ExecuteTest(t, func(config MockServerConfig) error {
+				client := clientForPact(config)
+
+				res, e := client.ListTeams()
+
+				assert.NoError(t, e)
+				assert.Len(t, res.Teams, 1)
+				assert.Equal(t, "terraform-team", res.Teams[0].Name)
+
+				return e
+			})
+			assert.NoError(t, err)
+		})
+
 		t.Run("ReadTeam", func(t *testing.T) {
 			mockProvider.
 				AddInteraction().
@@ -375,6 +574,43 @@ func TestTerraformClientPact(t *testing.T) {
 			assert.NoError(t, err)
 		})
 
+		t.Run("ListSecrets", func(t *testing.T) {
+			mockProvider.
+				AddInteraction().
+				Given("a secret with uuid b6af03cd-018c-4f1b-9546-c778d214f305 exists").
+				UponReceiving("a request to list secrets").
+				WithRequest("GET", S("/secrets")).
+				WithHeader("Authorization", Like("Bearer 1234")).
+				WillRespondWith(200).
+				WithHeader("Content-Type", S("application/hal+json")).
+				WithJSONBody(map[string]interface{}{
+					"_embedded": map[string]interface{}{
+						"secrets": EachLike(map[string]interface{}{
+							"name":        Like(created.Name),
+							"description": Like(created.Description),
+							"_links": map[string]interface{}{
+								"self": map[string]interface{}{
+									"href": Term("http://localhost/secrets/b6af03cd-018c-4f1b-9546-c778d214f305", `/secrets/[0-9a-f-]+$`),
+								},
+							},
+						}, 1),
+					},
+				})
+
+			err = mockProvider.ExecuteTest(t, func(config MockServerConfig) error {
+				client := clientForPact(config)
+
+				res, e := client.ListSecrets()
+				assert.NoError(t, e)
+				assert.Len(t, res.Embedded.Secrets, 1)
+				assert.Equal(t, "terraform-secret", res.Embedded.Secrets[0].Name)
+				assert.Empty(t, res.Embedded.Secrets[0].Value)
+
+				return e
+			})
+			assert.NoError(t, err)
+		})
+
 		t.Run("UpdateSecret", func(t *testing.T) {
 			mockProvider.
 				AddInteraction().
@@ -400,6 +636,37 @@ func TestTerraformClientPact(t *testing.T) {
 			assert.NoError(t, err)
 		})
 
+		// An unchanged value is only held in state as a hash, so it is left out of the update and the
+		// broker keeps the existing value
+		t.Run("UpdateSecret without a value", func(t *testing.T) {
+			unchangedValue := update
+			unchangedValue.Value = ""
+
+			mockProvider.
+				AddInteraction().
+				Given("a secret with uuid b6af03cd-018c-4f1b-9546-c778d214f305 exists").
+				UponReceiving("a request to update a secret without changing its value").
+				WithRequest("PUT", S("/secrets/b6af03cd-018c-4f1b-9546-c778d214f305")).
+				WithHeader("Content-Type", S("application/json")).
+				WithHeader("Authorization", Like("Bearer 1234")).
+				WithJSONBody(Like(unchangedValue)).
+				WillRespondWith(200).
+				WithHeader("Content-Type", S("application/hal+json")).
+				WithJSONBody(Like(unchangedValue))
+
+			err = mockProvider.ExecuteTest(t, func(config MockServerConfig) error {
+				client := clientForPact(config)
+
+				res, e := client.UpdateSecret(unchangedValue)
+				assert.NoError(t, e)
+				assert.Equal(t, "updated description", res.Description)
+				assert.Empty(t, res.Value)
+
+				return e
+			})
+			assert.NoError(t, err)
+		})
+
 		t.Run("DeleteSecret", func(t *testing.T) {
 			mockProvider.
 				AddInteraction().
@@ -492,6 +759,32 @@ func TestTerraformClientPact(t *testing.T) {
 			assert.NoError(t, err)
 		})
 
+		t.Run("ListRoles", func(t *testing.T) {
+			mockProvider.
+				AddInteraction().
+				Given("a role with uuid e1407277-2a25-4559-8fed-4214dd12a1e8 exists").
+				UponReceiving("a request to list roles").
+				WithRequest("GET", S("/admin/roles")).
+				WithHeader("Authorization", Like("Bearer 1234")).
+				WillRespondWith(200).
+				WithHeader("Content-Type", S("application/hal+json")).
+				WithJSONBody(Like(map[string]interface{}{
+					"roles": EachLike(created, 1),
+				}))
+
+			err = mockProvider.ExecuteTest(t, func(config MockServerConfig) error {
+				client := clientForPact(config)
+
+				res, e := client.ListRoles()
+				assert.NoError(t, e)
+				assert.Len(t, res.Roles, 1)
+				assert.Equal(t, "terraform-role", res.Roles[0].Name)
+
+				return e
+			})
+			assert.NoError(t, err)
+		})
+
 		t.Run("UpdateRole", func(t *testing.T) {
 			mockProvider.
 				AddInteraction().
@@ -627,6 +920,32 @@ func TestTerraformClientPact(t *testing.T) {
 			assert.NoError(t, err)
 		})
 
+		t.Run("ListUsers", func(t *testing.T) {
+			mockProvider.
+				AddInteraction().
+				Given("a user with uuid 819f6dbf-dd7a-47ff-b369-e3ed1d2578a0 exists").
+				UponReceiving("a request to list users").
+				WithRequest("GET", S("/admin/users")).
+				WithHeader("Authorization", Like("Bearer 1234")).
+				WillRespondWith(200).
+				WithHeader("Content-Type", S("application/hal+json")).
+				WithJSONBody(Like(map[string]interface{}{
+					"users": EachLike(created, 1),
+				}))
+
+			err = mockProvider.ExecuteTest(t, func(config MockServerConfig) error {
+				client := clientForPact(config)
+
+				res, e := client.ListUsers()
+				assert.NoError(t, e)
+				assert.Len(t, res.Users, 1)
+				assert.Equal(t, "terraform.user@some.domain", res.Users[0].Email)
+
+				return e
+			})
+			assert.NoError(t, err)
+		})
+
 		t.Run("UpdateUser", func(t *testing.T) {
 			mockProvider.
 				AddInteraction().
@@ -781,6 +1100,32 @@ func TestTerraformClientPact(t *testing.T) {
 			assert.NoError(t, err)
 		})
 
+		t.Run("ListSystemAccounts", func(t *testing.T) {
+			mockProvider.
+				AddInteraction().
+				Given("a system account with uuid 71a5be7d-bb9c-427b-ba49-ee8f1df0ae58 exists").
+				UponReceiving("a request to list system accounts").
+				WithRequest("GET", S("/admin/system-accounts")).
+				WithHeader("Authorization", Like("Bearer 1234")).
+				WillRespondWith(200).
+				WithHeader("Content-Type", S("application/hal+json")).
+				WithJSONBody(Like(map[string]interface{}{
+					"users": EachLike(created, 1),
+				}))
+
+			err = mockProvider.ExecuteTest(t, func(config MockServerConfig) error {
+				client := clientForPact(config)
+
+				res, e := client.ListSystemAccounts()
+				assert.NoError(t, e)
+				assert.Len(t, res.Users, 1)
+				assert.Equal(t, "system account", res.Users[0].Name)
+
+				return e
+			})
+			assert.NoError(t, err)
+		})
+
 		t.Run("UpdateUser", func(t *testing.T) {
 			mockProvider.
 				AddInteraction().
@@ -1019,6 +1364,37 @@ func TestTerraformClientPact(t *testing.T) {
 			assert.NoError(t, err)
 		})
 
+		t.Run("ListWebhooks", func(t *testing.T) {
+			mockProvider.
+				AddInteraction().
+				Given("a webhook with ID 2e4bf0e6-b0cf-451f-b05b-69048955f019 exists").
+				UponReceiving("a request to list webhooks").
+				WithRequest("GET", S("/webhooks")).
+				WithHeader("Authorization", Like("Bearer 1234")).
+				WillRespondWith(200).
+				WithHeader("Content-Type", S("application/hal+json")).
+				WithJSONBody(map[string]interface{}{
+					"_links": map[string]interface{}{
+						"pb:webhooks": EachLike(map[string]interface{}{
+							"title": Like("A webhook"),
+							"href":  Term("http://localhost/webhooks/2e4bf0e6-b0cf-451f-b05b-69048955f019", `/webhooks/[0-9a-f-]+$`),
+						}, 1),
+					},
+				})
+
+			err = mockProvider.ExecuteTest(t, func(config MockServerConfig) error {
+				client := clientForPact(config)
+
+				res, e := client.ListWebhooks()
+				assert.NoError(t, e)
+				assert.Len(t, res.Links.Webhooks, 1)
+				assert.Contains(t, res.Links.Webhooks[0].Href, "/webhooks/")
+
+				return e
+			})
+			assert.NoError(t, err)
+		})
+
 		t.Run("UpdateWebhook", func(t *testing.T) {
 			mockProvider.
 				AddInteraction().
@@ -1252,26 +1628,657 @@ func TestTerraformClientPact(t *testing.T) {
 			assert.NoError(t, err)
 		})
 
-		t.Run("DeleteEnvironment", func(t *testing.T) {
+		t.Run("ListEnvironments", func(t *testing.T) {
 			mockProvider.
 				AddInteraction().
-				Given("an environment with uuid 8000883c-abf0-4b4c-b993-426f607092a9 exists").
-				UponReceiving("a request to delete an environment").
-				WithRequest("DELETE", S("/environments/8000883c-abf0-4b4c-b993-426f607092a9")).
+				Given("an environment with name TerraformEnvironment exists").
+				UponReceiving("a request to find an environment by name").
+				WithRequest("GET", S("/environments")).
+				WithQuery("name", S(environment.Name)).
 				WithHeader("Authorization", Like("Bearer 1234")).
-				WillRespondWith(200)
+				WillRespondWith(200).
+				WithHeader("Content-Type", S("application/hal+json")).
+				WithJSONBody(Like(map[string]interface{}{
+					"_embedded": map[string]interface{}{
+						"environments": EachLike(created, 1),
+					},
+				}))
 
 			err = mockProvider.ExecuteTest(t, func(config MockServerConfig) error {
 				client := clientForPact(config)
 
-				return client.DeleteEnvironment(broker.Environment{
-					UUID: created.UUID,
+				res, e := client.ListEnvironments(environment.Name)
+				assert.NoError(t, e)
+				assert.Len(t, res.Embedded.Environments, 1)
+				assert.Equal(t, created.UUID, res.Embedded.Environments[0].UUID)
+
+				return e
+			})
+			assert.NoError(t, err)
+		})
+
+		t.Run("ListCurrentlyDeployedVersions", func(t *testing.T) {
+			deployed := broker.DeployedVersion{
+				UUID:              "ff3adecf-cfc5-4653-a4e3-f1861092f8e0",
+				CurrentlyDeployed: true,
+				CreatedAt:         "2021-09-08T05:08:13+00:00",
+				Embedded: broker.DeployedVersionEmbeddedItems{
+					Pacticipant: broker.Pacticipant{
+						Name: "terraform-client",
+					},
+					Version: broker.Version{
+						Number: "1.0.0",
+					},
+				},
+			}
+
+			mockProvider.
+				AddInteraction().
+				Given("version 1.0.0 of terraform-client is deployed to environment with uuid 8000883c-abf0-4b4c-b993-426f607092a9").
+				UponReceiving("a request to list the currently deployed versions of an environment").
+				WithRequest("GET", S("/environments/8000883c-abf0-4b4c-b993-426f607092a9/deployed-versions/currently-deployed")).
+				WithHeader("Authorization", Like("Bearer 1234")).
+				WillRespondWith(200).
+				WithHeader("Content-Type", S("application/hal+json")).
+				WithJSONBody(Like(map[string]interface{}{
+					"_embedded": map[string]interface{}{
+						"deployedVersions": EachLike(deployed, 1),
+					},
+				}))
+
+			err = mockProvider.ExecuteTest(t, func(config MockServerConfig) error {
+				client := clientForPact(config)
+
+				res, e := client.ListCurrentlyDeployedVersions(created.UUID)
+				assert.NoError(t, e)
+				assert.Len(t, res.Embedded.DeployedVersions, 1)
+				assert.Equal(t, "1.0.0", res.Embedded.DeployedVersions[0].Embedded.Version.Number)
+
+				return e
+			})
+			assert.NoError(t, err)
+		})
+
+		t.Run("ListCurrentlySupportedReleasedVersions", func(t *testing.T) {
+			released := broker.DeployedVersion{
+				UUID:               "fb4ac6b2-3c8c-4be5-8b8d-0f0e1f7a7b61",
+				CurrentlySupported: true,
+				CreatedAt:          "2021-09-08T05:08:13+00:00",
+				Embedded: broker.DeployedVersionEmbeddedItems{
+					Pacticipant: broker.Pacticipant{
+						Name: "terraform-client",
+					},
+					Version: broker.Version{
+						Number: "1.0.0",
+					},
+				},
+			}
+
+			mockProvider.
+				AddInteraction().
+				Given("version 1.0.0 of terraform-client is released to environment with uuid 8000883c-abf0-4b4c-b993-426f607092a9").
+				UponReceiving("a request to list the currently supported released versions of an environment").
+				WithRequest("GET", S("/environments/8000883c-abf0-4b4c-b993-426f607092a9/released-versions/currently-supported")).
+				WithHeader("Authorization", Like("Bearer 1234")).
+				WillRespondWith(200).
+				WithHeader("Content-Type", S("application/hal+json")).
+				WithJSONBody(Like(map[string]interface{}{
+					"_embedded": map[string]interface{}{
+						"releasedVersions": EachLike(released, 1),
+					},
+				}))
+
+			err = mockProvider.ExecuteTest(t, func(config MockServerConfig) error {
+				client := clientForPact(config)
+
+				res, e := client.ListCurrentlySupportedReleasedVersions(created.UUID)
+				assert.NoError(t, e)
+				assert.Len(t, res.Embedded.ReleasedVersions, 1)
+				assert.True(t, res.Embedded.ReleasedVersions[0].CurrentlySupported)
+
+				return e
+			})
+			assert.NoError(t, err)
+		})
+
+		t.Run("DeleteEnvironment", func(t *testing.T) {
+			mockProvider.
+				AddInteraction().
+				Given("an environment with uuid 8000883c-abf0-4b4c-b993-426f607092a9 exists").
+				UponReceiving("a request to delete an environment").
+				WithRequest("DELETE", S("/environments/8000883c-abf0-4b4c-b993-426f607092a9")).
+				WithHeader("Authorization", Like("Bearer 1234")).
+				WillRespondWith(200)
+
+			err = mockProvider.ExecuteTest(t, func(config MockServerConfig) error {
+				client := clientForPact(config)
+
+				return client.DeleteEnvironment(broker.Environment{
+					UUID: created.UUID,
+				})
+			})
+			assert.NoError(t, err)
+		})
+	})
+
+	t.Run("NotificationSettings", func(t *testing.T) {
+		settings := broker.NotificationSettings{
+			VerificationFailureDigest: true,
+			DigestFrequency:           "daily",
+			Recipients:                []string{"platform@example.com"},
+		}
+
+		t.Run("SetNotificationSettings", func(t *testing.T) {
+			mockProvider.
+				AddInteraction().
+				UponReceiving("a request to update notification settings").
+				WithRequest("PUT", S("/admin/tenant/notification-settings")).
+				WithHeader("Content-Type", S("application/json")).
+				WithHeader("Authorization", Like("Bearer 1234")).
+				WithJSONBody(Like(settings)).
+				WillRespondWith(200).
+				WithHeader("Content-Type", S("application/hal+json")).
+				WithJSONBody(Like(settings))
+
+			err = mockProvider.ExecuteTest(t, func(config MockServerConfig) error {
+				client := clientForPact(config)
+
+				res, e := client.SetNotificationSettings(settings)
+				assert.NoError(t, e)
+				assert.True(t, res.VerificationFailureDigest)
+				assert.Contains(t, res.Recipients, "platform@example.com")
+
+				return e
+			})
+			assert.NoError(t, err)
+		})
+
+		t.Run("ReadNotificationSettings", func(t *testing.T) {
+			mockProvider.
+				AddInteraction().
+				Given("a team with uuid 99643109-adb0-4e68-b25f-7b14d6bcae16 exists").
+				UponReceiving("a request to get the notification settings for a team").
+				WithRequest("GET", S("/admin/teams/99643109-adb0-4e68-b25f-7b14d6bcae16/notification-settings")).
+				WithHeader("Authorization", Like("Bearer 1234")).
+				WillRespondWith(200).
+				WithHeader("Content-Type", S("application/hal+json")).
+				WithJSONBody(Like(settings))
+
+			err = mockProvider.ExecuteTest(t, func(config MockServerConfig) error {
+				client := clientForPact(config)
+
+				res, e := client.ReadNotificationSettings("99643109-adb0-4e68-b25f-7b14d6bcae16")
+				assert.NoError(t, e)
+				assert.Equal(t, "daily", res.DigestFrequency)
+
+				return e
+			})
+			assert.NoError(t, err)
+		})
+	})
+
+	t.Run("ProviderContract", func(t *testing.T) {
+		publish := broker.ProviderContractPublishRequest{
+			Provider:                 "terraform-provider",
+			PacticipantVersionNumber: "1.0.0",
+			Branch:                   "main",
+			Contract: broker.ProviderContract{
+				Content:       "b3BlbmFwaTogMy4wLjEK",
+				ContentType:   "application/yaml",
+				Specification: "oas",
+				SelfVerificationResults: &broker.SelfVerificationResults{
+					Success:     true,
+					Content:     "VGVzdHMgcGFzc2VkCg==",
+					ContentType: "text/plain",
+					Format:      "text",
+					Verifier:    "schemathesis",
+				},
+			},
+		}
+
+		t.Run("PublishProviderContract", func(t *testing.T) {
+			mockProvider.
+				AddInteraction().
+				UponReceiving("a request to publish a provider contract").
+				WithRequest("POST", S("/provider-contracts/provider/terraform-provider/publish")).
+				WithHeader("Content-Type", S("application/json")).
+				WithHeader("Authorization", Like("Bearer 1234")).
+				WithJSONBody(Like(publish)).
+				WillRespondWith(200).
+				WithHeader("Content-Type", S("application/hal+json"))
+
+			err = mockProvider.ExecuteTest(t, func(config MockServerConfig) error {
+				client := clientForPact(config)
+
+				return client.PublishProviderContract(publish)
+			})
+			assert.NoError(t, err)
+		})
+
+		t.Run("ReadProviderContract", func(t *testing.T) {
+			mockProvider.
+				AddInteraction().
+				Given("a provider contract for terraform-provider version 1.0.0 exists").
+				UponReceiving("a request to get a provider contract").
+				WithRequest("GET", S("/contracts/provider/terraform-provider/version/1.0.0")).
+				WithHeader("Authorization", Like("Bearer 1234")).
+				WillRespondWith(200).
+				WithHeader("Content-Type", S("application/hal+json")).
+				WithJSONBody(Like(publish.Contract))
+
+			err = mockProvider.ExecuteTest(t, func(config MockServerConfig) error {
+				client := clientForPact(config)
+
+				res, e := client.ReadProviderContract("terraform-provider", "1.0.0")
+				assert.NoError(t, e)
+				assert.Equal(t, "oas", res.Specification)
+
+				return e
+			})
+			assert.NoError(t, err)
+		})
+	})
+
+	t.Run("BadgeSettings", func(t *testing.T) {
+		settings := broker.BadgeSettings{
+			PublicReadAccess: true,
+		}
+
+		t.Run("SetBadgeSettings", func(t *testing.T) {
+			mockProvider.
+				AddInteraction().
+				UponReceiving("a request to update badge settings").
+				WithRequest("PUT", S("/admin/tenant/badge-settings")).
+				WithHeader("Content-Type", S("application/json")).
+				WithHeader("Authorization", Like("Bearer 1234")).
+				WithJSONBody(Like(settings)).
+				WillRespondWith(200).
+				WithHeader("Content-Type", S("application/hal+json")).
+				WithJSONBody(Like(settings))
+
+			err = mockProvider.ExecuteTest(t, func(config MockServerConfig) error {
+				client := clientForPact(config)
+
+				res, e := client.SetBadgeSettings(settings)
+				assert.NoError(t, e)
+				assert.True(t, res.PublicReadAccess)
+
+				return e
+			})
+			assert.NoError(t, err)
+		})
+	})
+
+	t.Run("ChatIntegration", func(t *testing.T) {
+		integration := broker.ChatIntegration{
+			Type:       "slack",
+			Workspace:  "pactflow",
+			Channel:    "#contract-testing",
+			WebhookURL: "https://hooks.slack.com/services/T000/B000/XXXX",
+			Enabled:    true,
+			Events: []broker.WebhookEvent{
+				{
+					Name: "provider_verification_failed",
+				},
+			},
+		}
+
+		created := broker.ChatIntegrationResponse{
+			ChatIntegration: broker.ChatIntegration{
+				UUID:      "3c6b7d3a-8bdf-4c5e-a6f4-1a1f8e3d9a21",
+				Type:      integration.Type,
+				Workspace: integration.Workspace,
+				Channel:   integration.Channel,
+				Enabled:   integration.Enabled,
+				Events:    integration.Events,
+			},
+		}
+
+		t.Run("CreateChatIntegration", func(t *testing.T) {
+			mockProvider.
+				AddInteraction().
+				UponReceiving("a request to create a chat integration").
+				WithRequest("POST", S("/admin/chat-integrations")).
+				WithHeader("Content-Type", S("application/json")).
+				WithHeader("Authorization", Like("Bearer 1234")).
+				WithJSONBody(Like(integration)).
+				WillRespondWith(200).
+				WithHeader("Content-Type", S("application/hal+json")).
+				WithJSONBody(Like(created))
+
+			err = mockProvider.ExecuteTest(t, func(config MockServerConfig) error {
+				client := clientForPact(config)
+
+				res, e := client.CreateChatIntegration(integration)
+				assert.NoError(t, e)
+				assert.Equal(t, "#contract-testing", res.Channel)
+				assert.NotEmpty(t, res.UUID)
+
+				return e
+			})
+			assert.NoError(t, err)
+		})
+
+		t.Run("DeleteChatIntegration", func(t *testing.T) {
+			mockProvider.
+				AddInteraction().
+				Given("a chat integration with uuid 3c6b7d3a-8bdf-4c5e-a6f4-1a1f8e3d9a21 exists").
+				UponReceiving("a request to delete a chat integration").
+				WithRequest("DELETE", S("/admin/chat-integrations/3c6b7d3a-8bdf-4c5e-a6f4-1a1f8e3d9a21")).
+				WithHeader("Authorization", Like("Bearer 1234")).
+				WillRespondWith(200)
+
+			err = mockProvider.ExecuteTest(t, func(config MockServerConfig) error {
+				client := clientForPact(config)
+
+				return client.DeleteChatIntegration(created.ChatIntegration)
+			})
+			assert.NoError(t, err)
+		})
+	})
+
+	t.Run("Matrix", func(t *testing.T) {
+		deployable := true
+		matrix := broker.MatrixResponse{
+			Summary: broker.MatrixSummary{
+				Deployable: &deployable,
+				Reason:     "All required verification results are published and successful",
+				Success:    1,
+			},
+			Matrix: []broker.MatrixRow{
+				{
+					Consumer: broker.MatrixPacticipant{
+						Name:    "terraform-client",
+						Version: broker.MatrixVersion{Number: "1.0.0"},
+					},
+					Provider: broker.MatrixPacticipant{
+						Name:    "terraform-provider",
+						Version: broker.MatrixVersion{Number: "2.0.0"},
+					},
+					Pact: broker.MatrixPact{
+						CreatedAt: "2023-03-17T01:11:10+00:00",
+					},
+					VerificationResult: &broker.MatrixVerificationResult{
+						Success:    true,
+						VerifiedAt: "2023-03-17T01:15:10+00:00",
+					},
+				},
+			},
+		}
+
+		t.Run("QueryMatrix", func(t *testing.T) {
+			mockProvider.
+				AddInteraction().
+				Given("the pact between terraform-client 1.0.0 and terraform-provider 2.0.0 has been verified").
+				UponReceiving("a request to query the matrix").
+				WithRequest("GET", S("/matrix")).
+				WithQuery("q[][pacticipant]", S("terraform-client"), S("terraform-provider")).
+				WithQuery("q[][version]", S("1.0.0"), S("2.0.0")).
+				WithQuery("latestby", S("cvpv")).
+				WithHeader("Authorization", Like("Bearer 1234")).
+				WillRespondWith(200).
+				WithHeader("Content-Type", S("application/hal+json")).
+				WithJSONBody(Like(matrix))
+
+			err = mockProvider.ExecuteTest(t, func(config MockServerConfig) error {
+				client := clientForPact(config)
+
+				res, e := client.QueryMatrix(broker.MatrixQuery{
+					Selectors: []broker.MatrixSelector{
+						{Pacticipant: "terraform-client", Version: "1.0.0"},
+						{Pacticipant: "terraform-provider", Version: "2.0.0"},
+					},
+					LatestBy: "cvpv",
 				})
+				assert.NoError(t, e)
+				assert.True(t, *res.Summary.Deployable)
+				assert.Len(t, res.Matrix, 1)
+
+				return e
 			})
 			assert.NoError(t, err)
 		})
 	})
 
+	t.Run("PactsForVerification", func(t *testing.T) {
+		request := broker.PactsForVerificationRequest{
+			Provider: "terraform-provider",
+			ConsumerVersionSelectors: []broker.ConsumerVersionSelector{
+				{MainBranch: true},
+			},
+			ProviderVersionBranch: "main",
+			IncludePendingStatus:  true,
+		}
+
+		pact := broker.PactForVerification{
+			ShortDescription: "latest from main branch",
+			VerificationProperties: broker.VerificationProperties{
+				Pending: false,
+			},
+			HalDoc: broker.HalDoc{
+				Links: broker.HalLinks{
+					"self": broker.Link{
+						Href: "http://localhost/pacts/provider/terraform-provider/consumer/terraform-client/pact-version/1234",
+						Name: "Pact between terraform-client (1.0.0) and terraform-provider",
+					},
+				},
+			},
+		}
+
+		t.Run("ReadPactsForVerification", func(t *testing.T) {
+			mockProvider.
+				AddInteraction().
+				Given("a pact between terraform-client and terraform-provider exists on the main branch").
+				UponReceiving("a request for the pacts for verification").
+				WithRequest("POST", S("/pacts/provider/terraform-provider/for-verification")).
+				WithHeader("Content-Type", S("application/json")).
+				WithHeader("Authorization", Like("Bearer 1234")).
+				WithJSONBody(Like(request)).
+				WillRespondWith(200).
+				WithHeader("Content-Type", S("application/hal+json")).
+				WithJSONBody(Like(map[string]interface{}{
+					"_embedded": map[string]interface{}{
+						"pacts": EachLike(pact, 1),
+					},
+				}))
+
+			err = mockProvider.ExecuteTest(t, func(config MockServerConfig) error {
+				client := clientForPact(config)
+
+				res, e := client.ReadPactsForVerification(request)
+				assert.NoError(t, e)
+				assert.Len(t, res.Embedded.Pacts, 1)
+				assert.Equal(t, "latest from main branch", res.Embedded.Pacts[0].ShortDescription)
+
+				return e
+			})
+			assert.NoError(t, err)
+		})
+	})
+
+	t.Run("AuditEvents", func(t *testing.T) {
+		t.Run("ListAuditEvents", func(t *testing.T) {
+			mockProvider.
+				AddInteraction().
+				Given("an audit event exists").
+				UponReceiving("a request to list audit events").
+				WithRequest("GET", S("/audit")).
+				WithQuery("from", S("2023-03-01T00:00:00Z")).
+				WithHeader("Authorization", Like("Bearer 1234")).
+				WillRespondWith(200).
+				WithHeader("Content-Type", S("application/hal+json")).
+				WithJSONBody(Like(map[string]interface{}{
+					"_embedded": map[string]interface{}{
+						"events": EachLike(map[string]interface{}{
+							"uuid":        Like("0b6f5a3c-6a3e-4a8c-8a0e-2f5f6d7b1c9e"),
+							"type":        Like("user.invited"),
+							"description": Like("Invited user jo@example.com"),
+							"actor": Like(map[string]interface{}{
+								"uuid":  "5e4b3a2c-1d0f-4e8a-9b7c-6a5d4c3b2a1f",
+								"name":  "Admin",
+								"email": "admin@example.com",
+							}),
+							"createdAt": Like("2023-03-17T01:11:10+00:00"),
+						}, 1),
+					},
+				}))
+
+			err = mockProvider.ExecuteTest(t, func(config MockServerConfig) error {
+				client := clientForPact(config)
+
+				res, e := client.ListAuditEvents("2023-03-01T00:00:00Z", "")
+				assert.NoError(t, e)
+				assert.Len(t, res.Embedded.Events, 1)
+				assert.Equal(t, "user.invited", res.Embedded.Events[0].Type)
+				assert.Equal(t, "admin@example.com", res.Embedded.Events[0].Actor.Email)
+
+				return e
+			})
+			assert.NoError(t, err)
+		})
+	})
+
+	t.Run("ProviderStates", func(t *testing.T) {
+		t.Run("ListProviderStates", func(t *testing.T) {
+			mockProvider.
+				AddInteraction().
+				Given("a pact between terraform-client and terraform-provider exists").
+				UponReceiving("a request to list the provider states of terraform-provider").
+				WithRequest("GET", S("/pacts/provider/terraform-provider/provider-states")).
+				WithHeader("Authorization", Like("Bearer 1234")).
+				WillRespondWith(200).
+				WithHeader("Content-Type", S("application/hal+json")).
+				WithJSONBody(Like(map[string]interface{}{
+					"providerStates": EachLike(map[string]interface{}{
+						"name":      Like("an order with id 1 exists"),
+						"consumers": EachLike("terraform-client", 1),
+					}, 1),
+				}))
+
+			err = mockProvider.ExecuteTest(t, func(config MockServerConfig) error {
+				client := clientForPact(config)
+
+				res, e := client.ListProviderStates("terraform-provider")
+				assert.NoError(t, e)
+				assert.Len(t, res.ProviderStates, 1)
+				assert.Equal(t, "an order with id 1 exists", res.ProviderStates[0].Name)
+
+				return e
+			})
+			assert.NoError(t, err)
+		})
+	})
+
+	t.Run("Integrations", func(t *testing.T) {
+		t.Run("ListIntegrations", func(t *testing.T) {
+			mockProvider.
+				AddInteraction().
+				Given("a pact between terraform-client and terraform-provider exists").
+				UponReceiving("a request to list integrations").
+				WithRequest("GET", S("/integrations")).
+				WithHeader("Authorization", Like("Bearer 1234")).
+				WillRespondWith(200).
+				WithHeader("Content-Type", S("application/hal+json")).
+				WithJSONBody(Like(map[string]interface{}{
+					"_embedded": map[string]interface{}{
+						"integrations": EachLike(map[string]interface{}{
+							"consumer": Like(map[string]interface{}{
+								"name": "terraform-client",
+							}),
+							"provider": Like(map[string]interface{}{
+								"name": "terraform-provider",
+							}),
+						}, 1),
+					},
+				}))
+
+			err = mockProvider.ExecuteTest(t, func(config MockServerConfig) error {
+				client := clientForPact(config)
+
+				res, e := client.ListIntegrations()
+				assert.NoError(t, e)
+				assert.Len(t, res.Embedded.Integrations, 1)
+				assert.Equal(t, "terraform-client", res.Embedded.Integrations[0].Consumer.Name)
+				assert.Equal(t, "terraform-provider", res.Embedded.Integrations[0].Provider.Name)
+
+				return e
+			})
+			assert.NoError(t, err)
+		})
+	})
+
+	t.Run("Pact", func(t *testing.T) {
+		t.Run("ListPactVersions", func(t *testing.T) {
+			mockProvider.
+				AddInteraction().
+				Given("a pact between terraform-client and terraform-provider exists").
+				UponReceiving("a request to list the versions of the pact between terraform-client and terraform-provider").
+				WithRequest("GET", S("/pacts/provider/terraform-provider/consumer/terraform-client/versions")).
+				WithHeader("Authorization", Like("Bearer 1234")).
+				WillRespondWith(200).
+				WithHeader("Content-Type", S("application/hal+json")).
+				WithJSONBody(Like(map[string]interface{}{
+					"_links": map[string]interface{}{
+						"pb:pact-versions": EachLike(map[string]interface{}{
+							"href": Like("http://localhost/pacts/provider/terraform-provider/consumer/terraform-client/version/1.0.0"),
+							"name": Like("1.0.0"),
+						}, 1),
+					},
+				}))
+
+			err = mockProvider.ExecuteTest(t, func(config MockServerConfig) error {
+				client := clientForPact(config)
+
+				res, e := client.ListPactVersions("terraform-client", "terraform-provider")
+				assert.NoError(t, e)
+				assert.Len(t, res.Links.PactVersions, 1)
+				assert.Equal(t, "1.0.0", res.Links.PactVersions[0].Name)
+
+				return e
+			})
+			assert.NoError(t, err)
+		})
+
+		t.Run("ReadPact", func(t *testing.T) {
+			mockProvider.
+				AddInteraction().
+				Given("a pact between terraform-client and terraform-provider exists").
+				UponReceiving("a request to get the pact between terraform-client 1.0.0 and terraform-provider").
+				WithRequest("GET", S("/pacts/provider/terraform-provider/consumer/terraform-client/version/1.0.0")).
+				WithHeader("Authorization", Like("Bearer 1234")).
+				WillRespondWith(200).
+				WithHeader("Content-Type", S("application/hal+json")).
+				WithJSONBody(Like(map[string]interface{}{
+					"consumer": map[string]interface{}{
+						"name": "terraform-client",
+					},
+					"provider": map[string]interface{}{
+						"name": "terraform-provider",
+					},
+					"interactions": EachLike(map[string]interface{}{
+						"description": Like("a request for an order"),
+					}, 1),
+					"metadata": map[string]interface{}{
+						"pactSpecification": map[string]interface{}{
+							"version": Like("2.0.0"),
+						},
+					},
+					"createdAt": Like("2023-03-17T01:11:10+00:00"),
+				}))
+
+			err = mockProvider.ExecuteTest(t, func(config MockServerConfig) error {
+				client := clientForPact(config)
+
+				res, e := client.ReadPact("terraform-client", "terraform-provider", "1.0.0")
+				assert.NoError(t, e)
+				assert.Len(t, res.Interactions, 1)
+				assert.Equal(t, "2.0.0", res.Metadata.PactSpecification.Version)
+
+				return e
+			})
+			assert.NoError(t, err)
+		})
+	})
 }
 
 func clientForPact(config MockServerConfig) *Client {
@@ -1 +1,307 @@
 package client
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/pactflow/terraform/broker"
+	"github.com/pactflow/terraform/version"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewRequest_EscapedPathSegments(t *testing.T) {
+	baseURL, _ := url.Parse("https://broker.example.com")
+	c := NewClient(nil, Config{BaseURL: baseURL})
+
+	req, err := c.newRequest("GET", urlEncodeTemplate(branchLatestVersionTemplate, "my app", "feature/foo"), nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "https://broker.example.com/pacticipants/my%20app/branches/feature%2Ffoo/latest-version", req.URL.String())
+}
+
+func TestNewRequest_QueryString(t *testing.T) {
+	baseURL, _ := url.Parse("https://broker.example.com")
+	c := NewClient(nil, Config{BaseURL: baseURL})
+
+	req, err := c.newRequest("GET", "/environments?name=production", nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "/environments", req.URL.Path)
+	assert.Equal(t, "production", req.URL.Query().Get("name"))
+}
+
+func TestNewRequest_BasicAuth(t *testing.T) {
+	baseURL, _ := url.Parse("https://broker.example.com")
+	c := NewClient(nil, Config{BaseURL: baseURL, BasicAuthUsername: "pact_broker", BasicAuthPassword: "secret"})
+
+	req, err := c.newRequest("GET", "/pacticipants", nil)
+	assert.NoError(t, err)
+
+	username, password, ok := req.BasicAuth()
+	assert.True(t, ok)
+	assert.Equal(t, "pact_broker", username)
+	assert.Equal(t, "secret", password)
+}
+
+func TestNewClient_CustomTLSConfig(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/hal+json")
+		fmt.Fprint(w, `{"name": "Foo"}`)
+	}))
+	defer server.Close()
+
+	pool := x509.NewCertPool()
+	pool.AddCert(server.Certificate())
+	baseURL, _ := url.Parse(server.URL)
+
+	_, err := NewClient(nil, Config{BaseURL: baseURL}).ReadPacticipant("Foo")
+	assert.Error(t, err, "expected the test server's certificate to be untrusted by default")
+
+	_, err = NewClient(nil, Config{BaseURL: baseURL, CustomTLSConfig: &tls.Config{RootCAs: pool}}).ReadPacticipant("Foo")
+	assert.NoError(t, err)
+	assert.Nil(t, http.DefaultClient.Transport, "expected http.DefaultClient to be left alone")
+}
+
+func TestNewClient_ProxyURL(t *testing.T) {
+	var proxied string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxied = r.URL.String()
+		w.Header().Set("Content-Type", "application/hal+json")
+		fmt.Fprint(w, `{"name": "Foo"}`)
+	}))
+	defer proxy.Close()
+
+	baseURL, _ := url.Parse("http://broker.invalid")
+	proxyURL, _ := url.Parse(proxy.URL)
+
+	_, err := NewClient(nil, Config{BaseURL: baseURL, ProxyURL: proxyURL}).ReadPacticipant("Foo")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "http://broker.invalid/pacticipants/Foo", proxied)
+}
+
+func TestNewClient_RequestTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(200 * time.Millisecond)
+	}))
+	defer server.Close()
+
+	baseURL, _ := url.Parse(server.URL)
+
+	_, err := NewClient(nil, Config{BaseURL: baseURL, RequestTimeout: 20 * time.Millisecond}).ReadPacticipant("Foo")
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "Client.Timeout exceeded")
+}
+
+func TestNewRequest_UserAgent(t *testing.T) {
+	baseURL, _ := url.Parse("https://broker.example.com")
+
+	req, _ := NewClient(nil, Config{BaseURL: baseURL}).newRequest("GET", "/", nil)
+	assert.Equal(t, "terraform-provider-pact/"+version.LIBRARY_VERSION, req.Header.Get("User-Agent"))
+
+	req, _ = NewClient(nil, Config{BaseURL: baseURL, UserAgentSuffix: "pipeline/1234"}).newRequest("GET", "/", nil)
+	assert.Equal(t, "terraform-provider-pact/"+version.LIBRARY_VERSION+" pipeline/1234", req.Header.Get("User-Agent"))
+}
+
+func TestNewRequest_DefaultHeaders(t *testing.T) {
+	baseURL, _ := url.Parse("https://broker.example.com")
+	c := NewClient(nil, Config{BaseURL: baseURL, AccessToken: "abc", DefaultHeaders: map[string]string{
+		"X-Correlation-Id": "1234",
+		"Authorization":    "Bearer other",
+	}})
+
+	req, err := c.newRequest("GET", "/", nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "1234", req.Header.Get("X-Correlation-Id"))
+	assert.Equal(t, []string{"Bearer abc"}, req.Header.Values("Authorization"))
+}
+
+func TestMatrixQueryString_KeepsSelectorOrder(t *testing.T) {
+	q := broker.MatrixQuery{
+		Selectors: []broker.MatrixSelector{
+			{Pacticipant: "Foo", Version: "1.2.3"},
+			{Pacticipant: "Bar", Latest: true, Branch: "main"},
+		},
+		LatestBy: "cvpv",
+		Limit:    10,
+	}
+
+	assert.Equal(t, "q%5B%5D%5Bpacticipant%5D=Foo&q%5B%5D%5Bversion%5D=1.2.3&q%5B%5D%5Bpacticipant%5D=Bar&q%5B%5D%5Bbranch%5D=main&q%5B%5D%5Blatest%5D=true&latestby=cvpv&limit=10", matrixQueryString(q))
+}
+
+func TestReadLatestVerificationResult(t *testing.T) {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/hal+json")
+
+		switch r.URL.Path {
+		case "/pacts/provider/Bar/consumer/Foo/latest":
+			fmt.Fprintf(w, `{"_links": {"pb:latest-verification-results": {"href": "%s/pacts/provider/Bar/consumer/Foo/pact-version/1234/verification-results/latest"}}}`, server.URL)
+		case "/pacts/provider/Bar/consumer/Foo/pact-version/1234/verification-results/latest":
+			fmt.Fprint(w, `{"success": true, "providerApplicationVersion": "4.5.6"}`)
+		case "/pacts/provider/Bar/consumer/Elsewhere/latest":
+			fmt.Fprint(w, `{"_links": {"pb:latest-verification-results": {"href": "http://elsewhere.invalid/pacts/provider/Bar/consumer/Foo/pact-version/1234/verification-results/latest"}}}`)
+		case "/pacts/provider/Bar/consumer/Unverified/latest":
+			fmt.Fprint(w, `{"_links": {}}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"error": "not found"}`)
+		}
+	}))
+	defer server.Close()
+
+	baseURL, _ := url.Parse(server.URL)
+	c := NewClient(nil, Config{BaseURL: baseURL})
+
+	t.Run("follows the latest verification results link", func(t *testing.T) {
+		res, err := c.ReadLatestVerificationResult("Foo", "Bar")
+
+		assert.NoError(t, err)
+		assert.True(t, res.Success)
+		assert.Equal(t, "4.5.6", res.ProviderApplicationVersion)
+	})
+
+	t.Run("only follows the path of a link to another host", func(t *testing.T) {
+		res, err := c.ReadLatestVerificationResult("Elsewhere", "Bar")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "4.5.6", res.ProviderApplicationVersion)
+	})
+
+	t.Run("returns nil when the pact is unverified", func(t *testing.T) {
+		res, err := c.ReadLatestVerificationResult("Unverified", "Bar")
+
+		assert.NoError(t, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("returns an error when there is no pact", func(t *testing.T) {
+		_, err := c.ReadLatestVerificationResult("Missing", "Bar")
+
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+}
+
+func TestDryRun_DoesNotSendChanges(t *testing.T) {
+	var methods []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		methods = append(methods, r.Method)
+		w.Header().Set("Content-Type", "application/hal+json")
+		fmt.Fprint(w, `{"name": "db-password"}`)
+	}))
+	defer server.Close()
+
+	baseURL, _ := url.Parse(server.URL)
+	c := NewClient(nil, Config{BaseURL: baseURL, DryRun: true})
+
+	_, err := c.CreateSecret(broker.Secret{Name: "db-password", Value: "hunter2"})
+
+	assert.ErrorIs(t, err, ErrDryRun)
+	assert.Contains(t, err.Error(), "POST "+server.URL+"/secrets")
+	assert.Contains(t, err.Error(), `"name":"db-password"`)
+	assert.NotContains(t, err.Error(), "hunter2")
+
+	_, err = c.ReadSecret("1234")
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"GET"}, methods)
+}
+
+func TestReadToken_NotFound(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/hal+json")
+		fmt.Fprint(w, `{"_embedded": {"items": [{"uuid": "1234", "description": "Read only token (developer)"}]}}`)
+	}))
+	defer server.Close()
+
+	baseURL, _ := url.Parse(server.URL)
+	c := NewClient(nil, Config{BaseURL: baseURL})
+
+	_, err := c.ReadToken("5678")
+
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestPath_FollowsIndexLinks(t *testing.T) {
+	baseURL, _ := url.Parse("https://broker.example.com")
+	c := NewClient(nil, Config{BaseURL: baseURL})
+
+	assert.Equal(t, "/webhooks/abc", c.path(webhookReadUpdateDeleteTemplate, "abc"))
+
+	c.UseIndex(&broker.Index{Links: map[string]json.RawMessage{
+		"pb:webhook":      json.RawMessage(`{"href": "http://internal-broker/api/webhooks/{uuid}", "templated": true}`),
+		"pb:pacticipants": json.RawMessage(`{"href": "https://broker.example.com/api/pacticipants"}`),
+		"pb:pacticipant":  json.RawMessage(`{"href": "https://broker.example.com/api/pacticipants"}`),
+	}})
+
+	// Only the path of a link is used, even when the broker links to a different host
+	assert.Equal(t, "/api/webhooks/feature%2Fabc", c.path(webhookReadUpdateDeleteTemplate, "feature/abc"))
+	assert.Equal(t, "/api/pacticipants", c.path(pacticipantCreateTemplate))
+	// A link without a placeholder for each parameter, or with no link at all, falls back to the template
+	assert.Equal(t, "/pacticipants/Foo", c.path(pacticipantReadUpdateDeleteTemplate, "Foo"))
+	assert.Equal(t, "/admin/teams/abc", c.path(teamReadUpdateDeleteTemplate, "abc"))
+	assert.Equal(t, "/admin/tenant/authentication-settings", c.path(tenantAuthenticationTemplate))
+}
+
+func TestListPactVersions_FollowsNextLinks(t *testing.T) {
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.URL.RequestURI())
+		w.Header().Set("Content-Type", "application/hal+json")
+
+		if r.URL.Query().Get("page") == "" {
+			fmt.Fprint(w, `{"_links": {"pb:pact-versions": [{"name": "1.0.1"}, {"name": "1.0.0"}], "next": {"href": "http://internal-broker/pacts/provider/Bar/consumer/Foo/versions?page=2"}}}`)
+			return
+		}
+		fmt.Fprint(w, `{"_links": {"pb:pact-versions": [{"name": "0.9.0"}]}}`)
+	}))
+	defer server.Close()
+
+	baseURL, _ := url.Parse(server.URL)
+	c := NewClient(nil, Config{BaseURL: baseURL})
+
+	res, err := c.ListPactVersions("Foo", "Bar")
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"/pacts/provider/Bar/consumer/Foo/versions", "/pacts/provider/Bar/consumer/Foo/versions?page=2"}, requests)
+	assert.Len(t, res.Links.PactVersions, 3)
+	assert.Equal(t, "0.9.0", res.Links.PactVersions[2].Name)
+}
+
+func TestListPacticipants_FollowsNextLinks(t *testing.T) {
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.URL.RequestURI())
+		w.Header().Set("Content-Type", "application/hal+json")
+
+		switch r.URL.Query().Get("page") {
+		case "":
+			// The broker may link to its own host name, rather than the one the provider was configured with
+			fmt.Fprint(w, `{"_embedded": {"pacticipants": [{"name": "Foo"}]}, "_links": {"next": {"href": "http://internal-broker/pacticipants?page=2"}}}`)
+		case "2":
+			fmt.Fprint(w, `{"_embedded": {"pacticipants": [{"name": "Bar"}]}, "_links": {"next": {"href": "/pacticipants?page=3"}, "curies": [{"name": "pb"}]}}`)
+		default:
+			fmt.Fprint(w, `{"_embedded": {"pacticipants": [{"name": "Baz"}]}, "_links": {}}`)
+		}
+	}))
+	defer server.Close()
+
+	baseURL, _ := url.Parse(server.URL)
+	c := NewClient(nil, Config{BaseURL: baseURL})
+
+	res, err := c.ListPacticipants()
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"/pacticipants", "/pacticipants?page=2", "/pacticipants?page=3"}, requests)
+	assert.Len(t, res.Embedded.Pacticipants, 3)
+	assert.Equal(t, "Baz", res.Embedded.Pacticipants[2].Name)
+}
@@ -3,9 +3,13 @@ package client
 import (
 	"errors"
 	"fmt"
+	"sort"
 	"strings"
 )
 
+// maxErrorExcerptLength limits how much of an unrecognised error body is included in an error
+const maxErrorExcerptLength = 300
+
 type apiErrorKey string
 type apiErrorDescriptions []string
 
@@ -17,6 +21,8 @@ type apiErrorResponse struct {
 	Reference    string          `json:"reference"`
 	ErrorDetails apiErrorMessage `json:"error"`
 	err          error
+	request      string
+	excerpt      string
 }
 
 // apiErrorMessage represents are higher-level error such as for the cause of a 5xx
@@ -30,6 +36,28 @@ type apiArrayErrorResponse struct {
 	Reference    string               `json:"reference"`
 	ErrorDetails apiErrorMessage      `json:"error"`
 	err          error
+	request      string
+	excerpt      string
+}
+
+// problemResponse represents an RFC 7807 problem details body (application/problem+json), which newer brokers
+// return when asked to, e.g. {"title": "Validation errors", "errors": [{"detail": "must be filled", "pointer": "/name"}]}
+type problemResponse struct {
+	Title     string          `json:"title"`
+	Detail    string          `json:"detail"`
+	Reference string          `json:"reference"`
+	Errors    []problemDetail `json:"errors"`
+	err       error
+	request   string
+	excerpt   string
+}
+
+// problemDetail is one of the problems in a problem details body, such as a validation error. The pointer
+// is the JSON pointer to the attribute it is about, if any
+type problemDetail struct {
+	Title   string `json:"title"`
+	Detail  string `json:"detail"`
+	Pointer string `json:"pointer"`
 }
 
 // type apiError interface {
@@ -48,26 +76,36 @@ func (e *apiErrorResponse) Error() string {
 			errors.WriteString(fmt.Sprintf("\t\tsummary: %s\n", e.ErrorDetails.Message))
 		}
 
-		if len(e.Errors) > 0 {
-			for _, v := range e.Errors {
-				errors.WriteString(fmt.Sprintf("\t\t%s\n", strings.Join(v, "\n")))
-			}
+		// Name the attribute each error is for, so that it's clear what to change in the configuration
+		keys := make([]string, 0, len(e.Errors))
+		for k := range e.Errors {
+			keys = append(keys, string(k))
+		}
+		sort.Strings(keys)
+
+		for _, k := range keys {
+			errors.WriteString(fmt.Sprintf("\t\t%s: %s\n", k, strings.Join(e.Errors[apiErrorKey(k)], ", ")))
 		}
 
 		if e.Reference != "" {
 			errors.WriteString(fmt.Sprintf("\t\treference: %s\n", e.Reference))
 		}
 	} else {
-		errors.WriteString("\n\tplease see the log for error details\n")
+		errors.WriteString(unrecognisedErrorDetails(e.excerpt))
 	}
 
 	if e.err != nil {
-		return fmt.Sprintf("%s \n\n%s", e.err, errors.String())
+		return fmt.Sprintf("%s \n\n%s", describeError(e.err, e.request), errors.String())
 	}
 
 	return errors.String()
 }
 
+// Unwrap returns the underlying error (e.g. ErrNotFound) for use with errors.Is
+func (e *apiErrorResponse) Unwrap() error {
+	return e.err
+}
+
 func (e *apiArrayErrorResponse) Error() string {
 	errors := new(strings.Builder)
 	if e.ErrorDetails.Message != "" || len(e.Errors) > 0 || e.Reference != "" {
@@ -85,16 +123,108 @@ func (e *apiArrayErrorResponse) Error() string {
 			errors.WriteString(fmt.Sprintf("\t\treference: %s\n", e.Reference))
 		}
 	} else {
-		errors.WriteString("\n\tplease see the log for error details\n")
+		errors.WriteString(unrecognisedErrorDetails(e.excerpt))
 	}
 
 	if e.err != nil {
-		return fmt.Sprintf("%s\n\n%s", e.err, errors.String())
+		return fmt.Sprintf("%s\n\n%s", describeError(e.err, e.request), errors.String())
 	}
 
 	return errors.String()
 }
 
+func (e *problemResponse) Error() string {
+	errors := new(strings.Builder)
+	if e.Title != "" || e.Detail != "" || len(e.Errors) > 0 {
+		errors.WriteString("\terror details: \n")
+
+		summary := e.Title
+		if e.Detail != "" && summary != "" {
+			summary = fmt.Sprintf("%s: %s", summary, e.Detail)
+		} else if e.Detail != "" {
+			summary = e.Detail
+		}
+		if summary != "" {
+			errors.WriteString(fmt.Sprintf("\t\tsummary: %s\n", summary))
+		}
+
+		// Name the attribute each problem is about, in the same form as a keyed error
+		for _, problem := range e.Errors {
+			message := problem.Detail
+			if message == "" {
+				message = problem.Title
+			}
+			if attribute := pointerAttribute(problem.Pointer); attribute != "" {
+				errors.WriteString(fmt.Sprintf("\t\t%s: %s\n", attribute, message))
+			} else {
+				errors.WriteString(fmt.Sprintf("\t\t%s\n", message))
+			}
+		}
+
+		if e.Reference != "" {
+			errors.WriteString(fmt.Sprintf("\t\treference: %s\n", e.Reference))
+		}
+	} else {
+		errors.WriteString(unrecognisedErrorDetails(e.excerpt))
+	}
+
+	if e.err != nil {
+		return fmt.Sprintf("%s \n\n%s", describeError(e.err, e.request), errors.String())
+	}
+
+	return errors.String()
+}
+
+// Unwrap returns the underlying error (e.g. ErrBadRequest) for use with errors.Is
+func (e *problemResponse) Unwrap() error {
+	return e.err
+}
+
+// pointerAttribute turns the JSON pointer of a problem (e.g. /request/url) into the dotted attribute name a
+// keyed error uses (request.url)
+func pointerAttribute(pointer string) string {
+	segments := strings.Split(strings.TrimPrefix(pointer, "/"), "/")
+	for i, segment := range segments {
+		segments[i] = strings.NewReplacer("~1", "/", "~0", "~").Replace(segment)
+	}
+
+	return strings.Join(segments, ".")
+}
+
+// describeError includes the request (e.g. "POST /webhooks") that the error was in response to
+func describeError(err error, request string) string {
+	if request == "" {
+		return err.Error()
+	}
+
+	return fmt.Sprintf("%s (%s)", err, request)
+}
+
+// unrecognisedErrorDetails includes an excerpt of an error body that isn't in one of the known formats,
+// rather than only logging it
+func unrecognisedErrorDetails(excerpt string) string {
+	if excerpt == "" {
+		return "\n\tplease see the log for error details\n"
+	}
+
+	return fmt.Sprintf("\n\tresponse: %s\n", excerpt)
+}
+
+// errorExcerpt collapses the whitespace in a (redacted) error body and trims it to maxErrorExcerptLength
+func errorExcerpt(body string) string {
+	excerpt := strings.Join(strings.Fields(body), " ")
+	if len(excerpt) > maxErrorExcerptLength {
+		excerpt = excerpt[:maxErrorExcerptLength] + "..."
+	}
+
+	return excerpt
+}
+
+// Unwrap returns the underlying error (e.g. ErrNotFound) for use with errors.Is
+func (e *apiArrayErrorResponse) Unwrap() error {
+	return e.err
+}
+
 var (
 	// ErrBadRequest represents an HTTP 400 error
 	ErrBadRequest = errors.New("bad request")
@@ -104,4 +234,17 @@ var (
 	ErrUnauthorized = errors.New("unauthorized")
 	// ErrForbidden represents an HTTP 403 permissions issue
 	ErrForbidden = errors.New("access denied, check that you have access to this resource")
+	// ErrNotFound represents an HTTP 404 error
+	ErrNotFound = errors.New("not found")
+	// ErrConflict represents an HTTP 409 error, e.g. a resource with the same name already exists
+	ErrConflict = errors.New("conflict")
+	// ErrPreconditionFailed represents an HTTP 412 error, the entity was changed since the ETag sent with
+	// If-Match was read
+	ErrPreconditionFailed = errors.New("precondition failed, it was changed since it was last read")
+	// ErrRateLimited represents an HTTP 429 error that was still being returned after retrying
+	ErrRateLimited = errors.New("rate limited, too many requests")
+	// ErrDryRun is returned instead of sending a request that would change the broker in dry run mode
+	ErrDryRun = errors.New("dry run, request not sent")
+	// ErrNotConfigured is returned when the broker host depends on values that are only known after apply
+	ErrNotConfigured = errors.New("the provider host is not known until apply")
 )
@@ -1 +1,103 @@
 package client
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/pactflow/terraform/broker"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestErrorMessages(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/hal+json")
+
+		switch r.URL.Path {
+		case "/webhooks":
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, `{"errors": {"request.url": ["is not a valid URL"], "events": ["can't be blank"]}}`)
+		case "/pacticipants":
+			w.Header().Set("Content-Type", "application/problem+json")
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, `{"title": "Validation errors", "type": "https://pact-broker/problems/validation-error", "status": 400, "errors": [
+				{"title": "Validation error", "detail": "must be filled", "pointer": "/name", "status": 400},
+				{"title": "Validation error", "detail": "is not a valid URL", "pointer": "/repositoryUrl", "status": 400},
+				{"title": "Validation error", "detail": "must be a JSON object", "status": 400}
+			]}`)
+		case "/pacticipants/Foo":
+			w.Header().Set("Content-Type", "application/problem+json")
+			w.WriteHeader(http.StatusForbidden)
+			fmt.Fprint(w, `{"title": "Forbidden", "detail": "You do not have permission to read pacticipants", "status": 403}`)
+		case "/webhooks/5678":
+			w.WriteHeader(http.StatusInternalServerError)
+			fmt.Fprint(w, `{"message": "could not decrypt webhook", "token": "abc123", "trace": "`+strings.Repeat("x", 500)+`"}`)
+		default:
+			w.WriteHeader(http.StatusBadGateway)
+			fmt.Fprint(w, "<html>\n  <body>"+strings.Repeat("upstream unavailable ", 20)+"</body>\n</html>")
+		}
+	}))
+	defer server.Close()
+
+	baseURL, _ := url.Parse(server.URL)
+	c := NewClient(nil, Config{BaseURL: baseURL})
+
+	t.Run("names the request and the attribute of each validation error", func(t *testing.T) {
+		_, err := c.CreateWebhook(broker.Webhook{})
+
+		assert.ErrorIs(t, err, ErrBadRequest)
+		assert.Contains(t, err.Error(), "bad request (POST /webhooks)")
+		assert.Contains(t, err.Error(), "events: can't be blank")
+		assert.Contains(t, err.Error(), "request.url: is not a valid URL")
+	})
+
+	t.Run("names the attribute of each problem in a problem details body", func(t *testing.T) {
+		_, err := c.CreatePacticipant(broker.Pacticipant{})
+
+		assert.ErrorIs(t, err, ErrBadRequest)
+		assert.Contains(t, err.Error(), "bad request (POST /pacticipants)")
+		assert.Contains(t, err.Error(), "summary: Validation errors")
+		assert.Contains(t, err.Error(), "name: must be filled")
+		assert.Contains(t, err.Error(), "repositoryUrl: is not a valid URL")
+		assert.Contains(t, err.Error(), "\t\tmust be a JSON object")
+	})
+
+	t.Run("includes the detail of a problem", func(t *testing.T) {
+		_, err := c.ReadPacticipant("Foo")
+
+		assert.ErrorIs(t, err, ErrForbidden)
+		assert.Contains(t, err.Error(), "summary: Forbidden: You do not have permission to read pacticipants")
+	})
+
+	t.Run("includes a redacted, trimmed excerpt of an unrecognised error body", func(t *testing.T) {
+		_, err := c.ReadWebhook("5678")
+
+		assert.ErrorIs(t, err, ErrSystemUnavailable)
+		assert.Contains(t, err.Error(), "system unavailable (GET /webhooks/5678)")
+		assert.Contains(t, err.Error(), `"message":"could not decrypt webhook"`)
+		assert.Contains(t, err.Error(), `"token":"*****"`)
+		assert.NotContains(t, err.Error(), "abc123")
+		assert.Contains(t, err.Error(), "...")
+	})
+
+	t.Run("omits a non-JSON error body, which can't be redacted", func(t *testing.T) {
+		_, err := c.ReadWebhook("1234")
+
+		assert.ErrorIs(t, err, ErrSystemUnavailable)
+		assert.Contains(t, err.Error(), "system unavailable (GET /webhooks/1234)")
+		assert.Contains(t, err.Error(), "response: <non-JSON body omitted>")
+		assert.NotContains(t, err.Error(), "upstream unavailable")
+	})
+}
+
+func TestUnknownHost(t *testing.T) {
+	c := NewClient(nil, Config{})
+
+	_, err := c.ReadWebhook("1234")
+
+	assert.ErrorIs(t, err, ErrNotConfigured)
+	assert.Contains(t, err.Error(), "GET /webhooks/1234")
+}
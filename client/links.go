@@ -0,0 +1,72 @@
+package client
+
+import (
+	"net/url"
+	"regexp"
+
+	"github.com/pactflow/terraform/broker"
+)
+
+// linkRelations are the relations in the broker's API index for paths the client can otherwise build
+// itself. Following them keeps the client working with brokers that serve an endpoint somewhere else.
+// Only the OSS broker's pb: relations are followed. Every path is built through path, but endpoints with
+// no relation here use the client's own templates: PactFlow only endpoints (e.g. teams, roles and tenant
+// settings), and broker endpoints the index has no templated link for (e.g. the matrix and pact versions)
+var linkRelations = map[string]string{
+	webhookCreateTemplate:               "pb:webhooks",
+	webhookReadUpdateDeleteTemplate:     "pb:webhook",
+	pacticipantCreateTemplate:           "pb:pacticipants",
+	pacticipantReadUpdateDeleteTemplate: "pb:pacticipant",
+	pacticipantLatestVersionTemplate:    "pb:latest-version",
+	tagLatestVersionTemplate:            "pb:latest-tagged-version",
+	pacticipantVersionTemplate:          "pb:pacticipant-version",
+	pacticipantVersionTagTemplate:       "pb:pacticipant-version-tag",
+	environmentCreateTemplate:           "pb:environments",
+	environmentReadUpdateDeleteTemplate: "pb:environment",
+	integrationsTemplate:                "pb:integrations",
+	pactsForVerificationTemplate:        "pb:provider-pacts-for-verification",
+}
+
+var linkPlaceholder = regexp.MustCompile(`\{[^}]*\}`)
+
+// UseIndex makes the client follow the links in the broker's API index rather than building paths
+// itself. Paths the index has no link for are still built from the client's own templates
+func (c *Client) UseIndex(index *broker.Index) {
+	c.index = index
+}
+
+// path returns the path for a template filled in with the parameters. It comes from the template's link
+// in the API index instead, when the client has one and it has a placeholder for each parameter
+func (c *Client) path(template string, parameters ...string) string {
+	if c.index != nil {
+		if link, ok := c.index.Link(linkRelations[template]); ok && link.Href != "" {
+			if path, ok := expandLink(link.Href, parameters); ok {
+				return path
+			}
+		}
+	}
+
+	return urlEncodeTemplate(template, parameters...)
+}
+
+// expandLink fills in the placeholders of a templated link in order, and returns its path. Only the path
+// is used, as with the links between pages, so that credentials are only sent to the configured broker
+func expandLink(href string, parameters []string) (string, bool) {
+	if len(linkPlaceholder.FindAllString(href, -1)) != len(parameters) {
+		return "", false
+	}
+
+	i := 0
+	expanded := linkPlaceholder.ReplaceAllStringFunc(href, func(string) string {
+		parameter := url.PathEscape(parameters[i])
+		i++
+		return parameter
+	})
+
+	u, err := url.Parse(expanded)
+	if err != nil {
+		return "", false
+	}
+
+	return u.RequestURI(), true
+}
@@ -0,0 +1,69 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+)
+
+// LogEntry writes a structured log entry: the level and message, followed by the fields as key=value
+// pairs. Values of sensitive fields (e.g. password or access_token) are masked, and anything other than
+// a string, number or bool is logged as JSON with its sensitive keys masked
+func LogEntry(level, msg string, fields ...interface{}) {
+	var b strings.Builder
+	fmt.Fprintf(&b, "[%s] %s", level, msg)
+
+	for i := 0; i < len(fields); i += 2 {
+		key := fmt.Sprint(fields[i])
+		var value interface{} = "<missing>"
+		if i+1 < len(fields) {
+			value = fields[i+1]
+		}
+		fmt.Fprintf(&b, " %s=%s", key, formatLogValue(key, value))
+	}
+
+	log.Println(b.String())
+}
+
+// formatLogValue masks scalar values of sensitive fields outright. Structs, maps and slices are logged
+// as JSON whatever the field is called, as Redact masks the sensitive keys inside them
+func formatLogValue(key string, value interface{}) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case string, error, fmt.Stringer, bool, int, int64, float64:
+		if isSensitiveKey(key) {
+			return `"*****"`
+		}
+	}
+
+	switch v := value.(type) {
+	case string:
+		return fmt.Sprintf("%q", v)
+	case error:
+		return fmt.Sprintf("%q", v.Error())
+	case bool, int, int64, float64:
+		return fmt.Sprint(v)
+	case fmt.Stringer:
+		return fmt.Sprintf("%q", v.String())
+	default:
+		return Redact(v)
+	}
+}
+
+// Redact returns v as JSON with the values of sensitive keys masked, so that it can be logged
+func Redact(v interface{}) string {
+	body, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprintf("%q", "<unable to log value: "+err.Error()+">")
+	}
+
+	return redactBody(body)
+}
+
+// isSensitiveKey reports whether a JSON key or log field holds a credential. Keys are compared ignoring
+// case and underscores, so that webhookUrl and webhook_url both match
+func isSensitiveKey(key string) bool {
+	return sensitiveBodyKeys[strings.ReplaceAll(strings.ToLower(key), "_", "")]
+}
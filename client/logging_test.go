@@ -0,0 +1,30 @@
+package client
+
+import (
+	"bytes"
+	"errors"
+	"log"
+	"os"
+	"testing"
+
+	"github.com/pactflow/terraform/broker"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLogEntry(t *testing.T) {
+	var out bytes.Buffer
+	log.SetOutput(&out)
+	defer log.SetOutput(os.Stderr)
+
+	LogEntry("DEBUG", "creating user", "resource", "pact_user", "retry", 2, "error", errors.New("boom"), "password", "hunter2", "user", broker.User{
+		Name:  "Test User",
+		Email: "test@example.com",
+	}, "secret", broker.Secret{Name: "db", Value: "s3cr3t"})
+
+	logged := out.String()
+	assert.Contains(t, logged, `[DEBUG] creating user resource="pact_user" retry=2 error="boom" password="*****" user={`)
+	assert.Contains(t, logged, `"email":"test@example.com"`)
+	assert.Contains(t, logged, `"name":"db"`)
+	assert.NotContains(t, logged, "hunter2")
+	assert.NotContains(t, logged, "s3cr3t")
+}
@@ -0,0 +1,135 @@
+package client
+
+import (
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	defaultRetryMinWait = time.Second
+	defaultRetryMaxWait = 30 * time.Second
+)
+
+// retryable reports whether a response means the broker (or a gateway in front of it) was briefly
+// unavailable, or is rate limiting requests. A 429 means the request wasn't handled, so it is always
+// retried. A 502 or 504 says nothing about whether the broker processed the request, so it is only retried
+// for idempotent methods, as retrying a POST could create a duplicate; the same goes for a 503, unless it
+// comes with a Retry-After header saying when to try again. Other errors, including a 500, are returned
+// straight away
+func retryable(req *http.Request, resp *http.Response) bool {
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests:
+		return true
+	case http.StatusServiceUnavailable:
+		if _, ok := retryAfter(resp); ok {
+			return true
+		}
+		return idempotent(req.Method)
+	case http.StatusBadGateway, http.StatusGatewayTimeout:
+		return idempotent(req.Method)
+	}
+	return false
+}
+
+// idempotent reports whether sending a request with the given method more than once has the same effect
+// as sending it once
+func idempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// retryAfter is how long a response's Retry-After header (in seconds, or an HTTP date) asks the client to
+// wait, if it has one
+func retryAfter(resp *http.Response) (time.Duration, bool) {
+	header := resp.Header.Get("Retry-After")
+	if header == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(header); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(header); err == nil {
+		if wait := time.Until(date); wait > 0 {
+			return wait, true
+		}
+		return 0, true
+	}
+
+	return 0, false
+}
+
+// retryWaits are the configured RetryMinWait and RetryMaxWait, or their defaults when unset
+func (c *Client) retryWaits() (min, max time.Duration) {
+	min, max = c.Config.RetryMinWait, c.Config.RetryMaxWait
+	if min <= 0 {
+		min = defaultRetryMinWait
+	}
+	if max <= 0 {
+		max = defaultRetryMaxWait
+	}
+
+	return min, max
+}
+
+// retryWait is the exponential backoff before the given retry (starting at 0), capped at RetryMaxWait
+func (c *Client) retryWait(retry int) time.Duration {
+	min, max := c.retryWaits()
+
+	wait := min
+	for i := 0; i < retry && wait < max; i++ {
+		wait *= 2
+	}
+	if wait > max {
+		wait = max
+	}
+
+	return wait
+}
+
+// send sends the request, retrying up to MaxRetries times while the broker is unavailable. Waiting
+// between attempts stops early if the request's context is done, e.g. the operation timed out
+func (c *Client) send(req *http.Request) (*http.Response, error) {
+	for retry := 0; ; retry++ {
+		resp, err := c.client.Do(req)
+		if err != nil || !retryable(req, resp) || retry >= c.Config.MaxRetries {
+			return resp, err
+		}
+
+		wait := c.retryWait(retry)
+		if after, ok := retryAfter(resp); ok {
+			// Don't let the broker (or a misconfigured proxy) stall the apply for longer than backing off would
+			if _, max := c.retryWaits(); after > max {
+				after = max
+			}
+			wait = after
+		}
+		LogEntry("WARN", "retrying request", "method", req.Method, "path", req.URL.Path, "status", resp.StatusCode, "wait", wait, "retry", retry+1, "max_retries", c.Config.MaxRetries)
+
+		io.Copy(ioutil.Discard, resp.Body)
+		resp.Body.Close()
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-timer.C:
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		}
+
+		// The body was consumed by the previous attempt
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req.Body = body
+		}
+	}
+}
@@ -0,0 +1,185 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/pactflow/terraform/broker"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRetries(t *testing.T) {
+	var attempts int
+	var bodies []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		body, _ := ioutil.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+
+		if attempts < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "application/hal+json")
+		fmt.Fprint(w, `{"name": "Foo"}`)
+	}))
+	defer server.Close()
+
+	baseURL, _ := url.Parse(server.URL)
+
+	t.Run("retries until the broker is available, resending the body", func(t *testing.T) {
+		attempts, bodies = 0, nil
+		c := NewClient(nil, Config{BaseURL: baseURL, MaxRetries: 3, RetryMinWait: time.Millisecond})
+
+		_, err := c.UpdateTeam(broker.TeamCreateOrUpdateRequest{UUID: "1234", Name: "Foo"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 3, attempts)
+		assert.Equal(t, bodies[0], bodies[2])
+		assert.Contains(t, bodies[2], `"name":"Foo"`)
+	})
+
+	t.Run("does not retry a POST, which could create a duplicate", func(t *testing.T) {
+		attempts = 0
+		c := NewClient(nil, Config{BaseURL: baseURL, MaxRetries: 3, RetryMinWait: time.Millisecond})
+
+		_, err := c.CreatePacticipant(broker.Pacticipant{Name: "Foo"})
+
+		assert.ErrorIs(t, err, ErrSystemUnavailable)
+		assert.Equal(t, 1, attempts)
+	})
+
+	t.Run("gives up after the maximum number of retries", func(t *testing.T) {
+		attempts = 0
+		c := NewClient(nil, Config{BaseURL: baseURL, MaxRetries: 1, RetryMinWait: time.Millisecond})
+
+		_, err := c.ReadPacticipant("Foo")
+
+		assert.ErrorIs(t, err, ErrSystemUnavailable)
+		assert.Equal(t, 2, attempts)
+	})
+
+	t.Run("stops waiting when the deadline passes", func(t *testing.T) {
+		attempts = 0
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+		c := NewClient(nil, Config{BaseURL: baseURL, MaxRetries: 3, RetryMinWait: time.Minute}).WithContext(ctx)
+
+		_, err := c.ReadPacticipant("Foo")
+
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.Equal(t, 1, attempts)
+	})
+}
+
+func TestRetryable(t *testing.T) {
+	for _, tc := range []struct {
+		method     string
+		status     int
+		retryAfter string
+		expected   bool
+	}{
+		{http.MethodGet, http.StatusBadGateway, "", true},
+		{http.MethodPut, http.StatusGatewayTimeout, "", true},
+		{http.MethodDelete, http.StatusServiceUnavailable, "", true},
+		{http.MethodPost, http.StatusBadGateway, "", false},
+		{http.MethodPost, http.StatusGatewayTimeout, "", false},
+		{http.MethodPost, http.StatusServiceUnavailable, "", false},
+		{http.MethodPatch, http.StatusServiceUnavailable, "", false},
+		{http.MethodPost, http.StatusServiceUnavailable, "5", true},
+		{http.MethodPost, http.StatusTooManyRequests, "", true},
+		{http.MethodGet, http.StatusInternalServerError, "", false},
+	} {
+		req := &http.Request{Method: tc.method}
+		resp := &http.Response{StatusCode: tc.status, Header: http.Header{}}
+		if tc.retryAfter != "" {
+			resp.Header.Set("Retry-After", tc.retryAfter)
+		}
+
+		assert.Equal(t, tc.expected, retryable(req, resp), "%s with a %d", tc.method, tc.status)
+	}
+}
+
+func TestRetryWait(t *testing.T) {
+	c := NewClient(nil, Config{RetryMinWait: time.Second, RetryMaxWait: 5 * time.Second})
+
+	assert.Equal(t, time.Second, c.retryWait(0))
+	assert.Equal(t, 4*time.Second, c.retryWait(2))
+	assert.Equal(t, 5*time.Second, c.retryWait(10))
+}
+
+func TestRateLimiting(t *testing.T) {
+	var attempts int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts == 1 || r.URL.Path == "/pacticipants/Limited" {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Header().Set("Content-Type", "application/hal+json")
+		fmt.Fprint(w, `{"name": "Foo"}`)
+	}))
+	defer server.Close()
+
+	baseURL, _ := url.Parse(server.URL)
+	c := NewClient(nil, Config{BaseURL: baseURL, MaxRetries: 2, RetryMinWait: time.Minute})
+
+	_, err := c.ReadPacticipant("Foo")
+	assert.NoError(t, err, "expected the Retry-After header to be used instead of the minimum wait")
+	assert.Equal(t, 2, attempts)
+
+	_, err = c.ReadPacticipant("Limited")
+	assert.ErrorIs(t, err, ErrRateLimited)
+}
+
+func TestRetryAfterIsCapped(t *testing.T) {
+	var attempts int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts == 1 {
+			w.Header().Set("Retry-After", "3600")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Header().Set("Content-Type", "application/hal+json")
+		fmt.Fprint(w, `{"name": "Foo"}`)
+	}))
+	defer server.Close()
+
+	baseURL, _ := url.Parse(server.URL)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	c := NewClient(nil, Config{BaseURL: baseURL, MaxRetries: 1, RetryMinWait: time.Millisecond, RetryMaxWait: 10 * time.Millisecond}).WithContext(ctx)
+
+	_, err := c.ReadPacticipant("Foo")
+
+	assert.NoError(t, err, "expected the Retry-After header to be capped at the maximum wait")
+	assert.Equal(t, 2, attempts)
+}
+
+func TestRetryAfter(t *testing.T) {
+	for header, expected := range map[string]time.Duration{
+		"120": 2 * time.Minute,
+		time.Now().Add(-time.Minute).UTC().Format(http.TimeFormat): 0,
+	} {
+		wait, ok := retryAfter(&http.Response{Header: http.Header{"Retry-After": {header}}})
+		assert.True(t, ok)
+		assert.Equal(t, expected, wait)
+	}
+
+	wait, ok := retryAfter(&http.Response{Header: http.Header{"Retry-After": {time.Now().Add(time.Hour).UTC().Format(http.TimeFormat)}}})
+	assert.True(t, ok)
+	assert.InDelta(t, float64(time.Hour), float64(wait), float64(2*time.Second))
+
+	for _, header := range []string{"", "soon"} {
+		_, ok := retryAfter(&http.Response{Header: http.Header{"Retry-After": {header}}})
+		assert.False(t, ok)
+	}
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/pactflow/terraform/client"
+)
+
+var apiTokenType = &schema.Resource{
+	Schema: map[string]*schema.Schema{
+		"uuid": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"type": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"description": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"created_at": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"last_used_at": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"expires_at": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+	},
+}
+
+func apiTokensDataSource() *schema.Resource {
+	return &schema.Resource{
+		Read: apiTokensDataSourceRead,
+		Schema: map[string]*schema.Schema{
+			"tokens": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The API tokens of the authenticated user or system account. Token values are never returned",
+				Elem:        apiTokenType,
+			},
+		},
+	}
+}
+
+func apiTokensDataSourceRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*client.Client)
+
+	logDebug("pact_api_tokens", d, "listing api tokens")
+
+	res, err := client.ReadTokens()
+
+	if err != nil {
+		return fmt.Errorf("error listing api tokens: %w", err)
+	}
+
+	tokens := make([]interface{}, len(res.Embedded.Items))
+	for i, t := range res.Embedded.Items {
+		tokenType := ""
+		for k, v := range allowedTokenTypes {
+			if v == t.Description {
+				tokenType = k
+			}
+		}
+
+		tokens[i] = map[string]interface{}{
+			"uuid":         t.UUID,
+			"type":         tokenType,
+			"description":  t.Description,
+			"created_at":   t.CreatedAt,
+			"last_used_at": t.LastUsedAt,
+			"expires_at":   t.ExpiresAt,
+		}
+	}
+
+	d.SetId(client.Config.BaseURL.Host)
+
+	if err := d.Set("tokens", tokens); err != nil {
+		return fmt.Errorf("error setting key 'tokens': %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,133 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/pactflow/terraform/broker"
+	"github.com/pactflow/terraform/client"
+)
+
+var auditEventType = &schema.Resource{
+	Schema: map[string]*schema.Schema{
+		"uuid": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"action": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"description": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"actor_uuid": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"actor_name": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"actor_email": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"created_at": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+	},
+}
+
+func auditEventsDataSource() *schema.Resource {
+	return &schema.Resource{
+		Read: auditEventsDataSourceRead,
+		Schema: map[string]*schema.Schema{
+			"actor": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only include events performed by this user (uuid, name or email)",
+			},
+			"action": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only include events of this type (e.g. user.invited)",
+			},
+			"from": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.ValidateRFC3339TimeString,
+				Description:  "Only include events recorded at or after this time (RFC3339)",
+			},
+			"to": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.ValidateRFC3339TimeString,
+				Description:  "Only include events recorded before this time (RFC3339)",
+			},
+			"events": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The matching audit events",
+				Elem:        auditEventType,
+			},
+		},
+	}
+}
+
+func auditEventsDataSourceRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*client.Client)
+	actor := d.Get("actor").(string)
+	action := d.Get("action").(string)
+
+	logDebug("pact_audit_events", d, "listing audit events")
+
+	res, err := client.ListAuditEvents(d.Get("from").(string), d.Get("to").(string))
+
+	if err != nil {
+		return fmt.Errorf("error listing audit events: %w", err)
+	}
+
+	events := make([]interface{}, 0)
+	for _, e := range res.Embedded.Events {
+		if action != "" && e.Type != action {
+			continue
+		}
+		if actor != "" && !auditActorMatches(e.Actor, actor) {
+			continue
+		}
+
+		event := map[string]interface{}{
+			"uuid":        e.UUID,
+			"action":      e.Type,
+			"description": e.Description,
+			"created_at":  e.CreatedAt,
+		}
+		if e.Actor != nil {
+			event["actor_uuid"] = e.Actor.UUID
+			event["actor_name"] = e.Actor.Name
+			event["actor_email"] = e.Actor.Email
+		}
+		events = append(events, event)
+	}
+
+	d.SetId(client.Config.BaseURL.Host)
+
+	if err := d.Set("events", events); err != nil {
+		return fmt.Errorf("error setting key 'events': %w", err)
+	}
+
+	return nil
+}
+
+func auditActorMatches(a *broker.AuditActor, actor string) bool {
+	if a == nil {
+		return false
+	}
+
+	return a.UUID == actor || strings.EqualFold(a.Email, actor) || a.Name == actor
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/pactflow/terraform/client"
+)
+
+func authenticationSettingsDataSource() *schema.Resource {
+	return &schema.Resource{
+		Read: authenticationSettingsDataSourceRead,
+		Schema: map[string]*schema.Schema{
+			"github_organizations": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The Github organisations allowed access to the account",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"google_domains": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The Google organisation domains allowed access to the account",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"github_enabled": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether any Github organisations may log in",
+			},
+			"google_enabled": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether any Google domains may log in",
+			},
+		},
+	}
+}
+
+func authenticationSettingsDataSourceRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*client.Client)
+
+	logDebug("pact_authentication_settings", d, "reading authentication settings")
+
+	settings, err := client.ReadTenantAuthenticationSettings()
+
+	if err != nil {
+		return fmt.Errorf("error reading authentication settings: %w", err)
+	}
+
+	orgs := settings.Providers.Github.Organizations
+	if orgs == nil {
+		orgs = []string{}
+	}
+	domains := settings.Providers.Google.EmailDomains
+	if domains == nil {
+		domains = []string{}
+	}
+
+	d.SetId(client.Config.BaseURL.Host)
+	d.Set("github_enabled", len(orgs) > 0)
+	d.Set("google_enabled", len(domains) > 0)
+
+	if err := d.Set("github_organizations", orgs); err != nil {
+		return fmt.Errorf("error setting key 'github_organizations': %w", err)
+	}
+	if err := d.Set("google_domains", domains); err != nil {
+		return fmt.Errorf("error setting key 'google_domains': %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,118 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/pactflow/terraform/client"
+)
+
+const (
+	pactBadgeType   = "pact"
+	matrixBadgeType = "matrix"
+)
+
+func badgeURLDataSource() *schema.Resource {
+	return &schema.Resource{
+		Read: badgeURLDataSourceRead,
+		Schema: map[string]*schema.Schema{
+			"consumer_name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The name of the consumer",
+			},
+			"provider_name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The name of the provider",
+			},
+			"type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      pactBadgeType,
+				ValidateFunc: validation.StringInSlice([]string{pactBadgeType, matrixBadgeType}, false),
+				Description:  "The type of badge: pact (the latest pact's verification status) or matrix (the status between tagged versions)",
+			},
+			"consumer_tag": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Use the latest consumer version with this tag. Required for matrix badges",
+			},
+			"provider_tag": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Use the latest provider version with this tag. Required for matrix badges",
+			},
+			"url": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The badge URL",
+			},
+			"markdown": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "A markdown image for the badge, for use in README files",
+			},
+			"publicly_accessible": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the badge can be viewed without authentication",
+			},
+		},
+	}
+}
+
+func badgeURLDataSourceRead(d *schema.ResourceData, meta interface{}) error {
+	httpClient := meta.(*client.Client)
+	consumer := d.Get("consumer_name").(string)
+	provider := d.Get("provider_name").(string)
+	consumerTag := d.Get("consumer_tag").(string)
+	providerTag := d.Get("provider_tag").(string)
+
+	var path string
+
+	switch d.Get("type").(string) {
+	case matrixBadgeType:
+		if consumerTag == "" || providerTag == "" {
+			return fmt.Errorf("'consumer_tag' and 'provider_tag' are required for matrix badges")
+		}
+		path = fmt.Sprintf("/matrix/provider/%s/latest/%s/consumer/%s/latest/%s/badge",
+			url.PathEscape(provider), url.PathEscape(providerTag), url.PathEscape(consumer), url.PathEscape(consumerTag))
+	default:
+		path = fmt.Sprintf("/pacts/provider/%s/consumer/%s/latest", url.PathEscape(provider), url.PathEscape(consumer))
+		if consumerTag != "" {
+			path += "/" + url.PathEscape(consumerTag)
+		}
+		path += "/badge"
+	}
+
+	if httpClient.Config.BaseURL == nil {
+		return fmt.Errorf("error generating badge url: %w", client.ErrNotConfigured)
+	}
+
+	// Append rather than resolve the path, so brokers hosted under a base path keep it
+	badgeURL := strings.TrimSuffix(httpClient.Config.BaseURL.String(), "/") + path
+
+	logDebug("pact_badge_url", d, "generated badge url", "url", badgeURL)
+
+	// Badges on the OSS broker are always public, and it has no badge settings endpoint
+	public := true
+	settings, err := httpClient.ReadBadgeSettings()
+
+	if err == nil {
+		public = settings.PublicReadAccess
+	} else if !errors.Is(err, client.ErrNotFound) {
+		return fmt.Errorf("error reading badge settings: %w", err)
+	}
+
+	d.SetId(badgeURL)
+	d.Set("url", badgeURL)
+	d.Set("markdown", fmt.Sprintf("![%s/%s Pact Status](%s)", consumer, provider, badgeURL))
+	d.Set("publicly_accessible", public)
+
+	return nil
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/pactflow/terraform/client"
+)
+
+var branchType = &schema.Resource{
+	Schema: map[string]*schema.Schema{
+		"name": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"latest_version": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"created_at": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"updated_at": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+	},
+}
+
+func branchesDataSource() *schema.Resource {
+	return &schema.Resource{
+		Read: branchesDataSourceRead,
+		Schema: map[string]*schema.Schema{
+			"pacticipant": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The name of the pacticipant",
+			},
+			"names": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The names of the branches",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"branches": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The branches of the pacticipant",
+				Elem:        branchType,
+			},
+		},
+	}
+}
+
+func branchesDataSourceRead(d *schema.ResourceData, meta interface{}) error {
+	httpClient := meta.(*client.Client)
+	pacticipant := d.Get("pacticipant").(string)
+
+	logDebug("pact_branches", d, "listing branches for pacticipant", "pacticipant", pacticipant)
+
+	res, err := httpClient.ListPacticipantBranches(pacticipant)
+
+	if err != nil {
+		return fmt.Errorf("error listing branches of pacticipant %q: %w", pacticipant, err)
+	}
+
+	names := make([]string, 0)
+	branches := make([]interface{}, 0)
+
+	for _, b := range res.Embedded.Branches {
+		latestVersion := ""
+		version, err := httpClient.ReadLatestPacticipantVersionForBranch(pacticipant, b.Name)
+
+		if err == nil {
+			latestVersion = version.Number
+		} else if !errors.Is(err, client.ErrNotFound) {
+			return fmt.Errorf("error reading latest version of pacticipant %q on branch %q: %w", pacticipant, b.Name, err)
+		}
+
+		names = append(names, b.Name)
+		branches = append(branches, map[string]interface{}{
+			"name":           b.Name,
+			"latest_version": latestVersion,
+			"created_at":     b.CreatedAt,
+			"updated_at":     b.UpdatedAt,
+		})
+	}
+
+	d.SetId(pacticipant)
+
+	if err := d.Set("names", names); err != nil {
+		return fmt.Errorf("error setting key 'names': %w", err)
+	}
+	if err := d.Set("branches", branches); err != nil {
+		return fmt.Errorf("error setting key 'branches': %w", err)
+	}
+
+	return nil
+}
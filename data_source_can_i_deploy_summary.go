@@ -0,0 +1,171 @@
+package main
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/pactflow/terraform/broker"
+	"github.com/pactflow/terraform/client"
+)
+
+func canIDeploySummaryDataSource() *schema.Resource {
+	return &schema.Resource{
+		Read: canIDeploySummaryDataSourceRead,
+		Schema: map[string]*schema.Schema{
+			"pacticipant": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The name of the pacticipant to check",
+			},
+			"environment": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The environment to check the pacticipant can be deployed to",
+			},
+			"branch": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "Check the latest version of this branch. Defaults to the pacticipant's main branch",
+			},
+			"output_file": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "A file to write the full results to, for later pipeline steps to consume. It is written whenever the data source is read, including during terraform plan and refresh",
+			},
+			"format": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      jsonMatrixOutputFormat,
+				ValidateFunc: validation.StringInSlice(allowedMatrixOutputFormats, false),
+				Description:  "The format to write output_file in: json or junit",
+			},
+			"version": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The pacticipant version that was checked",
+			},
+			"deployable": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the version can be deployed to the environment",
+			},
+			"reason": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The reason for the deployable result",
+			},
+			"badge_url": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The URL of the can-i-deploy badge for the branch and environment",
+			},
+			"markdown": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "A markdown summary of the result, including the badge and each integration",
+			},
+		},
+	}
+}
+
+func canIDeploySummaryDataSourceRead(d *schema.ResourceData, meta interface{}) error {
+	httpClient := meta.(*client.Client)
+	pacticipant := d.Get("pacticipant").(string)
+	environment := d.Get("environment").(string)
+	branch := d.Get("branch").(string)
+
+	if branch == "" {
+		p, err := httpClient.ReadPacticipant(pacticipant)
+		if err != nil {
+			return fmt.Errorf("error reading the main branch of pacticipant %q: %w", pacticipant, err)
+		}
+		if p.MainBranch == "" {
+			return fmt.Errorf("pacticipant %q has no main branch, set 'branch' to choose the version to check", pacticipant)
+		}
+		branch = p.MainBranch
+	}
+
+	logDebug("pact_can_i_deploy_summary", d, "checking can i deploy", "pacticipant", pacticipant, "branch", branch, "environment", environment)
+
+	res, err := httpClient.QueryMatrix(broker.MatrixQuery{
+		Selectors: []broker.MatrixSelector{
+			{Pacticipant: pacticipant, Branch: branch, Latest: true},
+		},
+		Environment: environment,
+		LatestBy:    "cvp",
+	})
+	if err != nil {
+		return fmt.Errorf("error checking if %s (%s) can be deployed to %s: %w", pacticipant, branch, environment, err)
+	}
+
+	// Append rather than resolve the path, so brokers hosted under a base path keep it
+	badgeURL := strings.TrimSuffix(httpClient.Config.BaseURL.String(), "/") + fmt.Sprintf("/pacticipants/%s/branches/%s/latest-version/can-i-deploy/to-environment/%s/badge",
+		url.PathEscape(pacticipant), url.PathEscape(branch), url.PathEscape(environment))
+
+	d.SetId(fmt.Sprintf("%s/%s/%s", pacticipant, branch, environment))
+	d.Set("branch", branch)
+	d.Set("version", matrixPacticipantVersion(pacticipant, res))
+	d.Set("deployable", res.Summary.Deployable != nil && *res.Summary.Deployable)
+	d.Set("reason", res.Summary.Reason)
+	d.Set("badge_url", badgeURL)
+	d.Set("markdown", canIDeploySummaryMarkdown(pacticipant, branch, environment, badgeURL, res))
+
+	if path := d.Get("output_file").(string); path != "" {
+		if err := writeMatrixOutput(path, d.Get("format").(string), res); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// matrixPacticipantVersion finds the version of the pacticipant in the matrix rows, whichever side it is on
+func matrixPacticipantVersion(pacticipant string, res *broker.MatrixResponse) string {
+	for _, r := range res.Matrix {
+		if r.Consumer.Name == pacticipant {
+			return r.Consumer.Version.Number
+		}
+		if r.Provider.Name == pacticipant {
+			return r.Provider.Version.Number
+		}
+	}
+
+	return ""
+}
+
+func canIDeploySummaryMarkdown(pacticipant, branch, environment, badgeURL string, res *broker.MatrixResponse) string {
+	var b strings.Builder
+
+	answer := "No"
+	if res.Summary.Deployable != nil && *res.Summary.Deployable {
+		answer = "Yes"
+	}
+
+	fmt.Fprintf(&b, "### Can I deploy %s (%s) to %s?\n\n", pacticipant, branch, environment)
+	fmt.Fprintf(&b, "![can-i-deploy %s to %s](%s)\n\n", pacticipant, environment, badgeURL)
+	fmt.Fprintf(&b, "**%s**: %s\n", answer, res.Summary.Reason)
+
+	if len(res.Matrix) == 0 {
+		return b.String()
+	}
+
+	b.WriteString("\n| Consumer | Consumer version | Provider | Provider version | Verification |\n")
+	b.WriteString("| --- | --- | --- | --- | --- |\n")
+
+	for _, r := range res.Matrix {
+		status := "Not verified"
+		if r.VerificationResult != nil && r.VerificationResult.Success {
+			status = "Passed"
+		} else if r.VerificationResult != nil {
+			status = "Failed"
+		}
+
+		fmt.Fprintf(&b, "| %s | %s | %s | %s | %s |\n", r.Consumer.Name, r.Consumer.Version.Number, r.Provider.Name, r.Provider.Version.Number, status)
+	}
+
+	return b.String()
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/pactflow/terraform/broker"
+)
+
+func TestCanIDeploySummaryMarkdown(t *testing.T) {
+	deployable := false
+	res := &broker.MatrixResponse{
+		Summary: broker.MatrixSummary{Deployable: &deployable, Reason: "One or more verifications have failed"},
+		Matrix: []broker.MatrixRow{
+			{
+				Consumer:           broker.MatrixPacticipant{Name: "orders-web", Version: broker.MatrixVersion{Number: "1.2.3"}},
+				Provider:           broker.MatrixPacticipant{Name: "orders-api", Version: broker.MatrixVersion{Number: "4.5.6"}},
+				VerificationResult: &broker.MatrixVerificationResult{Success: false},
+			},
+		},
+	}
+
+	markdown := canIDeploySummaryMarkdown("orders-web", "main", "production", "https://broker/badge", res)
+
+	for _, expected := range []string{
+		"### Can I deploy orders-web (main) to production?",
+		"![can-i-deploy orders-web to production](https://broker/badge)",
+		"**No**: One or more verifications have failed",
+		"| orders-web | 1.2.3 | orders-api | 4.5.6 | Failed |",
+	} {
+		if !strings.Contains(markdown, expected) {
+			t.Fatalf("expected the markdown to contain %q, got\n%s", expected, markdown)
+		}
+	}
+
+	if version := matrixPacticipantVersion("orders-api", res); version != "4.5.6" {
+		t.Fatalf("expected the provider version to be found, got %q", version)
+	}
+}
+
+func TestCanIDeploySummaryDataSource_OutputFile(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/matrix" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/hal+json")
+		fmt.Fprint(w, `{"summary": {"deployable": false, "reason": "One or more verifications have failed"}, "matrix": [{"consumer": {"name": "orders-web", "version": {"number": "1.2.3"}}, "provider": {"name": "orders-api", "version": {"number": "4.5.6"}}, "verificationResult": {"success": false}}]}`)
+	}))
+	defer server.Close()
+
+	meta, err := configureProvider(schema.TestResourceDataRaw(t, Provider().Schema, map[string]interface{}{
+		"host":                        server.URL,
+		"skip_credentials_validation": true,
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	path := filepath.Join(t.TempDir(), "results", "can-i-deploy.xml")
+	d := schema.TestResourceDataRaw(t, canIDeploySummaryDataSource().Schema, map[string]interface{}{
+		"pacticipant": "orders-web",
+		"environment": "production",
+		"branch":      "main",
+		"output_file": path,
+		"format":      junitMatrixOutputFormat,
+	})
+
+	if err := canIDeploySummaryDataSourceRead(d, meta); err != nil {
+		t.Fatal(err)
+	}
+
+	out, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatalf("expected the results to be written to the output file: %s", err)
+	}
+	for _, expected := range []string{`name="orders-web (1.2.3) and orders-api (4.5.6)"`, `message="One or more verifications have failed"`} {
+		if !strings.Contains(string(out), expected) {
+			t.Fatalf("expected the output file to contain %s, got:\n%s", expected, out)
+		}
+	}
+}
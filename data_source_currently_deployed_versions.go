@@ -0,0 +1,105 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/pactflow/terraform/broker"
+	"github.com/pactflow/terraform/client"
+)
+
+var deployedVersionType = &schema.Resource{
+	Schema: map[string]*schema.Schema{
+		"uuid": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"pacticipant": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"version": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"branch": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"application_instance": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"created_at": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+	},
+}
+
+func currentlyDeployedVersionsDataSource() *schema.Resource {
+	return &schema.Resource{
+		Read: currentlyDeployedVersionsDataSourceRead,
+		Schema: map[string]*schema.Schema{
+			"environment": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validateUUID,
+				Description:  "The environment (uuid) to list the deployed versions for",
+			},
+			"pacticipant": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return versions of this pacticipant",
+			},
+			"versions": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The currently deployed versions",
+				Elem:        deployedVersionType,
+			},
+		},
+	}
+}
+
+func currentlyDeployedVersionsDataSourceRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*client.Client)
+	environment := d.Get("environment").(string)
+
+	logDebug("pact_currently_deployed_versions", d, "listing currently deployed versions for environment", "environment", environment)
+
+	res, err := client.ListCurrentlyDeployedVersions(environment)
+
+	if err != nil {
+		return fmt.Errorf("error listing currently deployed versions: %w", err)
+	}
+
+	d.SetId(environment)
+
+	if err := d.Set("versions", flattenDeployedVersions(res.Embedded.DeployedVersions, d.Get("pacticipant").(string))); err != nil {
+		return fmt.Errorf("error setting key 'versions': %w", err)
+	}
+
+	return nil
+}
+
+func flattenDeployedVersions(deployed []broker.DeployedVersion, pacticipant string) []interface{} {
+	versions := make([]interface{}, 0)
+
+	for _, v := range deployed {
+		if pacticipant != "" && v.Embedded.Pacticipant.Name != pacticipant {
+			continue
+		}
+
+		versions = append(versions, map[string]interface{}{
+			"uuid":                 v.UUID,
+			"pacticipant":          v.Embedded.Pacticipant.Name,
+			"version":              v.Embedded.Version.Number,
+			"branch":               v.Embedded.Version.Branch,
+			"application_instance": v.ApplicationInstance,
+			"created_at":           v.CreatedAt,
+		})
+	}
+
+	return versions
+}
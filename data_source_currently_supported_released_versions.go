@@ -0,0 +1,54 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/pactflow/terraform/client"
+)
+
+func currentlySupportedReleasedVersionsDataSource() *schema.Resource {
+	return &schema.Resource{
+		Read: currentlySupportedReleasedVersionsDataSourceRead,
+		Schema: map[string]*schema.Schema{
+			"environment": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validateUUID,
+				Description:  "The environment (uuid) to list the released versions for",
+			},
+			"pacticipant": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return versions of this pacticipant",
+			},
+			"versions": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The currently supported released versions",
+				Elem:        deployedVersionType,
+			},
+		},
+	}
+}
+
+func currentlySupportedReleasedVersionsDataSourceRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*client.Client)
+	environment := d.Get("environment").(string)
+
+	logDebug("pact_currently_supported_released_versions", d, "listing currently supported released versions for environment", "environment", environment)
+
+	res, err := client.ListCurrentlySupportedReleasedVersions(environment)
+
+	if err != nil {
+		return fmt.Errorf("error listing currently supported released versions: %w", err)
+	}
+
+	d.SetId(environment)
+
+	if err := d.Set("versions", flattenDeployedVersions(res.Embedded.ReleasedVersions, d.Get("pacticipant").(string))); err != nil {
+		return fmt.Errorf("error setting key 'versions': %w", err)
+	}
+
+	return nil
+}
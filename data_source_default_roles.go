@@ -0,0 +1,80 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/pactflow/terraform/client"
+)
+
+// The predefined roles every Pactflow account is created with, keyed by attribute name
+var defaultRoleNames = map[string]string{
+	"administrator": "Administrator",
+	"ci_cd":         "CI/CD",
+	"user":          "User",
+	"viewer":        "Viewer",
+}
+
+func defaultRolesDataSource() *schema.Resource {
+	s := map[string]*schema.Schema{
+		"uuids": {
+			Type:        schema.TypeMap,
+			Computed:    true,
+			Description: "The UUIDs of the predefined roles, keyed by role name",
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+	}
+
+	for key, name := range defaultRoleNames {
+		s[key] = &schema.Schema{
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: fmt.Sprintf("The UUID of the %s role", name),
+		}
+	}
+
+	return &schema.Resource{
+		Read:   defaultRolesDataSourceRead,
+		Schema: s,
+	}
+}
+
+func defaultRolesDataSourceRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*client.Client)
+
+	logDebug("pact_default_roles", d, "listing roles to find the predefined roles")
+
+	res, err := client.ListRoles()
+
+	if err != nil {
+		return fmt.Errorf("error listing roles: %w", err)
+	}
+
+	uuidsByName := make(map[string]string)
+	for _, r := range res.Roles {
+		uuidsByName[r.Name] = r.UUID
+	}
+
+	uuids := make(map[string]interface{})
+	for key, name := range defaultRoleNames {
+		uuid, ok := uuidsByName[name]
+		if !ok {
+			// Predefined roles can be deleted, so leave it empty rather than failing the plan
+			logWarn("pact_default_roles", d, "predefined role not found", "name", name)
+		} else {
+			uuids[name] = uuid
+		}
+
+		d.Set(key, uuid)
+	}
+
+	d.SetId(client.Config.BaseURL.Host)
+
+	if err := d.Set("uuids", uuids); err != nil {
+		return fmt.Errorf("error setting key 'uuids': %w", err)
+	}
+
+	return nil
+}
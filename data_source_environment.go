@@ -0,0 +1,62 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/pactflow/terraform/client"
+)
+
+func environmentDataSource() *schema.Resource {
+	return &schema.Resource{
+		Read: environmentDataSourceRead,
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Name of the Environment",
+			},
+			"uuid": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The UUID of environment",
+			},
+			"display_name": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Display name of the Environment",
+			},
+			"production": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Is this environment a production environment?",
+			},
+		},
+	}
+}
+
+func environmentDataSourceRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*client.Client)
+	name := d.Get("name").(string)
+
+	logDebug("pact_environment", d, "reading environment data source", "name", name)
+
+	res, err := client.ListEnvironments(name)
+
+	if err != nil {
+		return fmt.Errorf("error reading environment %q: %w", name, err)
+	}
+
+	for _, environment := range res.Embedded.Environments {
+		if environment.Name == name {
+			d.SetId(environment.UUID)
+			d.Set("uuid", environment.UUID)
+			d.Set("display_name", environment.DisplayName)
+			d.Set("production", environment.Production)
+
+			return nil
+		}
+	}
+
+	return fmt.Errorf("environment %q not found", name)
+}
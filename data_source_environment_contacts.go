@@ -0,0 +1,134 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/pactflow/terraform/broker"
+	"github.com/pactflow/terraform/client"
+)
+
+var environmentContactType = &schema.Resource{
+	Schema: map[string]*schema.Schema{
+		"name": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"details": {
+			Type:     schema.TypeMap,
+			Computed: true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+	},
+}
+
+func environmentContactsDataSource() *schema.Resource {
+	return &schema.Resource{
+		Read: environmentContactsDataSourceRead,
+		Schema: map[string]*schema.Schema{
+			"environment": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Name of the Environment",
+			},
+			"contacts": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The contacts for the environment",
+				Elem:        environmentContactType,
+			},
+			"email_addresses": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The email addresses of the contacts that have one",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+}
+
+func environmentContactsDataSourceRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*client.Client)
+	name := d.Get("environment").(string)
+
+	logDebug("pact_environment_contacts", d, "reading contacts for environment", "name", name)
+
+	res, err := client.ListEnvironments(name)
+
+	if err != nil {
+		return fmt.Errorf("error reading environment %q: %w", name, err)
+	}
+
+	uuid := ""
+	for _, environment := range res.Embedded.Environments {
+		if environment.Name == name {
+			uuid = environment.UUID
+		}
+	}
+
+	if uuid == "" {
+		return fmt.Errorf("environment %q not found", name)
+	}
+
+	// The contacts are only guaranteed to be included on the environment resource itself
+	environment, err := client.ReadEnvironment(uuid)
+
+	if err != nil {
+		return fmt.Errorf("error reading environment %q: %w", name, err)
+	}
+
+	contacts, emails, err := flattenEnvironmentContacts(environment.Contacts)
+
+	if err != nil {
+		return err
+	}
+
+	d.SetId(uuid)
+
+	if err := d.Set("contacts", contacts); err != nil {
+		return fmt.Errorf("error setting key 'contacts': %w", err)
+	}
+	if err := d.Set("email_addresses", emails); err != nil {
+		return fmt.Errorf("error setting key 'email_addresses': %w", err)
+	}
+
+	return nil
+}
+
+// Contact details are free form, so any value that isn't a string is passed through as JSON
+func flattenEnvironmentContacts(list []broker.EnvironmentContact) ([]interface{}, []string, error) {
+	contacts := make([]interface{}, 0)
+	emails := make([]string, 0)
+
+	for _, c := range list {
+		details := make(map[string]interface{})
+		for k, v := range c.Details {
+			if s, ok := v.(string); ok {
+				details[k] = s
+				continue
+			}
+
+			b, err := json.Marshal(v)
+			if err != nil {
+				return nil, nil, fmt.Errorf("error encoding contact detail %q: %w", k, err)
+			}
+			details[k] = string(b)
+		}
+
+		if email, ok := c.Details["emailAddress"].(string); ok && email != "" {
+			emails = append(emails, email)
+		}
+
+		contacts = append(contacts, map[string]interface{}{
+			"name":    c.Name,
+			"details": details,
+		})
+	}
+
+	return contacts, emails, nil
+}
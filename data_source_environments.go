@@ -0,0 +1,99 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/pactflow/terraform/client"
+)
+
+var environmentsItemType = &schema.Resource{
+	Schema: map[string]*schema.Schema{
+		"uuid": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"name": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"display_name": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"production": {
+			Type:     schema.TypeBool,
+			Computed: true,
+		},
+	},
+}
+
+func environmentsDataSource() *schema.Resource {
+	return &schema.Resource{
+		Read: environmentsDataSourceRead,
+		Schema: map[string]*schema.Schema{
+			"production": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Only return production (true) or non-production (false) environments. Leave empty to return all environments",
+			},
+			"names": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The names of the matching environments",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"environments": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The matching environments",
+				Elem:        environmentsItemType,
+			},
+		},
+	}
+}
+
+func environmentsDataSourceRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*client.Client)
+
+	// GetOkExists is the only way to tell an explicit false apart from an unset bool
+	production, filter := d.GetOkExists("production")
+
+	logDebug("pact_environments", d, "listing environments", "filter", filter, "production", production)
+
+	res, err := client.ListEnvironments("")
+
+	if err != nil {
+		return fmt.Errorf("error listing environments: %w", err)
+	}
+
+	names := make([]string, 0)
+	environments := make([]interface{}, 0)
+
+	for _, e := range res.Embedded.Environments {
+		if filter && e.Production != production.(bool) {
+			continue
+		}
+
+		names = append(names, e.Name)
+		environments = append(environments, map[string]interface{}{
+			"uuid":         e.UUID,
+			"name":         e.Name,
+			"display_name": e.DisplayName,
+			"production":   e.Production,
+		})
+	}
+
+	d.SetId(client.Config.BaseURL.Host)
+
+	if err := d.Set("names", names); err != nil {
+		return fmt.Errorf("error setting key 'names': %w", err)
+	}
+	if err := d.Set("environments", environments); err != nil {
+		return fmt.Errorf("error setting key 'environments': %w", err)
+	}
+
+	return nil
+}
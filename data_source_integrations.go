@@ -0,0 +1,87 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/pactflow/terraform/client"
+)
+
+var integrationType = &schema.Resource{
+	Schema: map[string]*schema.Schema{
+		"consumer_name": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"provider_name": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"verification_status": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"latest_pact_published_at": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+	},
+}
+
+func integrationsDataSource() *schema.Resource {
+	return &schema.Resource{
+		Read: integrationsDataSourceRead,
+		Schema: map[string]*schema.Schema{
+			"pacticipant": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only include integrations where this pacticipant is the consumer or the provider",
+			},
+			"integrations": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The consumer and provider pairs",
+				Elem:        integrationType,
+			},
+		},
+	}
+}
+
+func integrationsDataSourceRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*client.Client)
+	pacticipant := d.Get("pacticipant").(string)
+
+	logDebug("pact_integrations", d, "listing integrations")
+
+	res, err := client.ListIntegrations()
+
+	if err != nil {
+		return fmt.Errorf("error listing integrations: %w", err)
+	}
+
+	integrations := make([]interface{}, 0)
+	for _, i := range res.Embedded.Integrations {
+		if pacticipant != "" && i.Consumer.Name != pacticipant && i.Provider.Name != pacticipant {
+			continue
+		}
+
+		integrations = append(integrations, map[string]interface{}{
+			"consumer_name":            i.Consumer.Name,
+			"provider_name":            i.Provider.Name,
+			"verification_status":      i.VerificationStatus,
+			"latest_pact_published_at": i.LatestPactPublishedAt,
+		})
+	}
+
+	if pacticipant != "" {
+		d.SetId(pacticipant)
+	} else {
+		d.SetId(client.Config.BaseURL.Host)
+	}
+
+	if err := d.Set("integrations", integrations); err != nil {
+		return fmt.Errorf("error setting key 'integrations': %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/pactflow/terraform/client"
+)
+
+var labelType = &schema.Resource{
+	Schema: map[string]*schema.Schema{
+		"name": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"pacticipants": {
+			Type:     schema.TypeList,
+			Computed: true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+	},
+}
+
+func labelsDataSource() *schema.Resource {
+	return &schema.Resource{
+		Read: labelsDataSourceRead,
+		Schema: map[string]*schema.Schema{
+			"names": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The names of all labels applied to pacticipants",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"labels": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The labels and the pacticipants they are applied to",
+				Elem:        labelType,
+			},
+		},
+	}
+}
+
+func labelsDataSourceRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*client.Client)
+
+	logDebug("pact_labels", d, "listing pacticipant labels")
+
+	res, err := client.ListPacticipants()
+
+	if err != nil {
+		return fmt.Errorf("error listing pacticipants: %w", err)
+	}
+
+	pacticipantsByLabel := make(map[string][]string)
+	for _, p := range res.Embedded.Pacticipants {
+		for _, l := range pacticipantLabels(p) {
+			pacticipantsByLabel[l] = append(pacticipantsByLabel[l], p.Name)
+		}
+	}
+
+	names := make([]string, 0, len(pacticipantsByLabel))
+	for name := range pacticipantsByLabel {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	labels := make([]interface{}, len(names))
+	for i, name := range names {
+		labels[i] = map[string]interface{}{
+			"name":         name,
+			"pacticipants": pacticipantsByLabel[name],
+		}
+	}
+
+	d.SetId(client.Config.BaseURL.Host)
+
+	if err := d.Set("names", names); err != nil {
+		return fmt.Errorf("error setting key 'names': %w", err)
+	}
+	if err := d.Set("labels", labels); err != nil {
+		return fmt.Errorf("error setting key 'labels': %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/pactflow/terraform/broker"
+	"github.com/pactflow/terraform/client"
+)
+
+func latestPacticipantVersionDataSource() *schema.Resource {
+	return &schema.Resource{
+		Read: latestPacticipantVersionDataSourceRead,
+		Schema: map[string]*schema.Schema{
+			"pacticipant": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The name of the pacticipant",
+			},
+			"branch": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"tag"},
+				Description:   "Return the latest version on this branch",
+			},
+			"tag": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"branch"},
+				Description:   "Return the latest version with this tag",
+			},
+			"number": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The version number",
+			},
+			"build_url": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The URL of the CI build that created the version",
+			},
+			"created_at": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "When the version was created",
+			},
+		},
+	}
+}
+
+func latestPacticipantVersionDataSourceRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*client.Client)
+	pacticipant := d.Get("pacticipant").(string)
+	branch := d.Get("branch").(string)
+	tag := d.Get("tag").(string)
+
+	logDebug("pact_latest_pacticipant_version", d, "reading latest version", "pacticipant", pacticipant, "branch", branch, "tag", tag)
+
+	var version *broker.Version
+	var err error
+
+	switch {
+	case branch != "":
+		version, err = client.ReadLatestPacticipantVersionForBranch(pacticipant, branch)
+	case tag != "":
+		version, err = client.ReadLatestPacticipantVersionForTag(pacticipant, tag)
+	default:
+		version, err = client.ReadLatestPacticipantVersion(pacticipant)
+	}
+
+	if err != nil {
+		return fmt.Errorf("error reading latest version of pacticipant %q: %w", pacticipant, err)
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s", pacticipant, version.Number))
+	d.Set("number", version.Number)
+	d.Set("build_url", version.BuildURL)
+	d.Set("created_at", version.CreatedAt)
+
+	return nil
+}
@@ -0,0 +1,238 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/hashcode"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/pactflow/terraform/broker"
+	"github.com/pactflow/terraform/client"
+)
+
+var matrixRowType = &schema.Resource{
+	Schema: map[string]*schema.Schema{
+		"consumer_name": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"consumer_version": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"consumer_branch": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"provider_name": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"provider_version": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"provider_branch": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"pact_created_at": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"verified": {
+			Type:     schema.TypeBool,
+			Computed: true,
+		},
+		"verification_success": {
+			Type:     schema.TypeBool,
+			Computed: true,
+		},
+		"verified_at": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+	},
+}
+
+func matrixDataSource() *schema.Resource {
+	return &schema.Resource{
+		Read: matrixDataSourceRead,
+		Schema: map[string]*schema.Schema{
+			"selector": {
+				Type:        schema.TypeList,
+				Required:    true,
+				MinItems:    1,
+				Description: "Selects the pacticipant versions to query",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"pacticipant": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The name of the pacticipant",
+						},
+						"version": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The version number of the pacticipant",
+						},
+						"branch": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "Select versions on this branch",
+						},
+						"tag": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "Select versions with this tag",
+						},
+						"latest": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Description: "Only select the latest version (of the branch or tag, if given)",
+						},
+						"main_branch": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Description: "Select versions on the pacticipant's main branch",
+						},
+					},
+				},
+			},
+			"latestby": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice([]string{"cvp", "cvpv"}, false),
+				Description:  "Only return the latest row for each consumer version and provider (cvp), or consumer version and provider version (cvpv)",
+			},
+			"environment": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Query against the versions deployed to this environment",
+			},
+			"limit": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "The maximum number of rows to return",
+			},
+			"output_file": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "A file to write the full results to, for later pipeline steps to consume. It is written whenever the data source is read, including during terraform plan and refresh",
+			},
+			"format": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      jsonMatrixOutputFormat,
+				ValidateFunc: validation.StringInSlice(allowedMatrixOutputFormats, false),
+				Description:  "The format to write output_file in: json or junit",
+			},
+			"deployable": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the selected versions are compatible. Only meaningful when the query is a deployment check",
+			},
+			"reason": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The reason for the deployable result",
+			},
+			"success_count": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The number of rows with a successful verification",
+			},
+			"failed_count": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The number of rows with a failed verification",
+			},
+			"unknown_count": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The number of rows without a verification",
+			},
+			"rows": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The rows of the matrix",
+				Elem:        matrixRowType,
+			},
+		},
+	}
+}
+
+func matrixQueryFromState(d *schema.ResourceData) broker.MatrixQuery {
+	query := broker.MatrixQuery{
+		LatestBy:    d.Get("latestby").(string),
+		Environment: d.Get("environment").(string),
+		Limit:       d.Get("limit").(int),
+	}
+
+	for _, s := range d.Get("selector").([]interface{}) {
+		selector := s.(map[string]interface{})
+		query.Selectors = append(query.Selectors, broker.MatrixSelector{
+			Pacticipant: selector["pacticipant"].(string),
+			Version:     selector["version"].(string),
+			Branch:      selector["branch"].(string),
+			Tag:         selector["tag"].(string),
+			Latest:      selector["latest"].(bool),
+			MainBranch:  selector["main_branch"].(bool),
+		})
+	}
+
+	return query
+}
+
+func matrixDataSourceRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*client.Client)
+	query := matrixQueryFromState(d)
+
+	logDebug("pact_matrix", d, "querying matrix", "query", query)
+
+	res, err := client.QueryMatrix(query)
+
+	if err != nil {
+		return fmt.Errorf("error querying matrix: %w", err)
+	}
+
+	rows := make([]interface{}, len(res.Matrix))
+	for i, r := range res.Matrix {
+		row := map[string]interface{}{
+			"consumer_name":    r.Consumer.Name,
+			"consumer_version": r.Consumer.Version.Number,
+			"consumer_branch":  r.Consumer.Version.Branch,
+			"provider_name":    r.Provider.Name,
+			"provider_version": r.Provider.Version.Number,
+			"provider_branch":  r.Provider.Version.Branch,
+			"pact_created_at":  r.Pact.CreatedAt,
+			"verified":         r.VerificationResult != nil,
+		}
+		if r.VerificationResult != nil {
+			row["verification_success"] = r.VerificationResult.Success
+			row["verified_at"] = r.VerificationResult.VerifiedAt
+		}
+		rows[i] = row
+	}
+
+	d.SetId(fmt.Sprintf("%d", hashcode.String(fmt.Sprintf("%+v", query))))
+	d.Set("deployable", res.Summary.Deployable != nil && *res.Summary.Deployable)
+	d.Set("reason", res.Summary.Reason)
+	d.Set("success_count", res.Summary.Success)
+	d.Set("failed_count", res.Summary.Failed)
+	d.Set("unknown_count", res.Summary.Unknown)
+
+	if err := d.Set("rows", rows); err != nil {
+		return fmt.Errorf("error setting key 'rows': %w", err)
+	}
+
+	// Data sources are read during plan and refresh as well as apply, so the file is (re)written by each of
+	// them, not only when changes are applied
+	if path := d.Get("output_file").(string); path != "" {
+		if err := writeMatrixOutput(path, d.Get("format").(string), res); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,121 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/pactflow/terraform/client"
+)
+
+var pactVersionType = &schema.Resource{
+	Schema: map[string]*schema.Schema{
+		"consumer_version": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"created_at": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"interactions_count": {
+			Type:     schema.TypeInt,
+			Computed: true,
+		},
+		"specification_version": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+	},
+}
+
+func pactVersionsDataSource() *schema.Resource {
+	return &schema.Resource{
+		Read: pactVersionsDataSourceRead,
+		Schema: map[string]*schema.Schema{
+			"consumer_name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The name of the consumer",
+			},
+			"provider_name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The name of the provider",
+			},
+			"limit": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      10,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "The maximum number of pact versions to read, newest first",
+			},
+			"consumer_versions": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The consumer versions that published a pact, newest first",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"pacts": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Metadata about the most recent pacts, newest first",
+				Elem:        pactVersionType,
+			},
+		},
+	}
+}
+
+func pactVersionsDataSourceRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*client.Client)
+	consumer := d.Get("consumer_name").(string)
+	provider := d.Get("provider_name").(string)
+	limit := d.Get("limit").(int)
+
+	logDebug("pact_pact_versions", d, "listing pact versions", "consumer", consumer, "provider", provider)
+
+	res, err := client.ListPactVersions(consumer, provider)
+
+	if err != nil {
+		return fmt.Errorf("error listing pact versions between %q and %q: %w", consumer, provider, err)
+	}
+
+	versions := make([]string, len(res.Links.PactVersions))
+	for i, l := range res.Links.PactVersions {
+		versions[i] = l.Name
+	}
+
+	// Each pact has to be fetched individually to get its metadata, so only the most recent are read
+	pacts := make([]interface{}, 0)
+	for i, version := range versions {
+		if i >= limit {
+			break
+		}
+
+		pact, err := client.ReadPact(consumer, provider, version)
+
+		if err != nil {
+			return fmt.Errorf("error reading pact between %q and %q for version %q: %w", consumer, provider, version, err)
+		}
+
+		pacts = append(pacts, map[string]interface{}{
+			"consumer_version":      version,
+			"created_at":            pact.CreatedAt,
+			"interactions_count":    len(pact.Interactions) + len(pact.Messages),
+			"specification_version": pact.Metadata.PactSpecification.Version,
+		})
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s", consumer, provider))
+
+	if err := d.Set("consumer_versions", versions); err != nil {
+		return fmt.Errorf("error setting key 'consumer_versions': %w", err)
+	}
+	if err := d.Set("pacts", pacts); err != nil {
+		return fmt.Errorf("error setting key 'pacts': %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/pactflow/terraform/broker"
+	"github.com/pactflow/terraform/client"
+)
+
+func pacticipantDataSource() *schema.Resource {
+	return &schema.Resource{
+		Read: pacticipantDataSourceRead,
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Name of the Pacticipant",
+			},
+			"uuid": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The UUID of the pacticipant",
+			},
+			"display_name": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The display name of the pacticipant",
+			},
+			"main_branch": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Main (default) branch",
+			},
+			"repository_url": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "URL or location of the VCS repository",
+			},
+			"latest_version": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The most recently created version of the pacticipant (empty if no versions exist)",
+			},
+		},
+	}
+}
+
+func pacticipantDataSourceRead(d *schema.ResourceData, meta interface{}) error {
+	httpClient := meta.(*client.Client)
+	name := d.Get("name").(string)
+
+	logDebug("pact_pacticipant", d, "reading pacticipant data source", "name", name)
+
+	pacticipant, err := httpClient.ReadPacticipant(name)
+
+	if err != nil {
+		return fmt.Errorf("error reading pacticipant %q: %w", name, err)
+	}
+
+	latestVersion := ""
+	version, err := httpClient.ReadLatestPacticipantVersion(name)
+
+	if err == nil {
+		latestVersion = version.Number
+	} else if !errors.Is(err, client.ErrNotFound) {
+		return fmt.Errorf("error reading latest version of pacticipant %q: %w", name, err)
+	}
+
+	d.SetId(pacticipant.Name)
+	d.Set("name", pacticipant.Name)
+	d.Set("uuid", pacticipantUUID(pacticipant))
+	d.Set("display_name", pacticipant.DisplayName)
+	d.Set("main_branch", pacticipant.MainBranch)
+	d.Set("repository_url", pacticipant.RepositoryURL)
+	d.Set("latest_version", latestVersion)
+
+	return nil
+}
+
+// pacticipantUUID is the UUID the broker returned for the pacticipant or, when the body doesn't include it,
+// the last segment of its self link
+func pacticipantUUID(p *broker.PacticipantResponse) string {
+	if p.UUID != "" {
+		return p.UUID
+	}
+	if self, ok := p.Links["self"]; ok {
+		return idFromSelfLink(self.Href)
+	}
+	return ""
+}
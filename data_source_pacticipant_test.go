@@ -0,0 +1,62 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/pactflow/terraform/broker"
+)
+
+func TestPacticipantDataSourceRead(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/hal+json")
+		switch r.URL.Path {
+		case "/pacticipants/Foo":
+			fmt.Fprint(w, `{"name": "Foo", "displayName": "Foo API", "mainBranch": "main", "uuid": "c2bc5e1a", "_links": {"self": {"href": "http://broker/pacticipants/Foo"}}}`)
+		case "/pacticipants/Foo/latest-version":
+			fmt.Fprint(w, `{"number": "1.2.3"}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	meta, err := configureProvider(schema.TestResourceDataRaw(t, Provider().Schema, map[string]interface{}{
+		"host":                        server.URL,
+		"skip_credentials_validation": true,
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	d := schema.TestResourceDataRaw(t, pacticipantDataSource().Schema, map[string]interface{}{"name": "Foo"})
+	if err := pacticipantDataSourceRead(d, meta); err != nil {
+		t.Fatal(err)
+	}
+
+	for key, expected := range map[string]string{
+		"uuid":           "c2bc5e1a",
+		"display_name":   "Foo API",
+		"main_branch":    "main",
+		"latest_version": "1.2.3",
+	} {
+		if actual := d.Get(key).(string); actual != expected {
+			t.Fatalf("expected %s to be %q, got %q", key, expected, actual)
+		}
+	}
+}
+
+func TestPacticipantUUID(t *testing.T) {
+	for expected, p := range map[string]broker.PacticipantResponse{
+		"c2bc5e1a": {UUID: "c2bc5e1a", HalDoc: broker.HalDoc{Links: broker.HalLinks{"self": {Href: "http://broker/pacticipants/Foo"}}}},
+		"7f3d9a20": {HalDoc: broker.HalDoc{Links: broker.HalLinks{"self": {Href: "https://foo.pactflow.io/pacticipants/7f3d9a20"}}}},
+		"":         {},
+	} {
+		if actual := pacticipantUUID(&p); actual != expected {
+			t.Fatalf("expected the uuid to be %q, got %q", expected, actual)
+		}
+	}
+}
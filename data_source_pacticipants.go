@@ -0,0 +1,130 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/pactflow/terraform/broker"
+	"github.com/pactflow/terraform/client"
+)
+
+var pacticipantsItemType = &schema.Resource{
+	Schema: map[string]*schema.Schema{
+		"name": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"display_name": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"main_branch": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"repository_url": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"labels": {
+			Type:     schema.TypeList,
+			Computed: true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+	},
+}
+
+func pacticipantsDataSource() *schema.Resource {
+	return &schema.Resource{
+		Read: pacticipantsDataSourceRead,
+		Schema: map[string]*schema.Schema{
+			"name_prefix": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return pacticipants whose name starts with this prefix",
+			},
+			"label": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return pacticipants with this label",
+			},
+			"names": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The names of the matching pacticipants",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"pacticipants": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The matching pacticipants",
+				Elem:        pacticipantsItemType,
+			},
+		},
+	}
+}
+
+func pacticipantsDataSourceRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*client.Client)
+	prefix := d.Get("name_prefix").(string)
+	label := d.Get("label").(string)
+
+	logDebug("pact_pacticipants", d, "listing pacticipants", "prefix", prefix, "label", label)
+
+	res, err := client.ListPacticipants()
+
+	if err != nil {
+		return fmt.Errorf("error listing pacticipants: %w", err)
+	}
+
+	names := make([]string, 0)
+	pacticipants := make([]interface{}, 0)
+
+	for _, p := range res.Embedded.Pacticipants {
+		labels := pacticipantLabels(p)
+
+		if !strings.HasPrefix(p.Name, prefix) {
+			continue
+		}
+		if label != "" && !stringContains(labels, label) {
+			continue
+		}
+
+		names = append(names, p.Name)
+		pacticipants = append(pacticipants, map[string]interface{}{
+			"name":           p.Name,
+			"display_name":   p.DisplayName,
+			"main_branch":    p.MainBranch,
+			"repository_url": p.RepositoryURL,
+			"labels":         labels,
+		})
+	}
+
+	d.SetId(client.Config.BaseURL.Host)
+
+	if err := d.Set("names", names); err != nil {
+		return fmt.Errorf("error setting key 'names': %w", err)
+	}
+	if err := d.Set("pacticipants", pacticipants); err != nil {
+		return fmt.Errorf("error setting key 'pacticipants': %w", err)
+	}
+
+	return nil
+}
+
+func pacticipantLabels(p broker.Pacticipant) []string {
+	labels := make([]string, 0)
+
+	if p.Embedded != nil {
+		for _, l := range p.Embedded.Labels {
+			labels = append(labels, l.Name)
+		}
+	}
+
+	return labels
+}
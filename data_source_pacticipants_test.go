@@ -0,0 +1,40 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/pactflow/terraform/broker"
+	"github.com/pactflow/terraform/brokertest"
+)
+
+// Filtering by label mustn't change the order the labels are exported in
+func TestPacticipantsDataSource_LabelOrder(t *testing.T) {
+	server := brokertest.NewServer()
+	defer server.Close()
+
+	server.PutPacticipant(broker.Pacticipant{
+		Name:     "Foo",
+		Embedded: &broker.PacticipantEmbeddedItems{Labels: []broker.Label{{Name: "payments"}, {Name: "backend"}}},
+	})
+
+	meta, err := configureProvider(schema.TestResourceDataRaw(t, Provider().Schema, map[string]interface{}{
+		"host": server.URL,
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, label := range []string{"", "backend"} {
+		d := schema.TestResourceDataRaw(t, pacticipantsDataSource().Schema, map[string]interface{}{"label": label})
+		if err := pacticipantsDataSourceRead(d, meta); err != nil {
+			t.Fatal(err)
+		}
+
+		expected := []interface{}{"payments", "backend"}
+		if labels := d.Get("pacticipants.0.labels").([]interface{}); !reflect.DeepEqual(labels, expected) {
+			t.Fatalf("expected the labels %v when filtering by %q, got %v", expected, label, labels)
+		}
+	}
+}
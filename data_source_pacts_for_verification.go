@@ -0,0 +1,276 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/hashcode"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/pactflow/terraform/broker"
+	"github.com/pactflow/terraform/client"
+)
+
+var consumerVersionSelectorType = &schema.Resource{
+	Schema: map[string]*schema.Schema{
+		"main_branch": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Description: "Select the latest version from each consumer's main branch",
+		},
+		"branch": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "Select versions from this branch",
+		},
+		"matching_branch": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Description: "Select versions from the branch with the same name as the provider version branch",
+		},
+		"fallback_branch": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "The branch to fall back to if there are no versions on the branch",
+		},
+		"consumer": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "Only apply the selector to this consumer",
+		},
+		"tag": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "Select versions with this tag (legacy)",
+		},
+		"latest": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Description: "Only select the latest version matching the other criteria",
+		},
+		"deployed_or_released": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Description: "Select versions currently deployed or released to any environment",
+		},
+		"deployed": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Description: "Select versions currently deployed to any (or the given) environment",
+		},
+		"released": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Description: "Select versions currently released to any (or the given) environment",
+		},
+		"environment": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "Only select versions in this environment",
+		},
+	},
+}
+
+var pactForVerificationType = &schema.Resource{
+	Schema: map[string]*schema.Schema{
+		"url": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"name": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"short_description": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"pending": {
+			Type:     schema.TypeBool,
+			Computed: true,
+		},
+		"wip": {
+			Type:     schema.TypeBool,
+			Computed: true,
+		},
+	},
+}
+
+func pactsForVerificationDataSource() *schema.Resource {
+	return &schema.Resource{
+		Read: pactsForVerificationDataSourceRead,
+		Schema: map[string]*schema.Schema{
+			"provider_name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The name of the provider",
+			},
+			"consumer_version_selector": {
+				Type:        schema.TypeList,
+				Required:    true,
+				MinItems:    1,
+				Description: "Selects the consumer versions whose pacts should be verified",
+				Elem:        consumerVersionSelectorType,
+			},
+			"provider_version_branch": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The branch of the provider version that will perform the verification. Required for pending pacts",
+			},
+			"include_pending_status": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether to calculate the pending status of each pact",
+			},
+			"include_wip_pacts_since": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Include work in progress pacts created after this date (YYYY-MM-DD)",
+			},
+			"selectors_json": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The consumer version selectors as JSON, for passing to a verification job",
+			},
+			"urls": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The URLs of the pacts to verify",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"pacts": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The pacts to verify",
+				Elem:        pactForVerificationType,
+			},
+		},
+	}
+}
+
+func pactsForVerificationRequestFromState(d *schema.ResourceData) broker.PactsForVerificationRequest {
+	request := broker.PactsForVerificationRequest{
+		Provider:                 d.Get("provider_name").(string),
+		ConsumerVersionSelectors: []broker.ConsumerVersionSelector{},
+		ProviderVersionBranch:    d.Get("provider_version_branch").(string),
+		IncludePendingStatus:     d.Get("include_pending_status").(bool),
+		IncludeWipPactsSince:     d.Get("include_wip_pacts_since").(string),
+	}
+
+	for _, s := range d.Get("consumer_version_selector").([]interface{}) {
+		selector := s.(map[string]interface{})
+		request.ConsumerVersionSelectors = append(request.ConsumerVersionSelectors, broker.ConsumerVersionSelector{
+			MainBranch:         selector["main_branch"].(bool),
+			Branch:             selector["branch"].(string),
+			MatchingBranch:     selector["matching_branch"].(bool),
+			FallbackBranch:     selector["fallback_branch"].(string),
+			Consumer:           selector["consumer"].(string),
+			Tag:                selector["tag"].(string),
+			Latest:             selector["latest"].(bool),
+			DeployedOrReleased: selector["deployed_or_released"].(bool),
+			Deployed:           selector["deployed"].(bool),
+			Released:           selector["released"].(bool),
+			Environment:        selector["environment"].(string),
+		})
+	}
+
+	return request
+}
+
+// validateConsumerVersionSelector checks the selector is a combination the broker accepts, so that an
+// invalid selector fails the plan rather than the verification job it is passed on to
+func validateConsumerVersionSelector(s broker.ConsumerVersionSelector) error {
+	if !s.MainBranch && s.Branch == "" && !s.MatchingBranch && s.Tag == "" && !s.DeployedOrReleased && !s.Deployed && !s.Released && s.Environment == "" {
+		if s.Latest {
+			return fmt.Errorf("latest must be combined with main_branch, branch, matching_branch or tag")
+		}
+		return fmt.Errorf("at least one of main_branch, branch, matching_branch, tag, deployed_or_released, deployed, released or environment must be set")
+	}
+
+	branches := 0
+	for _, set := range []bool{s.MainBranch, s.Branch != "", s.MatchingBranch, s.Tag != ""} {
+		if set {
+			branches++
+		}
+	}
+	if branches > 1 {
+		return fmt.Errorf("only one of main_branch, branch, matching_branch or tag can be set")
+	}
+
+	if s.FallbackBranch != "" && s.Branch == "" && !s.MatchingBranch {
+		return fmt.Errorf("fallback_branch can only be used with branch or matching_branch")
+	}
+
+	deployments := 0
+	for _, set := range []bool{s.DeployedOrReleased, s.Deployed, s.Released} {
+		if set {
+			deployments++
+		}
+	}
+	if deployments > 1 {
+		return fmt.Errorf("only one of deployed_or_released, deployed or released can be set")
+	}
+	if (deployments > 0 || s.Environment != "") && branches > 0 {
+		return fmt.Errorf("deployed_or_released, deployed, released and environment can't be combined with main_branch, branch, matching_branch or tag")
+	}
+	if (deployments > 0 || s.Environment != "") && s.Latest {
+		return fmt.Errorf("latest can't be combined with deployed_or_released, deployed, released or environment")
+	}
+
+	return nil
+}
+
+func pactsForVerificationDataSourceRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*client.Client)
+	request := pactsForVerificationRequestFromState(d)
+
+	for i, s := range request.ConsumerVersionSelectors {
+		if err := validateConsumerVersionSelector(s); err != nil {
+			return fmt.Errorf("invalid consumer_version_selector.%d: %w", i, err)
+		}
+	}
+
+	selectors, err := json.Marshal(request.ConsumerVersionSelectors)
+
+	if err != nil {
+		return fmt.Errorf("error serialising consumer version selectors: %w", err)
+	}
+
+	logDebug("pact_pacts_for_verification", d, "reading pacts for verification", "provider", request.Provider, "selectors", request.ConsumerVersionSelectors)
+
+	res, err := client.ReadPactsForVerification(request)
+
+	if err != nil {
+		return fmt.Errorf("error reading pacts for verification for provider %q: %w", request.Provider, err)
+	}
+
+	urls := make([]string, 0)
+	pacts := make([]interface{}, 0)
+
+	for _, p := range res.Embedded.Pacts {
+		self := p.Links["self"]
+
+		urls = append(urls, self.Href)
+		pacts = append(pacts, map[string]interface{}{
+			"url":               self.Href,
+			"name":              self.Name,
+			"short_description": p.ShortDescription,
+			"pending":           p.VerificationProperties.Pending,
+			"wip":               p.VerificationProperties.Wip,
+		})
+	}
+
+	d.SetId(fmt.Sprintf("%s/%d", request.Provider, hashcode.String(string(selectors))))
+	d.Set("selectors_json", string(selectors))
+
+	if err := d.Set("urls", urls); err != nil {
+		return fmt.Errorf("error setting key 'urls': %w", err)
+	}
+	if err := d.Set("pacts", pacts); err != nil {
+		return fmt.Errorf("error setting key 'pacts': %w", err)
+	}
+
+	return nil
+}
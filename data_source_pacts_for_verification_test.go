@@ -0,0 +1,76 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/pactflow/terraform/broker"
+)
+
+// The query is a POST, but only reads from the broker, so it is still sent in dry run mode
+func TestPactsForVerificationDataSource_DryRun(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/pacts/provider/Bar/for-verification" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/hal+json")
+		fmt.Fprint(w, `{"_embedded": {"pacts": [{"shortDescription": "latest from main branch", "_links": {"self": {"href": "http://broker/pacts/provider/Bar/consumer/Foo/pact-version/1", "name": "Pact between Foo and Bar"}}}]}}`)
+	}))
+	defer server.Close()
+
+	meta, err := configureProvider(schema.TestResourceDataRaw(t, Provider().Schema, map[string]interface{}{
+		"host":    server.URL,
+		"dry_run": true,
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	d := schema.TestResourceDataRaw(t, pactsForVerificationDataSource().Schema, map[string]interface{}{
+		"provider_name":             "Bar",
+		"consumer_version_selector": []interface{}{map[string]interface{}{"main_branch": true}},
+	})
+
+	if err := pactsForVerificationDataSourceRead(d, meta); err != nil {
+		t.Fatalf("expected the pacts to be read in dry run mode, got %s", err)
+	}
+	if urls := d.Get("urls").([]interface{}); len(urls) != 1 || urls[0] != "http://broker/pacts/provider/Bar/consumer/Foo/pact-version/1" {
+		t.Fatalf("expected the pact URL, got %v", urls)
+	}
+}
+
+func TestValidateConsumerVersionSelector(t *testing.T) {
+	for _, s := range []broker.ConsumerVersionSelector{
+		{MainBranch: true},
+		{Branch: "feat/x", FallbackBranch: "main", Latest: true},
+		{MatchingBranch: true, FallbackBranch: "main"},
+		{Tag: "prod", Latest: true},
+		{DeployedOrReleased: true},
+		{Deployed: true, Environment: "production"},
+		{Environment: "production", Consumer: "orders"},
+	} {
+		if err := validateConsumerVersionSelector(s); err != nil {
+			t.Fatalf("expected selector %+v to be valid, got %s", s, err)
+		}
+	}
+
+	for _, s := range []broker.ConsumerVersionSelector{
+		{},
+		{Latest: true},
+		{Consumer: "orders"},
+		{MainBranch: true, Branch: "main"},
+		{Tag: "prod", Branch: "main"},
+		{MainBranch: true, FallbackBranch: "main"},
+		{Deployed: true, Released: true},
+		{Deployed: true, Branch: "main"},
+		{Environment: "production", Latest: true},
+	} {
+		if err := validateConsumerVersionSelector(s); err == nil {
+			t.Fatalf("expected selector %+v to be invalid", s)
+		}
+	}
+}
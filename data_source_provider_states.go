@@ -0,0 +1,107 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/pactflow/terraform/client"
+)
+
+var providerStateType = &schema.Resource{
+	Schema: map[string]*schema.Schema{
+		"name": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"params_json": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"consumers": {
+			Type:     schema.TypeList,
+			Computed: true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+	},
+}
+
+func providerStatesDataSource() *schema.Resource {
+	return &schema.Resource{
+		Read: providerStatesDataSourceRead,
+		Schema: map[string]*schema.Schema{
+			"provider_name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The name of the provider",
+			},
+			"names": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The distinct names of the provider states",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"provider_states": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The provider states, with their parameters and the consumers that declare them",
+				Elem:        providerStateType,
+			},
+		},
+	}
+}
+
+func providerStatesDataSourceRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*client.Client)
+	provider := d.Get("provider_name").(string)
+
+	logDebug("pact_provider_states", d, "listing provider states", "provider", provider)
+
+	res, err := client.ListProviderStates(provider)
+
+	if err != nil {
+		return fmt.Errorf("error listing provider states of %q: %w", provider, err)
+	}
+
+	// The same state may be declared with different params, so is listed once per params
+	names := make([]string, 0)
+	seen := make(map[string]bool)
+	states := make([]interface{}, 0)
+
+	for _, s := range res.ProviderStates {
+		if !seen[s.Name] {
+			seen[s.Name] = true
+			names = append(names, s.Name)
+		}
+
+		params := ""
+		if len(s.Params) > 0 {
+			b, err := json.Marshal(s.Params)
+			if err != nil {
+				return fmt.Errorf("error encoding params of provider state %q: %w", s.Name, err)
+			}
+			params = string(b)
+		}
+
+		states = append(states, map[string]interface{}{
+			"name":        s.Name,
+			"params_json": params,
+			"consumers":   s.Consumers,
+		})
+	}
+
+	d.SetId(provider)
+
+	if err := d.Set("names", names); err != nil {
+		return fmt.Errorf("error setting key 'names': %w", err)
+	}
+	if err := d.Set("provider_states", states); err != nil {
+		return fmt.Errorf("error setting key 'provider_states': %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/pactflow/terraform/client"
+)
+
+func roleDataSource() *schema.Resource {
+	return &schema.Resource{
+		Read: roleDataSourceRead,
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The name of the built-in or custom role",
+			},
+			"uuid": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The UUID of the role",
+			},
+			"scopes": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The permission scopes granted by the role",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+}
+
+func roleDataSourceRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*client.Client)
+	name := d.Get("name").(string)
+
+	logDebug("pact_role", d, "finding role by name", "name", name)
+
+	res, err := client.ListRoles()
+
+	if err != nil {
+		return fmt.Errorf("error listing roles: %w", err)
+	}
+
+	for _, r := range res.Roles {
+		if r.Name != name {
+			continue
+		}
+
+		scopes := make([]string, len(r.Permissions))
+		for i, p := range r.Permissions {
+			scopes[i] = p.Scope
+		}
+
+		d.SetId(r.UUID)
+		d.Set("uuid", r.UUID)
+
+		if err := d.Set("scopes", scopes); err != nil {
+			return fmt.Errorf("error setting key 'scopes': %w", err)
+		}
+
+		return nil
+	}
+
+	return fmt.Errorf("role %q not found", name)
+}
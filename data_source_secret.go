@@ -0,0 +1,68 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/pactflow/terraform/client"
+)
+
+func secretDataSource() *schema.Resource {
+	return &schema.Resource{
+		Read: secretDataSourceRead,
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The name of the secret",
+			},
+			"team": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "The team the secret belongs to (uuid), for when secret names are reused across teams",
+				ValidateFunc: validateUUID,
+			},
+			"uuid": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The UUID of the secret",
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The description of the secret",
+			},
+		},
+	}
+}
+
+func secretDataSourceRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*client.Client)
+	name := d.Get("name").(string)
+	team := d.Get("team").(string)
+
+	logDebug("pact_secret", d, "finding secret by name", "name", name)
+
+	res, err := client.ListSecrets()
+
+	if err != nil {
+		return fmt.Errorf("error listing secrets: %w", err)
+	}
+
+	for _, s := range res.Embedded.Secrets {
+		if s.Name != name || (team != "" && s.TeamUUID != team) {
+			continue
+		}
+
+		uuid := idFromSelfLink(s.Links["self"].Href)
+
+		d.SetId(uuid)
+		d.Set("uuid", uuid)
+		d.Set("team", s.TeamUUID)
+		d.Set("description", s.Description)
+
+		return nil
+	}
+
+	return fmt.Errorf("secret %q not found", name)
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/pactflow/terraform/client"
+)
+
+func systemAccountDataSource() *schema.Resource {
+	return &schema.Resource{
+		Read: systemAccountDataSourceRead,
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The name of the system account",
+			},
+			"uuid": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The UUID of the system account",
+			},
+			"active": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the system account is active",
+			},
+			"roles": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The UUIDs of the roles assigned to the system account",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"role_names": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The names of the roles assigned to the system account",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+}
+
+func systemAccountDataSourceRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*client.Client)
+	name := d.Get("name").(string)
+
+	logDebug("pact_system_account", d, "finding system account by name", "name", name)
+
+	accounts, err := client.ListSystemAccounts()
+
+	if err != nil {
+		return fmt.Errorf("error listing system accounts: %w", err)
+	}
+
+	uuid := ""
+	for _, a := range accounts.Users {
+		if a.Name == name {
+			uuid = a.UUID
+			break
+		}
+	}
+
+	if uuid == "" {
+		return fmt.Errorf("system account %q not found", name)
+	}
+
+	account, err := client.ReadUser(uuid)
+
+	if err != nil {
+		return fmt.Errorf("error reading system account %q: %w", uuid, err)
+	}
+
+	d.SetId(account.UUID)
+	d.Set("uuid", account.UUID)
+	d.Set("active", account.Active)
+
+	return setUserRoleAttributes(d, account)
+}
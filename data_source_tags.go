@@ -0,0 +1,104 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/pactflow/terraform/client"
+)
+
+var tagType = &schema.Resource{
+	Schema: map[string]*schema.Schema{
+		"name": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"latest_version": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"versions": {
+			Type:     schema.TypeList,
+			Computed: true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+	},
+}
+
+func tagsDataSource() *schema.Resource {
+	return &schema.Resource{
+		Read: tagsDataSourceRead,
+		Schema: map[string]*schema.Schema{
+			"pacticipant": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The name of the pacticipant",
+			},
+			"names": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The names of the tags",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"tags": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The tags of the pacticipant and the versions they are applied to",
+				Elem:        tagType,
+			},
+		},
+	}
+}
+
+func tagsDataSourceRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*client.Client)
+	pacticipant := d.Get("pacticipant").(string)
+
+	logDebug("pact_tags", d, "listing tags for pacticipant", "pacticipant", pacticipant)
+
+	res, err := client.ListPacticipantVersions(pacticipant)
+
+	if err != nil {
+		return fmt.Errorf("error listing versions of pacticipant %q: %w", pacticipant, err)
+	}
+
+	// Versions are returned newest first, so the first version seen for a tag is the latest
+	names := make([]string, 0)
+	versionsByTag := make(map[string][]string)
+
+	for _, v := range res.Embedded.Versions {
+		if v.Embedded == nil {
+			continue
+		}
+		for _, t := range v.Embedded.Tags {
+			if _, ok := versionsByTag[t.Name]; !ok {
+				names = append(names, t.Name)
+			}
+			versionsByTag[t.Name] = append(versionsByTag[t.Name], v.Number)
+		}
+	}
+
+	tags := make([]interface{}, len(names))
+	for i, name := range names {
+		tags[i] = map[string]interface{}{
+			"name":           name,
+			"latest_version": versionsByTag[name][0],
+			"versions":       versionsByTag[name],
+		}
+	}
+
+	d.SetId(pacticipant)
+
+	if err := d.Set("names", names); err != nil {
+		return fmt.Errorf("error setting key 'names': %w", err)
+	}
+	if err := d.Set("tags", tags); err != nil {
+		return fmt.Errorf("error setting key 'tags': %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/pactflow/terraform/broker"
+	"github.com/pactflow/terraform/client"
+)
+
+func teamDataSource() *schema.Resource {
+	return &schema.Resource{
+		Read: teamDataSourceRead,
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				ConflictsWith: []string{"uuid"},
+				Description:   "The name of the Team to look up",
+			},
+			"uuid": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				ConflictsWith: []string{"name"},
+				Description:   "The UUID of the Team to look up",
+				ValidateFunc:  validateUUID,
+			},
+			"number_of_members": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The number of users in the Team",
+			},
+			"pacticipants": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The names of the pacticipants assigned to the Team",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+}
+
+func teamDataSourceRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*client.Client)
+	name := d.Get("name").(string)
+	uuid := d.Get("uuid").(string)
+
+	if name == "" && uuid == "" {
+		return fmt.Errorf("one of 'name' or 'uuid' must be provided")
+	}
+
+	if uuid == "" {
+		logDebug("pact_team", d, "finding team by name", "name", name)
+
+		teams, err := client.ListTeams()
+
+		if err != nil {
+			return fmt.Errorf("error listing teams: %w", err)
+		}
+
+		for _, t := range teams.Teams {
+			if t.Name == name {
+				uuid = t.UUID
+				break
+			}
+		}
+
+		if uuid == "" {
+			return fmt.Errorf("team %q not found", name)
+		}
+	}
+
+	logDebug("pact_team", d, "reading team data source", "uuid", uuid)
+
+	// Only the single team resource embeds the assigned pacticipants
+	team, err := client.ReadTeam(broker.Team{
+		UUID: uuid,
+	})
+
+	if err != nil {
+		return fmt.Errorf("error reading team %q: %w", uuid, err)
+	}
+
+	pacticipants := make([]string, len(team.Embedded.Pacticipants))
+	for i, p := range team.Embedded.Pacticipants {
+		pacticipants[i] = p.Name
+	}
+
+	d.SetId(team.UUID)
+	d.Set("uuid", team.UUID)
+	d.Set("name", team.Name)
+	d.Set("number_of_members", team.NumberOfMembers)
+
+	if err := d.Set("pacticipants", pacticipants); err != nil {
+		return fmt.Errorf("error setting key 'pacticipants': %w", err)
+	}
+
+	return nil
+}
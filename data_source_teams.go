@@ -0,0 +1,82 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/pactflow/terraform/client"
+)
+
+var teamsItemType = &schema.Resource{
+	Schema: map[string]*schema.Schema{
+		"uuid": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"name": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"number_of_members": {
+			Type:     schema.TypeInt,
+			Computed: true,
+		},
+	},
+}
+
+func teamsDataSource() *schema.Resource {
+	return &schema.Resource{
+		Read: teamsDataSourceRead,
+		Schema: map[string]*schema.Schema{
+			"uuids": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The UUIDs of all teams",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"teams": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "All teams in the account",
+				Elem:        teamsItemType,
+			},
+		},
+	}
+}
+
+func teamsDataSourceRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*client.Client)
+
+	logDebug("pact_teams", d, "listing teams")
+
+	res, err := client.ListTeams()
+
+	if err != nil {
+		return fmt.Errorf("error listing teams: %w", err)
+	}
+
+	uuids := make([]string, 0)
+	teams := make([]interface{}, 0)
+
+	for _, t := range res.Teams {
+		uuids = append(uuids, t.UUID)
+		teams = append(teams, map[string]interface{}{
+			"uuid":              t.UUID,
+			"name":              t.Name,
+			"number_of_members": t.NumberOfMembers,
+		})
+	}
+
+	d.SetId(client.Config.BaseURL.Host)
+
+	if err := d.Set("uuids", uuids); err != nil {
+		return fmt.Errorf("error setting key 'uuids': %w", err)
+	}
+	if err := d.Set("teams", teams); err != nil {
+		return fmt.Errorf("error setting key 'teams': %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,111 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/pactflow/terraform/broker"
+	"github.com/pactflow/terraform/client"
+)
+
+func userDataSource() *schema.Resource {
+	return &schema.Resource{
+		Read: userDataSourceRead,
+		Schema: map[string]*schema.Schema{
+			"email": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The email address of the user (case insensitive)",
+			},
+			"uuid": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The UUID of the user",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The full name of the user",
+			},
+			"active": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the user is active",
+			},
+			"roles": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The UUIDs of the roles assigned to the user",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"role_names": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The names of the roles assigned to the user",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+}
+
+func userDataSourceRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*client.Client)
+	email := d.Get("email").(string)
+
+	logDebug("pact_user", d, "finding user by email", "email", email)
+
+	users, err := client.ListUsers()
+
+	if err != nil {
+		return fmt.Errorf("error listing users: %w", err)
+	}
+
+	uuid := ""
+	for _, u := range users.Users {
+		if strings.EqualFold(u.Email, email) {
+			uuid = u.UUID
+			break
+		}
+	}
+
+	if uuid == "" {
+		return fmt.Errorf("user with email %q not found", email)
+	}
+
+	// Role assignments are only embedded in the single user resource
+	user, err := client.ReadUser(uuid)
+
+	if err != nil {
+		return fmt.Errorf("error reading user %q: %w", uuid, err)
+	}
+
+	d.SetId(user.UUID)
+	d.Set("uuid", user.UUID)
+	d.Set("name", user.Name)
+	d.Set("active", user.Active)
+
+	return setUserRoleAttributes(d, user)
+}
+
+func setUserRoleAttributes(d *schema.ResourceData, user *broker.User) error {
+	roles := make([]string, len(user.Embedded.Roles))
+	roleNames := make([]string, len(user.Embedded.Roles))
+	for i, r := range user.Embedded.Roles {
+		roles[i] = r.UUID
+		roleNames[i] = r.Name
+	}
+
+	if err := d.Set("roles", roles); err != nil {
+		return fmt.Errorf("error setting key 'roles': %w", err)
+	}
+	if err := d.Set("role_names", roleNames); err != nil {
+		return fmt.Errorf("error setting key 'role_names': %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,132 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/pactflow/terraform/broker"
+	"github.com/pactflow/terraform/client"
+)
+
+var usersItemType = &schema.Resource{
+	Schema: map[string]*schema.Schema{
+		"uuid": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"name": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"email": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"active": {
+			Type:     schema.TypeBool,
+			Computed: true,
+		},
+		"type": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"identity_provider_id": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"last_login": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+	},
+}
+
+func usersDataSource() *schema.Resource {
+	return &schema.Resource{
+		Read: usersDataSourceRead,
+		Schema: map[string]*schema.Schema{
+			"active": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Only return active (true) or inactive (false) users. Leave empty to return all users",
+			},
+			"identity_provider_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return users authenticated by this identity provider",
+			},
+			"include_system_accounts": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether system accounts should be included in the results",
+			},
+			"uuids": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The UUIDs of the matching users",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"users": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The matching users",
+				Elem:        usersItemType,
+			},
+		},
+	}
+}
+
+func usersDataSourceRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*client.Client)
+	active, filterActive := d.GetOkExists("active")
+	identityProvider := d.Get("identity_provider_id").(string)
+	includeSystemAccounts := d.Get("include_system_accounts").(bool)
+
+	logDebug("pact_users", d, "listing users")
+
+	res, err := client.ListUsers()
+
+	if err != nil {
+		return fmt.Errorf("error listing users: %w", err)
+	}
+
+	uuids := make([]string, 0)
+	users := make([]interface{}, 0)
+
+	for _, u := range res.Users {
+		if u.Type == broker.SystemAccount && !includeSystemAccounts {
+			continue
+		}
+		if filterActive && u.Active != active.(bool) {
+			continue
+		}
+		if identityProvider != "" && u.IdentityProviderID != identityProvider {
+			continue
+		}
+
+		uuids = append(uuids, u.UUID)
+		users = append(users, map[string]interface{}{
+			"uuid":                 u.UUID,
+			"name":                 u.Name,
+			"email":                u.Email,
+			"active":               u.Active,
+			"type":                 userTypeAsString(u.Type),
+			"identity_provider_id": u.IdentityProviderID,
+			"last_login":           u.LastLogin,
+		})
+	}
+
+	d.SetId(client.Config.BaseURL.Host)
+
+	if err := d.Set("uuids", uuids); err != nil {
+		return fmt.Errorf("error setting key 'uuids': %w", err)
+	}
+	if err := d.Set("users", users); err != nil {
+		return fmt.Errorf("error setting key 'users': %w", err)
+	}
+
+	return nil
+}
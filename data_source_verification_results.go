@@ -0,0 +1,82 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/pactflow/terraform/client"
+)
+
+func verificationResultsDataSource() *schema.Resource {
+	return &schema.Resource{
+		Read: verificationResultsDataSourceRead,
+		Schema: map[string]*schema.Schema{
+			"consumer_name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The name of the consumer",
+			},
+			"provider_name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The name of the provider",
+			},
+			"verified": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the latest pact has been verified",
+			},
+			"success": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the latest verification was successful",
+			},
+			"provider_version": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The provider version that performed the verification",
+			},
+			"build_url": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The URL of the CI build that performed the verification",
+			},
+			"verification_date": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "When the verification was performed",
+			},
+		},
+	}
+}
+
+func verificationResultsDataSourceRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*client.Client)
+	consumer := d.Get("consumer_name").(string)
+	provider := d.Get("provider_name").(string)
+
+	logDebug("pact_verification_results", d, "reading latest verification result", "consumer", consumer, "provider", provider)
+
+	result, err := client.ReadLatestVerificationResult(consumer, provider)
+
+	if err != nil {
+		return fmt.Errorf("error reading verification results for %s and %s: %w", consumer, provider, err)
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s", consumer, provider))
+
+	if result == nil {
+		logDebug("pact_verification_results", d, "the latest pact has not been verified")
+		d.Set("verified", false)
+
+		return nil
+	}
+
+	d.Set("verified", true)
+	d.Set("success", result.Success)
+	d.Set("provider_version", result.ProviderApplicationVersion)
+	d.Set("build_url", result.BuildURL)
+	d.Set("verification_date", result.VerificationDate)
+
+	return nil
+}
@@ -0,0 +1,125 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/pactflow/terraform/broker"
+	"github.com/pactflow/terraform/client"
+)
+
+func versionDeploymentStatusDataSource() *schema.Resource {
+	return &schema.Resource{
+		Read: versionDeploymentStatusDataSourceRead,
+		Schema: map[string]*schema.Schema{
+			"pacticipant": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The name of the pacticipant",
+			},
+			"version": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The pacticipant version number",
+			},
+			"environment": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validateUUID,
+				Description:  "The environment (uuid) to check",
+			},
+			"deployed": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the version is currently deployed to the environment",
+			},
+			"deployed_at": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "When the version was first recorded as deployed, if it is currently deployed",
+			},
+			"application_instances": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The application instances the version is deployed to",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"released": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the version is released and currently supported in the environment",
+			},
+			"released_at": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "When the version was recorded as released, if it is currently supported",
+			},
+		},
+	}
+}
+
+func versionDeploymentStatusDataSourceRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*client.Client)
+	pacticipant := d.Get("pacticipant").(string)
+	version := d.Get("version").(string)
+	environment := d.Get("environment").(string)
+
+	logDebug("pact_version_deployment_status", d, "reading deployment status", "pacticipant", pacticipant, "version", version, "environment", environment)
+
+	deployed, err := client.ListCurrentlyDeployedVersions(environment)
+
+	if err != nil {
+		return fmt.Errorf("error listing currently deployed versions: %w", err)
+	}
+
+	released, err := client.ListCurrentlySupportedReleasedVersions(environment)
+
+	if err != nil {
+		return fmt.Errorf("error listing currently supported released versions: %w", err)
+	}
+
+	// A version may be deployed to several application instances, so report the earliest deployment
+	instances := make([]string, 0)
+	deployedAt := ""
+	deployments := matchingDeployedVersions(deployed.Embedded.DeployedVersions, pacticipant, version)
+	for _, v := range deployments {
+		if v.ApplicationInstance != "" {
+			instances = append(instances, v.ApplicationInstance)
+		}
+		if deployedAt == "" || v.CreatedAt < deployedAt {
+			deployedAt = v.CreatedAt
+		}
+	}
+
+	releasedAt := ""
+	releases := matchingDeployedVersions(released.Embedded.ReleasedVersions, pacticipant, version)
+	if len(releases) > 0 {
+		releasedAt = releases[0].CreatedAt
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s/%s", environment, pacticipant, version))
+	d.Set("deployed", len(deployments) > 0)
+	d.Set("deployed_at", deployedAt)
+	d.Set("released", len(releases) > 0)
+	d.Set("released_at", releasedAt)
+
+	if err := d.Set("application_instances", instances); err != nil {
+		return fmt.Errorf("error setting key 'application_instances': %w", err)
+	}
+
+	return nil
+}
+
+func matchingDeployedVersions(list []broker.DeployedVersion, pacticipant, version string) []broker.DeployedVersion {
+	matches := make([]broker.DeployedVersion, 0)
+
+	for _, v := range list {
+		if v.Embedded.Pacticipant.Name == pacticipant && v.Embedded.Version.Number == version {
+			matches = append(matches, v)
+		}
+	}
+
+	return matches
+}
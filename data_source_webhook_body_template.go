@@ -0,0 +1,304 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/hashcode"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+)
+
+const (
+	slackTemplateTarget         = "slack"
+	msTeamsTemplateTarget       = "ms_teams"
+	githubStatusTemplateTarget  = "github_commit_status"
+	gitlabTriggerTemplateTarget = "gitlab_pipeline_trigger"
+	pagerDutyTemplateTarget     = "pagerduty_event"
+	datadogTemplateTarget       = "datadog_event"
+)
+
+var allowedTemplateTargets = []string{
+	slackTemplateTarget,
+	msTeamsTemplateTarget,
+	githubStatusTemplateTarget,
+	gitlabTriggerTemplateTarget,
+	pagerDutyTemplateTarget,
+	datadogTemplateTarget,
+}
+
+// Severities follow PagerDuty's, and are mapped to the closest Datadog alert type
+var allowedTemplateSeverities = []string{"critical", "error", "warning", "info"}
+
+var datadogAlertTypes = map[string]string{
+	"critical": "error",
+	"error":    "error",
+	"warning":  "warning",
+	"info":     "info",
+}
+
+const defaultTemplateMessage = "Verification of the pact between ${pactbroker.consumerName} version ${pactbroker.consumerVersionNumber} and ${pactbroker.providerName} version ${pactbroker.providerVersionNumber}: ${pactbroker.githubVerificationStatus}. ${pactbroker.verificationResultUrl}"
+
+// webhookTemplate is a rendered webhook request, ready to use in a pact_webhook request block
+type webhookTemplate struct {
+	URL     string
+	Method  string
+	Headers map[string]string
+	Body    interface{}
+}
+
+func webhookBodyTemplateDataSource() *schema.Resource {
+	return &schema.Resource{
+		Read: webhookBodyTemplateDataSourceRead,
+		Schema: map[string]*schema.Schema{
+			"target": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringInSlice(allowedTemplateTargets, false),
+				Description:  "The system the webhook will call: slack, ms_teams, github_commit_status, gitlab_pipeline_trigger, pagerduty_event or datadog_event",
+			},
+			"message": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     defaultTemplateMessage,
+				Description: "The message to post (slack, ms_teams, pagerduty_event and datadog_event only). May contain webhook template parameters such as ${pactbroker.consumerName}",
+			},
+			"incoming_webhook_url": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The incoming webhook URL to post to (slack and ms_teams only)",
+			},
+			"repository": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The GitHub repository (owner/name) or GitLab project (id or url encoded path) to notify",
+			},
+			"api_url": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The API base URL, for GitHub Enterprise, self-hosted GitLab or another Datadog site. Defaults to the public github.com, gitlab.com or datadoghq.com API",
+			},
+			"token_secret": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The name of the Pactflow secret holding the GitHub token, GitLab trigger token, PagerDuty routing key or Datadog API key",
+			},
+			"status_context": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "${pactbroker.providerName}",
+				Description: "The context of the GitHub commit status",
+			},
+			"ref": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "main",
+				Description: "The branch to trigger the GitLab pipeline on",
+			},
+			"severity": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "error",
+				ValidateFunc: validation.StringInSlice(allowedTemplateSeverities, false),
+				Description:  "The severity of the PagerDuty event, or the alert type of the Datadog event (critical, error, warning or info)",
+			},
+			"url": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The URL for the webhook request",
+			},
+			"method": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The HTTP method for the webhook request",
+			},
+			"headers": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The headers for the webhook request",
+			},
+			"body": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The JSON body for the webhook request",
+			},
+		},
+	}
+}
+
+func webhookBodyTemplateDataSourceRead(d *schema.ResourceData, meta interface{}) error {
+	target := d.Get("target").(string)
+
+	template, err := renderWebhookTemplate(target, d)
+
+	if err != nil {
+		return err
+	}
+
+	body, err := json.Marshal(template.Body)
+
+	if err != nil {
+		return fmt.Errorf("error rendering %s webhook body: %w", target, err)
+	}
+
+	d.SetId(fmt.Sprintf("%d", hashcode.String(string(body)+template.URL)))
+	d.Set("url", template.URL)
+	d.Set("method", template.Method)
+	d.Set("body", string(body))
+
+	if err := d.Set("headers", template.Headers); err != nil {
+		return fmt.Errorf("error setting key 'headers': %w", err)
+	}
+
+	return nil
+}
+
+func renderWebhookTemplate(target string, d *schema.ResourceData) (*webhookTemplate, error) {
+	message := d.Get("message").(string)
+	incomingURL := d.Get("incoming_webhook_url").(string)
+	repository := d.Get("repository").(string)
+	apiURL := strings.TrimSuffix(d.Get("api_url").(string), "/")
+	secret := d.Get("token_secret").(string)
+
+	switch target {
+	case slackTemplateTarget:
+		return &webhookTemplate{
+			URL:     incomingURL,
+			Method:  "POST",
+			Headers: map[string]string{"Content-Type": "application/json"},
+			Body: map[string]interface{}{
+				"text": message,
+			},
+		}, nil
+
+	case msTeamsTemplateTarget:
+		return &webhookTemplate{
+			URL:     incomingURL,
+			Method:  "POST",
+			Headers: map[string]string{"Content-Type": "application/json"},
+			Body: map[string]interface{}{
+				"@type":    "MessageCard",
+				"@context": "https://schema.org/extensions",
+				"summary":  "Pact verification result",
+				"text":     message,
+			},
+		}, nil
+
+	case githubStatusTemplateTarget:
+		if repository == "" {
+			return nil, fmt.Errorf("'repository' (owner/name) is required for %s templates", target)
+		}
+		if apiURL == "" {
+			apiURL = "https://api.github.com"
+		}
+
+		headers := map[string]string{
+			"Content-Type": "application/json",
+			"Accept":       "application/vnd.github+json",
+		}
+		if secret != "" {
+			headers["Authorization"] = fmt.Sprintf("Bearer ${user.%s}", secret)
+		}
+
+		return &webhookTemplate{
+			URL:     fmt.Sprintf("%s/repos/%s/statuses/${pactbroker.consumerVersionNumber}", apiURL, repository),
+			Method:  "POST",
+			Headers: headers,
+			Body: map[string]interface{}{
+				"state":       "${pactbroker.githubVerificationStatus}",
+				"description": "Pact verification against ${pactbroker.providerName} ${pactbroker.providerVersionNumber}",
+				"context":     d.Get("status_context").(string),
+				"target_url":  "${pactbroker.verificationResultUrl}",
+			},
+		}, nil
+
+	case gitlabTriggerTemplateTarget:
+		if repository == "" {
+			return nil, fmt.Errorf("'repository' (project id) is required for %s templates", target)
+		}
+		if secret == "" {
+			return nil, fmt.Errorf("'token_secret' is required for %s templates", target)
+		}
+		if apiURL == "" {
+			apiURL = "https://gitlab.com/api/v4"
+		}
+
+		return &webhookTemplate{
+			URL:     fmt.Sprintf("%s/projects/%s/trigger/pipeline", apiURL, repository),
+			Method:  "POST",
+			Headers: map[string]string{"Content-Type": "application/json"},
+			Body: map[string]interface{}{
+				"token": fmt.Sprintf("${user.%s}", secret),
+				"ref":   d.Get("ref").(string),
+				"variables": map[string]string{
+					"PACT_URL":              "${pactbroker.pactUrl}",
+					"PACT_CONSUMER":         "${pactbroker.consumerName}",
+					"PACT_PROVIDER":         "${pactbroker.providerName}",
+					"PACT_CONSUMER_VERSION": "${pactbroker.consumerVersionNumber}",
+					"PACT_CONSUMER_BRANCH":  "${pactbroker.consumerVersionBranch}",
+				},
+			},
+		}, nil
+
+	case pagerDutyTemplateTarget:
+		if secret == "" {
+			return nil, fmt.Errorf("'token_secret' (the routing key) is required for %s templates", target)
+		}
+
+		return &webhookTemplate{
+			URL:     "https://events.pagerduty.com/v2/enqueue",
+			Method:  "POST",
+			Headers: map[string]string{"Content-Type": "application/json"},
+			Body: map[string]interface{}{
+				"routing_key":  fmt.Sprintf("${user.%s}", secret),
+				"event_action": "trigger",
+				"dedup_key":    "pact-${pactbroker.consumerName}-${pactbroker.providerName}-${pactbroker.providerVersionNumber}",
+				"payload": map[string]interface{}{
+					"summary":  message,
+					"source":   "${pactbroker.providerName}",
+					"severity": d.Get("severity").(string),
+					"custom_details": map[string]string{
+						"consumer":         "${pactbroker.consumerName}",
+						"consumer_version": "${pactbroker.consumerVersionNumber}",
+						"provider":         "${pactbroker.providerName}",
+						"provider_version": "${pactbroker.providerVersionNumber}",
+					},
+				},
+				"links": []map[string]string{
+					{"href": "${pactbroker.verificationResultUrl}", "text": "Verification result"},
+				},
+			},
+		}, nil
+
+	case datadogTemplateTarget:
+		if secret == "" {
+			return nil, fmt.Errorf("'token_secret' (the API key) is required for %s templates", target)
+		}
+		if apiURL == "" {
+			apiURL = "https://api.datadoghq.com"
+		}
+
+		return &webhookTemplate{
+			URL:    fmt.Sprintf("%s/api/v1/events", apiURL),
+			Method: "POST",
+			Headers: map[string]string{
+				"Content-Type": "application/json",
+				"DD-API-KEY":   fmt.Sprintf("${user.%s}", secret),
+			},
+			Body: map[string]interface{}{
+				"title":            "Pact verification of ${pactbroker.consumerName} by ${pactbroker.providerName}: ${pactbroker.githubVerificationStatus}",
+				"text":             message,
+				"alert_type":       datadogAlertTypes[d.Get("severity").(string)],
+				"source_type_name": "pact",
+				"tags": []string{
+					"consumer:${pactbroker.consumerName}",
+					"provider:${pactbroker.providerName}",
+				},
+			},
+		}, nil
+	}
+
+	return nil, fmt.Errorf("unsupported webhook template target %q", target)
+}
@@ -0,0 +1,193 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/pactflow/terraform/broker"
+	"github.com/pactflow/terraform/client"
+)
+
+var webhooksItemType = &schema.Resource{
+	Schema: map[string]*schema.Schema{
+		"uuid": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"description": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"consumer_name": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"provider_name": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"team": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"enabled": {
+			Type:     schema.TypeBool,
+			Computed: true,
+		},
+		"url": {
+			Type:      schema.TypeString,
+			Computed:  true,
+			Sensitive: true,
+		},
+		"events": {
+			Type:     schema.TypeList,
+			Computed: true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+	},
+}
+
+func webhooksDataSource() *schema.Resource {
+	return &schema.Resource{
+		Read: webhooksDataSourceRead,
+		Schema: map[string]*schema.Schema{
+			"consumer_name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return webhooks for this consumer",
+			},
+			"provider_name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return webhooks for this provider",
+			},
+			"team": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "Only return webhooks belonging to this team (uuid)",
+				ValidateFunc: validateUUID,
+			},
+			"uuids": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The UUIDs of the matching webhooks",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"webhooks": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The matching webhooks",
+				Elem:        webhooksItemType,
+			},
+		},
+	}
+}
+
+func webhooksDataSourceRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*client.Client)
+	consumer := d.Get("consumer_name").(string)
+	provider := d.Get("provider_name").(string)
+	team := d.Get("team").(string)
+
+	logDebug("pact_webhooks", d, "listing webhooks", "consumer", consumer, "provider", provider, "team", team)
+
+	all, err := readWebhooks(client)
+
+	if err != nil {
+		return err
+	}
+
+	uuids := make([]string, 0)
+	webhooks := make([]interface{}, 0)
+
+	for _, webhook := range all {
+		uuid := webhook.ID
+		consumerName := webhookPacticipantName(webhook.Consumer)
+		providerName := webhookPacticipantName(webhook.Provider)
+
+		if consumer != "" && consumerName != consumer {
+			continue
+		}
+		if provider != "" && providerName != provider {
+			continue
+		}
+		if team != "" && webhook.TeamUUID != team {
+			continue
+		}
+
+		events := make([]string, len(webhook.Events))
+		for i, e := range webhook.Events {
+			events[i] = e.Name
+		}
+
+		uuids = append(uuids, uuid)
+		webhooks = append(webhooks, map[string]interface{}{
+			"uuid":          uuid,
+			"description":   webhook.Description,
+			"consumer_name": consumerName,
+			"provider_name": providerName,
+			"team":          webhook.TeamUUID,
+			"enabled":       webhook.Enabled,
+			"url":           webhook.Request.URL,
+			"events":        events,
+		})
+	}
+
+	d.SetId(client.Config.BaseURL.Host)
+
+	if err := d.Set("uuids", uuids); err != nil {
+		return fmt.Errorf("error setting key 'uuids': %w", err)
+	}
+	if err := d.Set("webhooks", webhooks); err != nil {
+		return fmt.Errorf("error setting key 'webhooks': %w", err)
+	}
+
+	return nil
+}
+
+// readWebhooks returns every webhook in the broker. They are taken from the list when the broker embeds
+// them, otherwise the list only contains links, so each webhook is fetched
+func readWebhooks(c *client.Client) ([]broker.WebhookResponse, error) {
+	res, err := c.ListWebhooks()
+
+	if err != nil {
+		return nil, fmt.Errorf("error listing webhooks: %w", err)
+	}
+
+	if len(res.Embedded.Webhooks) > 0 {
+		webhooks := res.Embedded.Webhooks
+		for i := range webhooks {
+			webhooks[i].ID = idFromSelfLink(webhooks[i].Links["self"].Href)
+		}
+
+		return webhooks, nil
+	}
+
+	webhooks := make([]broker.WebhookResponse, 0, len(res.Links.Webhooks))
+	for _, link := range res.Links.Webhooks {
+		uuid := idFromSelfLink(link.Href)
+
+		webhook, err := c.ReadWebhook(uuid)
+
+		if err != nil {
+			return nil, fmt.Errorf("error reading webhook %q: %w", uuid, err)
+		}
+
+		webhook.ID = uuid
+		webhooks = append(webhooks, *webhook)
+	}
+
+	return webhooks, nil
+}
+
+func webhookPacticipantName(p *broker.Pacticipant) string {
+	if p == nil {
+		return ""
+	}
+
+	return p.Name
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/pactflow/terraform/broker"
+	"github.com/pactflow/terraform/brokertest"
+)
+
+func TestWebhooksDataSource_FetchesLinkedWebhooks(t *testing.T) {
+	server := brokertest.NewServer()
+	defer server.Close()
+
+	server.PutWebhook("1111", broker.Webhook{Description: "Foo", Consumer: &broker.Pacticipant{Name: "Foo"}, Request: broker.Request{URL: "https://ci/foo"}})
+	server.PutWebhook("2222", broker.Webhook{Description: "Bar", Consumer: &broker.Pacticipant{Name: "Bar"}, Request: broker.Request{URL: "https://ci/bar"}})
+
+	meta, err := configureProvider(schema.TestResourceDataRaw(t, Provider().Schema, map[string]interface{}{
+		"host": server.URL,
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	d := schema.TestResourceDataRaw(t, webhooksDataSource().Schema, map[string]interface{}{"consumer_name": "Bar"})
+	if err := webhooksDataSourceRead(d, meta); err != nil {
+		t.Fatal(err)
+	}
+
+	if uuids := d.Get("uuids").([]interface{}); !reflect.DeepEqual(uuids, []interface{}{"2222"}) {
+		t.Fatalf("expected only the webhook for Bar, got %v", uuids)
+	}
+	if url := d.Get("webhooks.0.url"); url != "https://ci/bar" {
+		t.Fatalf("expected the url of the webhook, got %v", url)
+	}
+}
+
+// When the broker embeds the webhooks in the list, they aren't fetched one by one
+func TestWebhooksDataSource_UsesEmbeddedWebhooks(t *testing.T) {
+	var fetched int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/hal+json")
+		switch r.URL.Path {
+		case "/webhooks":
+			fmt.Fprint(w, `{
+				"_links": {"pb:webhooks": [{"href": "http://broker/webhooks/1111"}]},
+				"_embedded": {"webhooks": [{"description": "Foo", "enabled": true, "consumer": {"name": "Foo"}, "events": [{"name": "contract_published"}], "request": {"url": "https://ci/foo"}, "_links": {"self": {"href": "http://broker/webhooks/1111"}}}]}
+			}`)
+		default:
+			fetched++
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	meta, err := configureProvider(schema.TestResourceDataRaw(t, Provider().Schema, map[string]interface{}{
+		"host":                        server.URL,
+		"skip_credentials_validation": true,
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	d := schema.TestResourceDataRaw(t, webhooksDataSource().Schema, map[string]interface{}{})
+	if err := webhooksDataSourceRead(d, meta); err != nil {
+		t.Fatal(err)
+	}
+
+	if fetched != 0 {
+		t.Fatalf("expected the embedded webhooks to be used, but %d were fetched", fetched)
+	}
+	if uuids := d.Get("uuids").([]interface{}); !reflect.DeepEqual(uuids, []interface{}{"1111"}) {
+		t.Fatalf("expected the uuid from the embedded webhook's self link, got %v", uuids)
+	}
+	if events := d.Get("webhooks.0.events").([]interface{}); !reflect.DeepEqual(events, []interface{}{"contract_published"}) {
+		t.Fatalf("expected the embedded webhook's events, got %v", events)
+	}
+}
+
+func TestWebhooksDataSource_URLIsSensitive(t *testing.T) {
+	if !webhooksItemType.Schema["url"].Sensitive {
+		t.Fatal("expected the webhook url, which can contain credentials, to be sensitive")
+	}
+}
@@ -8,4 +8,5 @@ require (
 	github.com/mitchellh/mapstructure v1.1.2
 	github.com/pact-foundation/pact-go/v2 v2.0.0-20210621102432-26b32fd1552a
 	github.com/stretchr/testify v1.7.0
+	github.com/zclconf/go-cty v1.8.2
 )
@@ -1,11 +1,285 @@
 package main
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
 	"sort"
+	"strings"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/pactflow/terraform/client"
 )
 
+const defaultTimeout = 5 * time.Minute
+
+// defaultTimeouts allows each operation's timeout to be configured with a timeouts block
+func defaultTimeouts() *schema.ResourceTimeout {
+	return &schema.ResourceTimeout{
+		Create: schema.DefaultTimeout(defaultTimeout),
+		Read:   schema.DefaultTimeout(defaultTimeout),
+		Update: schema.DefaultTimeout(defaultTimeout),
+		Delete: schema.DefaultTimeout(defaultTimeout),
+	}
+}
+
+// timeoutClient returns a client whose requests fail once the timeout for the operation (e.g.
+// schema.TimeoutCreate) has passed. The returned function releases the deadline and must be called
+func timeoutClient(d *schema.ResourceData, meta interface{}, operation string) (*client.Client, context.CancelFunc) {
+	ctx, cancel := context.WithTimeout(context.Background(), d.Timeout(operation))
+
+	return meta.(*client.Client).WithContext(ctx), cancel
+}
+
+// boundedClient returns a client whose requests fail once defaultTimeout has passed, for broker calls made
+// outside a resource operation (e.g. plan time checks and state upgrades), which have no timeouts of their own
+func boundedClient(c *client.Client) (*client.Client, context.CancelFunc) {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultTimeout)
+
+	return c.WithContext(ctx), cancel
+}
+
+// withDefaultTimeout bounds a data source's Read by defaultTimeout, so that a hung broker fails the plan
+// rather than stalling it. The SDK doesn't pass a timeouts block through to data sources
+func withDefaultTimeout(r *schema.Resource) *schema.Resource {
+	read := r.Read
+	r.Read = func(d *schema.ResourceData, meta interface{}) error {
+		if c, ok := meta.(*client.Client); ok {
+			bounded, cancel := boundedClient(c)
+			defer cancel()
+			meta = bounded
+		}
+
+		return read(d, meta)
+	}
+
+	return r
+}
+
+var deletionProtectionType = &schema.Schema{
+	Type:        schema.TypeBool,
+	Optional:    true,
+	Default:     false,
+	Description: "Prevent the resource from being deleted. Must be set to false (and applied) before the resource can be destroyed",
+}
+
+// importWithDeletionProtection imports by ID like schema.ImportStatePassthrough, and sets deletion_protection
+// to its default. Read can't populate a local attribute, so otherwise config generated for an import block
+// would plan an update straight after the import
+func importWithDeletionProtection(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	if err := d.Set("deletion_protection", false); err != nil {
+		return nil, fmt.Errorf("error setting key 'deletion_protection': %w", err)
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// checkDeletionProtection returns an error if the resource is protected from deletion. The value in
+// state is used, so disabling the protection has to be applied before a destroy will succeed
+func checkDeletionProtection(d *schema.ResourceData, resource string) error {
+	if d.Get("deletion_protection").(bool) {
+		return fmt.Errorf("cannot delete %s %q as deletion_protection is enabled. Set deletion_protection = false and apply before destroying it", resource, d.Id())
+	}
+
+	return nil
+}
+
+// removeFromStateIfNotFound drops a resource from state when reading it returned a 404, as it has
+// been deleted outside of Terraform and should be recreated on the next apply. It returns true if
+// the resource was removed, in which case the Read should return without an error
+func removeFromStateIfNotFound(d *schema.ResourceData, err error) bool {
+	if !isNotFound(err) {
+		return false
+	}
+
+	logWarn("", d, "not found, removing from state")
+	d.SetId("")
+
+	return true
+}
+
+func isNotFound(err error) bool {
+	return errors.Is(err, client.ErrNotFound)
+}
+
+// recreateAfterNotFound handles a resource deleted outside of Terraform between refresh and apply.
+// When create is given the resource is created again as planned. Otherwise (e.g. because state only
+// holds a hash of a value the broker needs) it is removed from state, so the next plan recreates it
+func recreateAfterNotFound(d *schema.ResourceData, meta interface{}, resource string, create schema.CreateFunc) error {
+	id := d.Id()
+	d.SetId("")
+
+	if create == nil {
+		return fmt.Errorf("%s %s was deleted outside of Terraform and has been removed from state, run terraform apply again to recreate it", resource, id)
+	}
+
+	logWarn("", d, "deleted outside of Terraform, creating it again", "kind", resource, "previous_id", id)
+
+	return create(d, meta)
+}
+
+// isConflict reports whether a create failed because the resource already exists, which the broker
+// reports as a 409. Any other error, including a validation error whose message mentions something
+// that already exists, is returned rather than adopting a resource
+func isConflict(err error) bool {
+	return errors.Is(err, client.ErrConflict)
+}
+
+func adoptExistingResources(meta interface{}) bool {
+	return meta.(*client.Client).Config.AdoptExistingResources
+}
+
+// adoptExisting takes over a resource that already exists in the broker: find returns its ID, which is
+// put in state before update applies the configured attributes to it
+func adoptExisting(d *schema.ResourceData, meta interface{}, resource string, find func() (string, error), update schema.UpdateFunc) error {
+	id, err := find()
+	if err != nil {
+		return fmt.Errorf("error finding existing %s to adopt: %w", resource, err)
+	}
+
+	logInfo("", d, "already exists, adopting it into state", "kind", resource, "existing_id", id)
+	d.SetId(id)
+
+	return update(d, meta)
+}
+
+// describeResource names a resource in errors, so that a failure in a large apply points at the resource
+// to fix. Most resources have a name, otherwise the first identifying attribute that is set or the ID is used
+func describeResource(resource string, d *schema.ResourceData) string {
+	for _, key := range []string{"name", "description", "channel", "email", "provider_name"} {
+		if v, ok := d.GetOk(key); ok {
+			return fmt.Sprintf("%s %q", resource, v)
+		}
+	}
+
+	if d.Id() != "" {
+		return fmt.Sprintf("%s %s", resource, d.Id())
+	}
+
+	return resource
+}
+
+// allCustomizeDiffs runs each of funcs in turn, stopping at the first error
+func allCustomizeDiffs(funcs ...schema.CustomizeDiffFunc) schema.CustomizeDiffFunc {
+	return func(d *schema.ResourceDiff, meta interface{}) error {
+		for _, f := range funcs {
+			if err := f(d, meta); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+}
+
+// maxVisibilityWait bounds how long to wait for a newly created resource to become readable
+const maxVisibilityWait = time.Minute
+
+// waitUntilVisible retries read until a newly created resource can be read back, as on some broker
+// deployments it isn't immediately visible to a follow-up GET. Only not found errors are retried, and
+// waiting stops when ctx is done, e.g. the operation timed out or was interrupted
+func waitUntilVisible(ctx context.Context, d *schema.ResourceData, kind string, read func() error) error {
+	timeout := maxVisibilityWait
+	if t := d.Timeout(schema.TimeoutCreate); t < timeout {
+		timeout = t
+	}
+
+	deadline := time.Now().Add(timeout)
+	delay := 250 * time.Millisecond
+
+	err := read()
+	for isNotFound(err) && time.Now().Add(delay).Before(deadline) {
+		logDebug("", d, "not visible yet, retrying", "kind", kind, "delay", delay)
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return fmt.Errorf("error waiting for %s to become readable after creating it: %w", describeResource(kind, d), ctx.Err())
+		}
+		if delay < 5*time.Second {
+			delay *= 2
+		}
+
+		err = read()
+	}
+
+	if err != nil {
+		return fmt.Errorf("error waiting for %s to become readable after creating it: %w", describeResource(kind, d), err)
+	}
+
+	return nil
+}
+
+// localAttributes only change how the provider behaves, so they are never sent to the broker
+var localAttributes = map[string]bool{
+	"deletion_protection": true,
+}
+
+// hasRemoteChanges reports whether any attribute sent to the broker has changed, so that updates which
+// only touch local attributes can skip the API call (and the audit noise it creates). Keys in skip are
+// left for the caller to compare, e.g. where nested values need a semantic comparison
+func hasRemoteChanges(d *schema.ResourceData, s map[string]*schema.Schema, skip ...string) bool {
+	ignored := map[string]bool{}
+	for _, k := range skip {
+		ignored[k] = true
+	}
+
+	for k, v := range s {
+		if localAttributes[k] || ignored[k] || (v.Computed && !v.Optional) {
+			continue
+		}
+		if d.HasChange(k) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// validateUUID catches malformed UUIDs at plan time rather than as a broker error during apply.
+// Empty values are allowed, as optional attributes such as team use them to mean "not set"
+func validateUUID(val interface{}, key string) (warns []string, errs []error) {
+	if v, ok := val.(string); ok && v == "" {
+		return
+	}
+
+	return validation.IsUUID(val, key)
+}
+
+// validateDuration checks a Go duration string such as "30s" or "2m", which must be positive
+func validateDuration(val interface{}, key string) (warns []string, errs []error) {
+	d, err := time.ParseDuration(val.(string))
+	if err != nil {
+		errs = append(errs, fmt.Errorf("%q must be a duration such as 30s or 2m, got: %v", key, err))
+	} else if d <= 0 {
+		errs = append(errs, fmt.Errorf("%q must be a positive duration, got: %s", key, val))
+	}
+	return
+}
+
+// idFromSelfLink returns the ID (UUID) of a resource from its self link, which is the last segment of
+// the path. Query strings, trailing slashes and escaping are ignored, so that the ID is always the same
+// for a given resource. Values that are already an ID are returned as is
+func idFromSelfLink(href string) string {
+	path := href
+	if u, err := url.Parse(href); err == nil {
+		path = u.Path
+	}
+
+	path = strings.TrimRight(path, "/")
+	id := path[strings.LastIndex(path, "/")+1:]
+	if unescaped, err := url.PathUnescape(id); err == nil {
+		id = unescaped
+	}
+
+	return id
+}
+
 func arrayInterfaceToArrayString(raw []interface{}) []string {
 	items := make([]string, len(raw))
 	if len(raw) > 0 {
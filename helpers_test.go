@@ -0,0 +1,40 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/pactflow/terraform/client"
+)
+
+func TestWaitUntilVisible_StopsWhenTheContextIsDone(t *testing.T) {
+	d := application().Data(nil)
+	d.SetId("Foo")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(20*time.Millisecond, cancel)
+
+	started := time.Now()
+	err := waitUntilVisible(ctx, d, "application", func() error {
+		return client.ErrNotFound
+	})
+
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected the wait to be cancelled, got %v", err)
+	}
+	if waited := time.Since(started); waited > time.Second {
+		t.Fatalf("expected the wait to stop when the context was cancelled, waited %s", waited)
+	}
+}
+
+func TestIsConflict(t *testing.T) {
+	if !isConflict(fmt.Errorf("error creating application: %w", client.ErrConflict)) {
+		t.Fatal("expected a 409 to be a conflict")
+	}
+	if isConflict(fmt.Errorf("%w: version 1.0.0 already has a branch", client.ErrBadRequest)) {
+		t.Fatal("expected a validation error to not be a conflict, even when it says something already exists")
+	}
+}
@@ -0,0 +1,108 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"math/big"
+	"strings"
+)
+
+// decodeJSON parses a JSON document, keeping numbers as json.Number so that they are not rounded
+// through a float64 (e.g. large IDs) and are written back exactly as given
+func decodeJSON(s string) (interface{}, error) {
+	decoder := json.NewDecoder(bytes.NewBufferString(s))
+	decoder.UseNumber()
+
+	var i interface{}
+	if err := decoder.Decode(&i); err != nil {
+		return nil, err
+	}
+
+	// Anything after the first value means it wasn't a single JSON document
+	if _, err := decoder.Token(); !errors.Is(err, io.EOF) {
+		return nil, errors.New("unexpected data after the JSON value")
+	}
+
+	return i, nil
+}
+
+// normalizeJSON returns the canonical form of a JSON document: insignificant whitespace removed,
+// object keys sorted (at every level) and numbers written in a single form, so 1, 1.0 and 1e0 are equal.
+// Array order is significant, so is preserved
+func normalizeJSON(s string) (string, error) {
+	i, err := decodeJSON(s)
+	if err != nil {
+		return "", err
+	}
+
+	b, err := json.Marshal(canonicalizeJSONNumbers(i))
+	if err != nil {
+		return "", err
+	}
+
+	return string(b), nil
+}
+
+// jsonEqual reports whether two strings are semantically the same JSON document. Strings that are
+// not valid JSON are never equal
+func jsonEqual(a, b string) bool {
+	normalizedA, err := normalizeJSON(a)
+	if err != nil {
+		return false
+	}
+	normalizedB, err := normalizeJSON(b)
+	if err != nil {
+		return false
+	}
+
+	return normalizedA == normalizedB
+}
+
+// prettyJSON indents a JSON document (with sorted object keys) so that a change to it is shown line by
+// line in a plan, rather than as a single line of escaped JSON. <, > and & are left as they are, rather
+// than escaped as for HTML, so that e.g. a webhook body template reads as written. Anything that isn't
+// JSON is returned as is
+func prettyJSON(s string) string {
+	i, err := decodeJSON(s)
+	if err != nil {
+		return s
+	}
+
+	var b bytes.Buffer
+	encoder := json.NewEncoder(&b)
+	encoder.SetIndent("", "  ")
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(i); err != nil {
+		return s
+	}
+
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// prettyJSONStateFunc is a StateFunc storing JSON attributes pretty printed
+func prettyJSONStateFunc(v interface{}) string {
+	s, _ := v.(string)
+	return prettyJSON(s)
+}
+
+func canonicalizeJSONNumbers(i interface{}) interface{} {
+	switch v := i.(type) {
+	case map[string]interface{}:
+		for k, item := range v {
+			v[k] = canonicalizeJSONNumbers(item)
+		}
+	case []interface{}:
+		for k, item := range v {
+			v[k] = canonicalizeJSONNumbers(item)
+		}
+	case json.Number:
+		f, _, err := big.ParseFloat(string(v), 10, 256, big.ToNearestEven)
+		if err == nil {
+			return json.Number(f.Text('g', -1))
+		}
+	}
+
+	return i
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"testing"
+)
+
+func TestJSONEqual(t *testing.T) {
+	cases := []struct {
+		name  string
+		a, b  string
+		equal bool
+	}{
+		{"whitespace", `{"a": 1}`, "{\n  \"a\":1\n}", true},
+		{"key order", `{"a": 1, "b": {"c": 2, "d": 3}}`, `{"b": {"d": 3, "c": 2}, "a": 1}`, true},
+		{"top level arrays", `[{"a": 1}, 2]`, `[ {"a":1}, 2 ]`, true},
+		{"array order", `[1, 2]`, `[2, 1]`, false},
+		{"number formatting", `{"a": 1, "b": 100}`, `{"a": 1.0, "b": 1e2}`, true},
+		{"large integers", `{"id": 12345678901234567891}`, `{"id": 12345678901234567890}`, false},
+		{"different values", `{"a": 1}`, `{"a": 2}`, false},
+		{"not JSON", `hello`, `hello`, false},
+		{"trailing data", `{"a": 1} {"b": 2}`, `{"a": 1}`, false},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if actual := jsonEqual(c.a, c.b); actual != c.equal {
+				t.Fatalf("expected jsonEqual(%s, %s) to be %v", c.a, c.b, c.equal)
+			}
+		})
+	}
+}
+
+func TestPrettyJSON(t *testing.T) {
+	expected := "{\n  \"a\": 1.0,\n  \"b\": [\n    \"c\"\n  ]\n}"
+
+	if actual := prettyJSON(`{"b":["c"],"a":1.0}`); actual != expected {
+		t.Fatalf("expected %q, got %q", expected, actual)
+	}
+	if actual := prettyJSON(`{"query":"a < b && b > c"}`); actual != "{\n  \"query\": \"a < b && b > c\"\n}" {
+		t.Fatalf("expected <, > and & not to be escaped, got %q", actual)
+	}
+	if actual := prettyJSON("not json"); actual != "not json" {
+		t.Fatalf("expected non JSON to be unchanged, got %q", actual)
+	}
+}
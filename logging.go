@@ -0,0 +1,40 @@
+package main
+
+import (
+	"github.com/pactflow/terraform/client"
+)
+
+// identified is anything with a Terraform ID, i.e. *schema.ResourceData and *schema.ResourceDiff
+type identified interface {
+	Id() string
+}
+
+// logDebug writes a structured log entry tagged with the resource type (e.g. pact_webhook) and, once it
+// has one, the ID of d. The fields are key/value pairs, and sensitive values are masked
+func logDebug(resource string, d identified, msg string, fields ...interface{}) {
+	logResource("DEBUG", resource, d, msg, fields)
+}
+
+func logInfo(resource string, d identified, msg string, fields ...interface{}) {
+	logResource("INFO", resource, d, msg, fields)
+}
+
+func logWarn(resource string, d identified, msg string, fields ...interface{}) {
+	logResource("WARN", resource, d, msg, fields)
+}
+
+func logError(resource string, d identified, msg string, fields ...interface{}) {
+	logResource("ERROR", resource, d, msg, fields)
+}
+
+func logResource(level, resource string, d identified, msg string, fields []interface{}) {
+	tags := make([]interface{}, 0, 4+len(fields))
+	if resource != "" {
+		tags = append(tags, "resource", resource)
+	}
+	if d != nil && d.Id() != "" {
+		tags = append(tags, "id", d.Id())
+	}
+
+	client.LogEntry(level, msg, append(tags, fields...)...)
+}
@@ -0,0 +1,118 @@
+package main
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/pactflow/terraform/broker"
+)
+
+// Formats the matrix results can be written to output_file in
+const (
+	jsonMatrixOutputFormat  = "json"
+	junitMatrixOutputFormat = "junit"
+)
+
+var allowedMatrixOutputFormats = []string{
+	jsonMatrixOutputFormat,
+	junitMatrixOutputFormat,
+}
+
+type junitTestSuites struct {
+	XMLName xml.Name         `xml:"testsuites"`
+	Suites  []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name     string          `xml:"name,attr"`
+	Tests    int             `xml:"tests,attr"`
+	Failures int             `xml:"failures,attr"`
+	Skipped  int             `xml:"skipped,attr"`
+	Cases    []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Failure   *junitMessage `xml:"failure,omitempty"`
+	Skipped   *junitMessage `xml:"skipped,omitempty"`
+}
+
+type junitMessage struct {
+	Message string `xml:"message,attr"`
+}
+
+// writeMatrixOutput writes the full matrix response to a file, for later pipeline steps to consume
+func writeMatrixOutput(path, format string, res *broker.MatrixResponse) error {
+	var out []byte
+	var err error
+
+	switch format {
+	case junitMatrixOutputFormat:
+		out, err = matrixJUnit(res)
+	default:
+		out, err = json.MarshalIndent(res, "", "  ")
+	}
+	if err != nil {
+		return fmt.Errorf("error formatting matrix results as %s: %w", format, err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("error creating the directory for %s: %w", path, err)
+	}
+
+	if err := ioutil.WriteFile(path, append(out, '\n'), 0644); err != nil {
+		return fmt.Errorf("error writing matrix results to %s: %w", path, err)
+	}
+
+	return nil
+}
+
+// matrixJUnit reports each row as a test case, so CI systems can show which integration blocks a deployment.
+// Unverified rows are reported as skipped
+func matrixJUnit(res *broker.MatrixResponse) ([]byte, error) {
+	suite := junitTestSuite{
+		Name:  "can-i-deploy",
+		Tests: len(res.Matrix),
+		Cases: []junitTestCase{},
+	}
+
+	for _, r := range res.Matrix {
+		c := junitTestCase{
+			Name:      fmt.Sprintf("%s (%s) and %s (%s)", r.Consumer.Name, r.Consumer.Version.Number, r.Provider.Name, r.Provider.Version.Number),
+			ClassName: fmt.Sprintf("%s.%s", r.Consumer.Name, r.Provider.Name),
+		}
+
+		switch {
+		case r.VerificationResult == nil:
+			c.Skipped = &junitMessage{Message: "the pact has not been verified"}
+			suite.Skipped++
+		case !r.VerificationResult.Success:
+			c.Failure = &junitMessage{Message: fmt.Sprintf("verification failed at %s", r.VerificationResult.VerifiedAt)}
+			suite.Failures++
+		}
+
+		suite.Cases = append(suite.Cases, c)
+	}
+
+	if res.Summary.Deployable != nil && !*res.Summary.Deployable {
+		suite.Tests++
+		suite.Failures++
+		suite.Cases = append(suite.Cases, junitTestCase{
+			Name:      "deployable",
+			ClassName: "can-i-deploy",
+			Failure:   &junitMessage{Message: res.Summary.Reason},
+		})
+	}
+
+	out, err := xml.MarshalIndent(junitTestSuites{Suites: []junitTestSuite{suite}}, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	return append([]byte(xml.Header), out...), nil
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pactflow/terraform/broker"
+)
+
+func TestWriteMatrixOutput(t *testing.T) {
+	deployable := false
+	res := &broker.MatrixResponse{
+		Summary: broker.MatrixSummary{Deployable: &deployable, Reason: "One or more verifications have failed", Failed: 1, Unknown: 1},
+		Matrix: []broker.MatrixRow{
+			{
+				Consumer:           broker.MatrixPacticipant{Name: "Foo", Version: broker.MatrixVersion{Number: "1.2.3"}},
+				Provider:           broker.MatrixPacticipant{Name: "Bar", Version: broker.MatrixVersion{Number: "4.5.6"}},
+				VerificationResult: &broker.MatrixVerificationResult{Success: false, VerifiedAt: "2023-03-17T01:15:10+00:00"},
+			},
+			{
+				Consumer: broker.MatrixPacticipant{Name: "Foo", Version: broker.MatrixVersion{Number: "1.2.3"}},
+				Provider: broker.MatrixPacticipant{Name: "Baz", Version: broker.MatrixVersion{Number: "7.8.9"}},
+			},
+		},
+	}
+
+	for format, expected := range map[string][]string{
+		jsonMatrixOutputFormat:  {`"deployable": false`, `"name": "Baz"`},
+		junitMatrixOutputFormat: {`tests="3" failures="2" skipped="1"`, `name="Foo (1.2.3) and Bar (4.5.6)"`, `message="One or more verifications have failed"`},
+	} {
+		path := filepath.Join(t.TempDir(), "results", "matrix."+format)
+		if err := writeMatrixOutput(path, format, res); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		out, err := ioutil.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, e := range expected {
+			if !strings.Contains(string(out), e) {
+				t.Fatalf("expected the %s output to contain %s, got %s", format, e, out)
+			}
+		}
+	}
+}
@@ -0,0 +1,201 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/pactflow/terraform/client"
+)
+
+// pacticipantNames are the names of the pacticipants a provider instance knows about, so webhooks can be
+// checked against them at plan time. They are kept per client, as each provider configuration (e.g. an
+// aliased provider for a second broker) has its own client and its own pacticipants
+type pacticipantNames struct {
+	mu sync.Mutex
+	// declared are the pacticipants declared in the configuration being planned, which may not exist in
+	// the broker yet. Terraform doesn't guarantee the order resources are planned in, so this is best effort
+	declared map[string]bool
+
+	// listed are the pacticipants in the broker, listed once rather than for every webhook being planned
+	listOnce sync.Once
+	listed   []string
+}
+
+var pacticipantNamesByClient = struct {
+	sync.Mutex
+	clients map[*client.Client]*pacticipantNames
+}{clients: make(map[*client.Client]*pacticipantNames)}
+
+func pacticipantNamesFor(c *client.Client) *pacticipantNames {
+	pacticipantNamesByClient.Lock()
+	defer pacticipantNamesByClient.Unlock()
+
+	names, ok := pacticipantNamesByClient.clients[c]
+	if !ok {
+		names = &pacticipantNames{declared: make(map[string]bool)}
+		pacticipantNamesByClient.clients[c] = names
+	}
+
+	return names
+}
+
+func (n *pacticipantNames) declare(name string) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	n.declared[name] = true
+}
+
+func (n *pacticipantNames) declaredNames() []string {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	names := make([]string, 0, len(n.declared))
+	for name := range n.declared {
+		names = append(names, name)
+	}
+
+	return names
+}
+
+func (n *pacticipantNames) listedNames(c *client.Client) []string {
+	n.listOnce.Do(func() {
+		bounded, cancel := boundedClient(c)
+		defer cancel()
+
+		n.listed = listPacticipantNames(bounded)
+	})
+
+	return n.listed
+}
+
+// pacticipantLocks serialises the requests that can create a pacticipant, keyed by (case insensitive)
+// name. Webhooks implicitly create the pacticipants they are scoped to, and the broker can end up with
+// duplicates or return a 409 when the same pacticipant is created by concurrent requests
+var pacticipantLocks = struct {
+	sync.Mutex
+	locks map[string]*sync.Mutex
+}{locks: make(map[string]*sync.Mutex)}
+
+// lockPacticipants locks each of the named pacticipants until the returned function is first called. Locks
+// are always taken in the same order so that resources locking more than one pacticipant can't deadlock.
+// The locks aren't reentrant, so release them before calling another function that takes them
+func lockPacticipants(names ...string) func() {
+	keys := make([]string, 0, len(names))
+	seen := make(map[string]bool)
+	for _, name := range names {
+		key := strings.ToLower(name)
+		if name != "" && !seen[key] {
+			seen[key] = true
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	held := make([]*sync.Mutex, 0, len(keys))
+	for _, key := range keys {
+		pacticipantLocks.Lock()
+		lock, ok := pacticipantLocks.locks[key]
+		if !ok {
+			lock = &sync.Mutex{}
+			pacticipantLocks.locks[key] = lock
+		}
+		pacticipantLocks.Unlock()
+
+		lock.Lock()
+		held = append(held, lock)
+	}
+
+	var once sync.Once
+
+	return func() {
+		once.Do(func() {
+			for i := len(held) - 1; i >= 0; i-- {
+				held[i].Unlock()
+			}
+		})
+	}
+}
+
+// findPacticipantCaseMismatch returns a known name that differs from name only by case
+func findPacticipantCaseMismatch(name string, known []string) (string, bool) {
+	for _, k := range known {
+		if k == name {
+			return "", false
+		}
+	}
+	for _, k := range known {
+		if strings.EqualFold(k, name) {
+			return k, true
+		}
+	}
+
+	return "", false
+}
+
+func applicationCustomizeDiff(d *schema.ResourceDiff, meta interface{}) error {
+	if d.NewValueKnown("name") {
+		pacticipantNamesFor(meta.(*client.Client)).declare(d.Get("name").(string))
+	}
+
+	return nil
+}
+
+// The most common webhook misconfiguration is a consumer or provider name that differs in case from
+// the pacticipant, which the broker treats as a different pacticipant. Catch it at plan time. A name that
+// exactly matches any known pacticipant is fine. A mismatch with a pacticipant in the broker fails the plan,
+// but one with a pacticipant only declared in the configuration is a warning, as the declared names depend
+// on the order resources happen to be planned in
+func webhookCustomizeDiff(d *schema.ResourceDiff, meta interface{}) error {
+	c := meta.(*client.Client)
+	names := pacticipantNamesFor(c)
+
+	for _, key := range []string{"webhook_consumer", "webhook_provider"} {
+		if !d.NewValueKnown(key) || (d.Id() != "" && !d.HasChange(key)) {
+			continue
+		}
+
+		name, _ := d.Get(key).(map[string]interface{})["name"].(string)
+		if name == "" {
+			continue
+		}
+
+		declared, listed := names.declaredNames(), names.listedNames(c)
+		if _, ok := findPacticipantCaseMismatch(name, append(declared, listed...)); !ok {
+			continue
+		}
+
+		if match, ok := findPacticipantCaseMismatch(name, listed); ok {
+			return pacticipantCaseMismatchError(key, name, match)
+		}
+		if match, ok := findPacticipantCaseMismatch(name, declared); ok {
+			logWarn("pact_webhook", d, pacticipantCaseMismatchError(key, name, match).Error())
+		}
+	}
+
+	return nil
+}
+
+// The check is advisory, so a failure to list the pacticipants shouldn't fail the plan
+func listPacticipantNames(c *client.Client) []string {
+	names := make([]string, 0)
+
+	res, err := c.ListPacticipants()
+	if err != nil {
+		logWarn("", nil, "unable to list pacticipants to validate webhook names", "error", err)
+		return names
+	}
+
+	for _, p := range res.Embedded.Pacticipants {
+		names = append(names, p.Name)
+	}
+
+	return names
+}
+
+func pacticipantCaseMismatchError(key, name, match string) error {
+	return fmt.Errorf("%s name %q does not match the pacticipant %q, names are case sensitive. Reference the pacticipant resource instead of repeating the name e.g. %s = { name = pact_pacticipant.<resource name>.name }", key, name, match, key)
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/pactflow/terraform/broker"
+	"github.com/pactflow/terraform/brokertest"
+	"github.com/pactflow/terraform/client"
+)
+
+func TestFindPacticipantCaseMismatch(t *testing.T) {
+	known := []string{"AdminService", "adminservice", "OrdersAPI"}
+
+	if _, ok := findPacticipantCaseMismatch("AdminService", known); ok {
+		t.Fatal("expected an exact match not to be reported")
+	}
+	if _, ok := findPacticipantCaseMismatch("PaymentsAPI", known); ok {
+		t.Fatal("expected an unknown name not to be reported")
+	}
+	if match, ok := findPacticipantCaseMismatch("ordersapi", known); !ok || match != "OrdersAPI" {
+		t.Fatalf("expected ordersapi to be reported as a mismatch of OrdersAPI, got %q", match)
+	}
+}
+
+func TestPacticipantNames(t *testing.T) {
+	configure := func(server *brokertest.Server) *client.Client {
+		meta, err := configureProvider(schema.TestResourceDataRaw(t, Provider().Schema, map[string]interface{}{
+			"host": server.URL,
+		}))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return meta.(*client.Client)
+	}
+
+	first, second := brokertest.NewServer(), brokertest.NewServer()
+	defer first.Close()
+	defer second.Close()
+	first.PutPacticipant(broker.Pacticipant{Name: "Bar"})
+
+	c1, c2 := configure(first), configure(second)
+	pacticipantNamesFor(c1).declare("Foo")
+
+	if declared := pacticipantNamesFor(c2).declaredNames(); len(declared) != 0 {
+		t.Fatalf("expected another provider instance not to see the declared pacticipants, got %v", declared)
+	}
+	if declared := pacticipantNamesFor(c1).declaredNames(); len(declared) != 1 || declared[0] != "Foo" {
+		t.Fatalf("expected the declared pacticipant, got %v", declared)
+	}
+
+	if listed := pacticipantNamesFor(c1).listedNames(c1); len(listed) != 1 || listed[0] != "Bar" {
+		t.Fatalf("expected the pacticipants in the broker, got %v", listed)
+	}
+
+	first.PutPacticipant(broker.Pacticipant{Name: "Baz"})
+	if listed := pacticipantNamesFor(c1).listedNames(c1); len(listed) != 1 {
+		t.Fatalf("expected the pacticipants to be listed only once, got %v", listed)
+	}
+}
+
+func TestLockPacticipants(t *testing.T) {
+	unlock := lockPacticipants("Foo", "Bar", "")
+
+	locked := make(chan bool)
+	go func() {
+		defer lockPacticipants("foo")()
+		locked <- true
+	}()
+
+	select {
+	case <-locked:
+		t.Fatal("expected the pacticipant to stay locked until it is unlocked")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	unlock()
+	unlock()
+
+	select {
+	case <-locked:
+	case <-time.After(time.Second):
+		t.Fatal("expected the pacticipant to be unlocked")
+	}
+}
@@ -1,49 +1,154 @@
 package main
 
 import (
+	"bytes"
+	"context"
 	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
 	"net/url"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/pactflow/terraform/broker"
 	"github.com/pactflow/terraform/client"
 )
 
+// connectionCheckTimeout limits how long configuring the provider waits for the broker to respond
+const connectionCheckTimeout = time.Minute
+
+// credentialsCommandTimeout limits how long configuring the provider waits for credentials_command
+const credentialsCommandTimeout = time.Minute
+
 func Provider() *schema.Provider {
-	return &schema.Provider{
+	provider := &schema.Provider{
 		ResourcesMap: map[string]*schema.Resource{
-			"pact_role":           role(),
-			"pact_role_v1":        roleV1(),
-			"pact_team":           team(),
-			"pact_user":           user(),
-			"pact_application":    application(),
-			"pact_pacticipant":    application(),
-			"pact_webhook":        webhook(),
-			"pact_secret":         secret(),
-			"pact_token":          token(),
-			"pact_authentication": authentication(),
-			"pact_environment":    environment(),
+			"pact_role":                  pactflowOnly("pact_role", role()),
+			"pact_role_v1":               pactflowOnly("pact_role_v1", roleV1()),
+			"pact_team":                  pactflowOnly("pact_team", team()),
+			"pact_user":                  pactflowOnly("pact_user", user()),
+			"pact_application":           application(),
+			"pact_pacticipant":           application(),
+			"pact_pacticipant_version":   pacticipantVersion(),
+			"pact_webhook":               webhook(),
+			"pact_secret":                pactflowOnly("pact_secret", secret()),
+			"pact_token":                 pactflowOnly("pact_token", token()),
+			"pact_authentication":        pactflowOnly("pact_authentication", authentication()),
+			"pact_environment":           environment(),
+			"pact_notification_settings": pactflowOnly("pact_notification_settings", notificationSettings()),
+			"pact_provider_contract":     pactflowOnly("pact_provider_contract", providerContract()),
+			"pact_badge_settings":        pactflowOnly("pact_badge_settings", badgeSettings()),
+			"pact_chat_integration":      pactflowOnly("pact_chat_integration", chatIntegration()),
+			"pact_announcement":          pactflowOnly("pact_announcement", announcement()),
+		},
+		DataSourcesMap: map[string]*schema.Resource{
+			"pact_pacticipant":                 pacticipantDataSource(),
+			"pact_pacticipants":                pacticipantsDataSource(),
+			"pact_environment":                 environmentDataSource(),
+			"pact_environments":                environmentsDataSource(),
+			"pact_team":                        pactflowOnly("pact_team", teamDataSource()),
+			"pact_teams":                       pactflowOnly("pact_teams", teamsDataSource()),
+			"pact_user":                        pactflowOnly("pact_user", userDataSource()),
+			"pact_users":                       pactflowOnly("pact_users", usersDataSource()),
+			"pact_role":                        pactflowOnly("pact_role", roleDataSource()),
+			"pact_webhooks":                    webhooksDataSource(),
+			"pact_secret":                      pactflowOnly("pact_secret", secretDataSource()),
+			"pact_latest_pacticipant_version":  latestPacticipantVersionDataSource(),
+			"pact_matrix":                      matrixDataSource(),
+			"pact_can_i_deploy_summary":        canIDeploySummaryDataSource(),
+			"pact_currently_deployed_versions": currentlyDeployedVersionsDataSource(),
+			"pact_currently_supported_released_versions": currentlySupportedReleasedVersionsDataSource(),
+			"pact_verification_results":                  verificationResultsDataSource(),
+			"pact_webhook_body_template":                 webhookBodyTemplateDataSource(),
+			"pact_badge_url":                             badgeURLDataSource(),
+			"pact_branches":                              branchesDataSource(),
+			"pact_tags":                                  tagsDataSource(),
+			"pact_pacts_for_verification":                pactsForVerificationDataSource(),
+			"pact_system_account":                        pactflowOnly("pact_system_account", systemAccountDataSource()),
+			"pact_api_tokens":                            pactflowOnly("pact_api_tokens", apiTokensDataSource()),
+			"pact_authentication_settings":               pactflowOnly("pact_authentication_settings", authenticationSettingsDataSource()),
+			"pact_audit_events":                          pactflowOnly("pact_audit_events", auditEventsDataSource()),
+			"pact_environment_contacts":                  environmentContactsDataSource(),
+			"pact_provider_states":                       providerStatesDataSource(),
+			"pact_integrations":                          integrationsDataSource(),
+			"pact_labels":                                labelsDataSource(),
+			"pact_default_roles":                         pactflowOnly("pact_default_roles", defaultRolesDataSource()),
+			"pact_pact_versions":                         pactVersionsDataSource(),
+			"pact_version_deployment_status":             versionDeploymentStatusDataSource(),
 		},
 		ConfigureFunc: configureProvider,
 		Schema: map[string]*schema.Schema{
 			"access_token": {
 				Type:        schema.TypeString,
 				Optional:    true,
-				Description: "An API Bearer token to authenticate to a Pactflow account (for Pactflow users only)",
+				Sensitive:   true,
+				DefaultFunc: schema.EnvDefaultFunc("PACT_BROKER_TOKEN", nil),
+				Description: "An API Bearer token to authenticate to a Pactflow account (for Pactflow users only). Defaults to the PACT_BROKER_TOKEN environment variable",
+			},
+			"token_file": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The path to a file containing the API Bearer token (e.g. a mounted Kubernetes secret), read when the provider is configured. Surrounding whitespace is ignored",
+			},
+			"credentials_command": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MinItems: 1,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Description: "A command (the program followed by its arguments) that prints the API Bearer token as JSON, e.g. {\"token\": \"...\"}. It is run when the provider is configured, so short-lived tokens can be issued by a credential helper",
 			},
 			"basic_auth_username": {
 				Type:        schema.TypeString,
 				Optional:    true,
-				Description: "A basic auth username to authenticate to a Pact Broker (not required for Pactflow users)",
+				DefaultFunc: schema.EnvDefaultFunc("PACT_BROKER_USERNAME", nil),
+				Description: "A basic auth username to authenticate to a Pact Broker (not required for Pactflow users). Defaults to the PACT_BROKER_USERNAME environment variable",
 			},
 			"basic_auth_password": {
 				Type:        schema.TypeString,
 				Optional:    true,
-				Description: "A basic auth password to authenticate to a Pact Broker (not required for Pactflow users)",
+				Sensitive:   true,
+				DefaultFunc: schema.EnvDefaultFunc("PACT_BROKER_PASSWORD", nil),
+				Description: "A basic auth password to authenticate to a Pact Broker (not required for Pactflow users). Defaults to the PACT_BROKER_PASSWORD environment variable",
 			},
 			"host": {
-				Type:        schema.TypeString,
-				Required:    true,
-				Description: "A fully qualified hostname (e.g. for a Pactflow account https://mybroker.pact.dius.com.au",
+				Type:         schema.TypeString,
+				Required:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("PACT_BROKER_BASE_URL", nil),
+				ValidateFunc: validation.NoZeroValues,
+				Description:  "A fully qualified hostname (e.g. for a Pactflow account https://mybroker.pact.dius.com.au). Defaults to the PACT_BROKER_BASE_URL environment variable",
+			},
+			"adopt_existing_resources": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "When creating a resource that already exists in the broker, adopt the existing resource into state and update it to match the configuration instead of failing",
+			},
+			"dry_run": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Log the requests that would change the broker instead of sending them. Reads are still made, and each change fails with a description of the request",
+			},
+			"require_https_webhooks": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Fail the plan for any webhook whose URL doesn't use HTTPS",
+			},
+			"broker_type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice(allowedBrokerTypes, false),
+				Description:  "The type of broker the provider talks to: pactflow or oss. Resources and data sources only PactFlow supports fail to plan against an oss broker",
 			},
 			"tls_insecure": {
 				Type:        schema.TypeBool,
@@ -51,19 +156,322 @@ func Provider() *schema.Provider {
 				Default:     false,
 				Description: "Disable TLS verification checks for privately hosted brokers",
 			},
+			"request_timeout": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateDuration,
+				Description:  "How long each request to the broker may take, as a duration such as 30s or 2m. Defaults to no limit other than the resource's operation timeout",
+			},
+			"max_retries": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      3,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "How many times to retry a request when the broker responds with a 429, or with a 502, 503 or 504 for a request that is safe to repeat. Creates (POST) are only retried on a 429, or a 503 with a Retry-After header, so they are never duplicated. Set to 0 to disable retries",
+			},
+			"retry_min_wait": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "1s",
+				ValidateFunc: validateDuration,
+				Description:  "How long to wait before the first retry. The wait doubles with each retry",
+			},
+			"retry_max_wait": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "30s",
+				ValidateFunc: validateDuration,
+				Description:  "The longest to wait between retries, including when a Retry-After header asks for longer",
+			},
+			"user_agent_suffix": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Appended to the User-Agent (terraform-provider-pact/<version>) sent with every request, e.g. to identify the pipeline in the broker's logs",
+			},
+			"skip_credentials_validation": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Don't check that the broker can be reached with the configured credentials when the provider is configured",
+			},
+			"default_headers": {
+				Type:         schema.TypeMap,
+				Optional:     true,
+				Elem:         &schema.Schema{Type: schema.TypeString},
+				ValidateFunc: validateHeaders,
+				Description:  "Headers to send with every request to the broker, e.g. routing headers required by an API gateway. They can't replace the headers the provider sets itself, such as Authorization",
+			},
+			"proxy_url": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateURL,
+				Description:  "The URL of a proxy to send requests to the broker through (e.g. http://proxy.example.com:3128). Defaults to the HTTPS_PROXY and HTTP_PROXY environment variables",
+			},
+			"ca_cert_file": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"ca_cert_pem"},
+				Description:   "The path to a PEM encoded CA bundle to trust, in addition to the system roots, for brokers behind an internal CA",
+			},
+			"ca_cert_pem": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"ca_cert_file"},
+				Description:   "A PEM encoded CA bundle to trust, in addition to the system roots, for brokers behind an internal CA",
+			},
+			"client_cert_file": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				RequiredWith: []string{"client_key_file"},
+				Description:  "The path to a PEM encoded client certificate, for brokers that require mutual TLS",
+			},
+			"client_key_file": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				RequiredWith: []string{"client_cert_file"},
+				Description:  "The path to the PEM encoded private key of the client certificate",
+			},
 		},
 	}
+
+	for _, dataSource := range provider.DataSourcesMap {
+		withDefaultTimeout(dataSource)
+	}
+
+	return provider
 }
 
 func configureProvider(d *schema.ResourceData) (interface{}, error) {
-	baseURL, err := url.Parse(d.Get("host").(string))
-	return client.NewClient(nil, client.Config{
-		AccessToken:       d.Get("access_token").(string),
-		BasicAuthUsername: d.Get("basic_auth_username").(string),
-		BasicAuthPassword: d.Get("basic_auth_password").(string),
-		CustomTLSConfig: &tls.Config{
-			InsecureSkipVerify: d.Get("tls_insecure").(bool),
-		},
-		BaseURL: baseURL,
-	}), err
+	// An empty host fails validation, so here it means the host depends on values that are only known
+	// after apply (e.g. another resource's output). Configure without it so that planning can continue,
+	// any request to the broker before then fails with client.ErrNotConfigured
+	var baseURL *url.URL
+	if host := d.Get("host").(string); host != "" {
+		var err error
+		if baseURL, err = url.Parse(host); err != nil {
+			return nil, fmt.Errorf("error parsing host: %w", err)
+		}
+	} else {
+		logDebug("", d, "the provider host is not known yet, requests to the broker will fail until apply")
+	}
+
+	accessToken, err := resolveAccessToken(d)
+	if err != nil {
+		return nil, err
+	}
+
+	username := d.Get("basic_auth_username").(string)
+	password := d.Get("basic_auth_password").(string)
+	if err := validateCredentials(accessToken, username, password); err != nil {
+		return nil, err
+	}
+
+	customTLSConfig, tlsErr := tlsConfig(d)
+	if tlsErr != nil {
+		return nil, tlsErr
+	}
+
+	var proxyURL *url.URL
+	if proxy := d.Get("proxy_url").(string); proxy != "" {
+		var proxyErr error
+		if proxyURL, proxyErr = url.Parse(proxy); proxyErr != nil {
+			return nil, fmt.Errorf("error parsing proxy_url: %w", proxyErr)
+		}
+	}
+
+	// Durations are already validated by the schema
+	var requestTimeout time.Duration
+	if timeout := d.Get("request_timeout").(string); timeout != "" {
+		requestTimeout, _ = time.ParseDuration(timeout)
+	}
+	retryMinWait, _ := time.ParseDuration(d.Get("retry_min_wait").(string))
+	retryMaxWait, _ := time.ParseDuration(d.Get("retry_max_wait").(string))
+
+	defaultHeaders := make(map[string]string)
+	for name, value := range d.Get("default_headers").(map[string]interface{}) {
+		defaultHeaders[name] = value.(string)
+	}
+
+	c := client.NewClient(nil, client.Config{
+		AccessToken:            accessToken,
+		BasicAuthUsername:      username,
+		BasicAuthPassword:      password,
+		CustomTLSConfig:        customTLSConfig,
+		ProxyURL:               proxyURL,
+		RequestTimeout:         requestTimeout,
+		MaxRetries:             d.Get("max_retries").(int),
+		RetryMinWait:           retryMinWait,
+		RetryMaxWait:           retryMaxWait,
+		UserAgentSuffix:        d.Get("user_agent_suffix").(string),
+		DefaultHeaders:         defaultHeaders,
+		BaseURL:                baseURL,
+		DryRun:                 d.Get("dry_run").(bool),
+		AdoptExistingResources: d.Get("adopt_existing_resources").(bool),
+		RequireHTTPSWebhooks:   d.Get("require_https_webhooks").(bool),
+		BrokerType:             d.Get("broker_type").(string),
+	})
+
+	// Like the host, credentials may come from values that aren't known until apply, which Terraform passes
+	// as empty. A broker that needs credentials rejects the request without them, so the credentials are
+	// only validated once there are some. The index is still read for a broker that allows public reads
+	hasCredentials := accessToken != "" || username != ""
+
+	if baseURL != nil && !d.Get("skip_credentials_validation").(bool) {
+		index, err := checkConnection(c)
+		switch {
+		case err == nil:
+			detectBrokerType(c, index)
+			c.UseIndex(index)
+		case hasCredentials:
+			return nil, err
+		default:
+			logWarn("", d, "unable to read the broker's API index without credentials, so the broker type isn't detected and the provider uses its own paths", "error", err)
+		}
+	}
+
+	return c, nil
+}
+
+// checkConnection reads the API index, so that a wrong host or credentials fail when the provider is
+// configured rather than with a less obvious error from whichever resource happens to be read first
+func checkConnection(c *client.Client) (*broker.Index, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), connectionCheckTimeout)
+	defer cancel()
+
+	index, err := c.WithContext(ctx).ReadIndex()
+
+	switch {
+	case err == nil:
+		return index, nil
+	case errors.Is(err, client.ErrUnauthorized), errors.Is(err, client.ErrForbidden):
+		return nil, fmt.Errorf("the broker at %s rejected the credentials, check access_token, token_file or credentials_command (or basic_auth_username and basic_auth_password): %w", c.Config.BaseURL, err)
+	case errors.Is(err, client.ErrNotFound):
+		return nil, fmt.Errorf("no Pact Broker API was found at %s, check host: %w", c.Config.BaseURL, err)
+	default:
+		return nil, fmt.Errorf("unable to connect to the broker at %s, check host (or set skip_credentials_validation to configure the provider without connecting): %w", c.Config.BaseURL, err)
+	}
+}
+
+// resolveAccessToken returns the API token from whichever of access_token, token_file or
+// credentials_command is set
+func resolveAccessToken(d *schema.ResourceData) (string, error) {
+	token := d.Get("access_token").(string)
+	path := d.Get("token_file").(string)
+	command := ExpandStringList(d.Get("credentials_command").([]interface{}))
+
+	set := 0
+	for _, configured := range []bool{token != "", path != "", len(command) > 0} {
+		if configured {
+			set++
+		}
+	}
+	if set > 1 {
+		return "", fmt.Errorf("only one of access_token, token_file or credentials_command can be set")
+	}
+
+	switch {
+	case path != "":
+		return readTokenFile(path)
+	case len(command) > 0:
+		return runCredentialsCommand(command, d.Get("host").(string))
+	}
+
+	return token, nil
+}
+
+// readTokenFile reads the API token from a file, ignoring the trailing newline most tools write
+func readTokenFile(path string) (string, error) {
+	contents, err := ioutil.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("error reading token_file: %w", err)
+	}
+
+	token := strings.TrimSpace(string(contents))
+	if token == "" {
+		return "", fmt.Errorf("token_file %s is empty", path)
+	}
+
+	return token, nil
+}
+
+// runCredentialsCommand runs a credential helper and returns the token it prints, as JSON, to stdout.
+// The host is passed in PACT_BROKER_BASE_URL, so one helper can issue tokens for several brokers
+func runCredentialsCommand(command []string, host string) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), credentialsCommandTimeout)
+	defer cancel()
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, command[0], command[1:]...)
+	cmd.Env = append(os.Environ(), "PACT_BROKER_BASE_URL="+host)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("error running credentials_command %s: %w: %s", command[0], err, strings.TrimSpace(stderr.String()))
+	}
+
+	var credentials struct {
+		Token string `json:"token"`
+	}
+	if err := json.Unmarshal(stdout.Bytes(), &credentials); err != nil {
+		return "", fmt.Errorf("error parsing the output of credentials_command %s, expected JSON with a token: %w", command[0], err)
+	}
+	if credentials.Token == "" {
+		return "", fmt.Errorf("credentials_command %s did not return a token", command[0])
+	}
+
+	return credentials.Token, nil
+}
+
+// validateCredentials rejects a token together with basic auth credentials, and half a set of basic auth
+// credentials. It is checked when configuring rather than in the schema so that credentials from
+// environment variables are included
+func validateCredentials(accessToken, username, password string) error {
+	if accessToken != "" && (username != "" || password != "") {
+		return fmt.Errorf("only one of access_token or basic_auth_username/basic_auth_password can be set")
+	}
+	if (username == "") != (password == "") {
+		return fmt.Errorf("basic_auth_username and basic_auth_password must be set together")
+	}
+
+	return nil
+}
+
+// tlsConfig builds the TLS configuration for the broker connection, trusting the configured CA bundle as
+// well as the system roots, and presenting the client certificate (if any) for mutual TLS
+func tlsConfig(d *schema.ResourceData) (*tls.Config, error) {
+	config := &tls.Config{
+		InsecureSkipVerify: d.Get("tls_insecure").(bool),
+	}
+
+	if certFile := d.Get("client_cert_file").(string); certFile != "" {
+		cert, err := tls.LoadX509KeyPair(certFile, d.Get("client_key_file").(string))
+		if err != nil {
+			return nil, fmt.Errorf("error loading the client certificate: %w", err)
+		}
+		config.Certificates = []tls.Certificate{cert}
+	}
+
+	bundle := []byte(d.Get("ca_cert_pem").(string))
+	if path := d.Get("ca_cert_file").(string); path != "" {
+		var err error
+		if bundle, err = ioutil.ReadFile(path); err != nil {
+			return nil, fmt.Errorf("error reading ca_cert_file: %w", err)
+		}
+	}
+	if len(bundle) == 0 {
+		return config, nil
+	}
+
+	pool, err := x509.SystemCertPool()
+	if err != nil {
+		logDebug("", d, "unable to load the system CA certificates, only the configured bundle will be trusted", "error", err)
+		pool = x509.NewCertPool()
+	}
+	if !pool.AppendCertsFromPEM(bundle) {
+		return nil, fmt.Errorf("the CA bundle doesn't contain any PEM encoded certificates")
+	}
+	config.RootCAs = pool
+
+	return config, nil
 }
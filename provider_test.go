@@ -1,7 +1,24 @@
 package main
 
 import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/pactflow/terraform/client"
 )
 
 func TestProvider(t *testing.T) {
@@ -9,3 +26,292 @@ func TestProvider(t *testing.T) {
 		t.Fatalf("err: %s", err)
 	}
 }
+
+func TestProviderCredentials(t *testing.T) {
+	for name, config := range map[string]map[string]interface{}{
+		"token and basic auth":          {"host": "http://localhost", "access_token": "abc", "basic_auth_username": "pact_broker", "basic_auth_password": "pact_broker"},
+		"basic auth without a password": {"host": "http://localhost", "basic_auth_username": "pact_broker"},
+	} {
+		if _, err := configureProvider(schema.TestResourceDataRaw(t, Provider().Schema, config)); err == nil {
+			t.Fatalf("expected %s to be rejected", name)
+		}
+	}
+
+	for name, config := range map[string]map[string]interface{}{
+		"token":      {"host": "http://localhost", "access_token": "abc", "skip_credentials_validation": true},
+		"basic auth": {"host": "http://localhost", "basic_auth_username": "pact_broker", "basic_auth_password": "pact_broker", "skip_credentials_validation": true},
+	} {
+		if _, err := configureProvider(schema.TestResourceDataRaw(t, Provider().Schema, config)); err != nil {
+			t.Fatalf("expected %s to be accepted, got %s", name, err)
+		}
+	}
+}
+
+func TestProviderTokenFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "token")
+	if err := ioutil.WriteFile(path, []byte("abc\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	meta, err := configureProvider(schema.TestResourceDataRaw(t, Provider().Schema, map[string]interface{}{
+		"host":                        "http://localhost",
+		"token_file":                  path,
+		"skip_credentials_validation": true,
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if token := meta.(*client.Client).Config.AccessToken; token != "abc" {
+		t.Fatalf("expected the token to be read from the file and trimmed, got %q", token)
+	}
+
+	for name, config := range map[string]map[string]interface{}{
+		"token file and token": {"host": "http://localhost", "token_file": path, "access_token": "abc"},
+		"missing token file":   {"host": "http://localhost", "token_file": filepath.Join(t.TempDir(), "missing")},
+	} {
+		if _, err := configureProvider(schema.TestResourceDataRaw(t, Provider().Schema, config)); err == nil {
+			t.Fatalf("expected %s to be rejected", name)
+		}
+	}
+}
+
+func TestProviderCredentialsCommand(t *testing.T) {
+	configure := func(command ...interface{}) (*client.Client, error) {
+		meta, err := configureProvider(schema.TestResourceDataRaw(t, Provider().Schema, map[string]interface{}{
+			"host":                        "http://localhost",
+			"credentials_command":         command,
+			"skip_credentials_validation": true,
+		}))
+		if err != nil {
+			return nil, err
+		}
+		return meta.(*client.Client), nil
+	}
+
+	c, err := configure("sh", "-c", `echo "{\"token\": \"token-for-$PACT_BROKER_BASE_URL\"}"`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.Config.AccessToken != "token-for-http://localhost" {
+		t.Fatalf("expected the token printed by the command, got %q", c.Config.AccessToken)
+	}
+
+	if _, err := configure("sh", "-c", "echo denied >&2; exit 1"); err == nil || !strings.Contains(err.Error(), "denied") {
+		t.Fatalf("expected the command's error output to be reported, got %v", err)
+	}
+	if _, err := configure("sh", "-c", "echo not-json"); err == nil || !strings.Contains(err.Error(), "expected JSON with a token") {
+		t.Fatalf("expected output that isn't JSON to be rejected, got %v", err)
+	}
+}
+
+func TestProviderEnvironmentVariables(t *testing.T) {
+	for k, v := range map[string]string{
+		"PACT_BROKER_BASE_URL": "https://broker.example.com",
+		"PACT_BROKER_USERNAME": "pact_broker",
+		"PACT_BROKER_PASSWORD": "secret",
+	} {
+		os.Setenv(k, v)
+		defer os.Unsetenv(k)
+	}
+
+	meta, err := configureProvider(schema.TestResourceDataRaw(t, Provider().Schema, map[string]interface{}{
+		"basic_auth_password":         "from-config",
+		"skip_credentials_validation": true,
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	config := meta.(*client.Client).Config
+	if config.BaseURL.String() != "https://broker.example.com" || config.BasicAuthUsername != "pact_broker" {
+		t.Fatalf("expected the host and username to come from the environment, got %s and %s", config.BaseURL, config.BasicAuthUsername)
+	}
+	if config.BasicAuthPassword != "from-config" {
+		t.Fatalf("expected the configured password to take precedence, got %s", config.BasicAuthPassword)
+	}
+}
+
+func TestProviderCACertificates(t *testing.T) {
+	_, err := configureProvider(schema.TestResourceDataRaw(t, Provider().Schema, map[string]interface{}{
+		"host":        "https://broker.example.com",
+		"ca_cert_pem": "not a certificate",
+	}))
+	if err == nil || !strings.Contains(err.Error(), "doesn't contain any PEM encoded certificates") {
+		t.Fatalf("expected an error for an invalid CA bundle, got %v", err)
+	}
+
+	_, err = configureProvider(schema.TestResourceDataRaw(t, Provider().Schema, map[string]interface{}{
+		"host":         "https://broker.example.com",
+		"ca_cert_file": "missing.pem",
+	}))
+	if err == nil || !strings.Contains(err.Error(), "error reading ca_cert_file") {
+		t.Fatalf("expected an error for a missing CA bundle, got %v", err)
+	}
+}
+
+func TestProviderClientCertificate(t *testing.T) {
+	key, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	der, err := x509.CreateCertificate(rand.Reader, &x509.Certificate{SerialNumber: big.NewInt(1), NotAfter: time.Now().Add(time.Hour)}, &x509.Certificate{SerialNumber: big.NewInt(1)}, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, _ := x509.MarshalECPrivateKey(key)
+
+	dir := t.TempDir()
+	certFile, keyFile := filepath.Join(dir, "client.crt"), filepath.Join(dir, "client.key")
+	ioutil.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600)
+	ioutil.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0600)
+
+	config, err := tlsConfig(schema.TestResourceDataRaw(t, Provider().Schema, map[string]interface{}{
+		"client_cert_file": certFile,
+		"client_key_file":  keyFile,
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(config.Certificates) != 1 {
+		t.Fatalf("expected the client certificate to be presented, got %d certificates", len(config.Certificates))
+	}
+
+	_, err = tlsConfig(schema.TestResourceDataRaw(t, Provider().Schema, map[string]interface{}{
+		"client_cert_file": certFile,
+		"client_key_file":  certFile,
+	}))
+	if err == nil || !strings.Contains(err.Error(), "error loading the client certificate") {
+		t.Fatalf("expected an error for an invalid key, got %v", err)
+	}
+}
+
+func TestCheckConnection(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer valid" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Header().Set("Content-Type", "application/hal+json")
+		fmt.Fprint(w, `{"_links": {"self": {"href": "/"}, "curies": [{"name": "pb", "href": "/doc/{rel}"}]}}`)
+	}))
+	defer server.Close()
+
+	configure := func(token string) error {
+		_, err := configureProvider(schema.TestResourceDataRaw(t, Provider().Schema, map[string]interface{}{
+			"host":         server.URL,
+			"access_token": token,
+		}))
+		return err
+	}
+
+	if err := configure("valid"); err != nil {
+		t.Fatalf("expected valid credentials to be accepted, got %s", err)
+	}
+	if err := configure("invalid"); err == nil || !strings.Contains(err.Error(), "rejected the credentials") {
+		t.Fatalf("expected invalid credentials to be rejected, got %v", err)
+	}
+	// Credentials that aren't known until apply are empty when the provider is configured for the plan
+	if err := configure(""); err != nil {
+		t.Fatalf("expected the credentials not to be validated without credentials, got %s", err)
+	}
+}
+
+// A broker that allows public reads needs no credentials, but the index is still read from it
+func TestCheckConnection_WithoutCredentials(t *testing.T) {
+	var requested string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requested = r.URL.Path
+		w.Header().Set("Content-Type", "application/hal+json")
+		if r.URL.Path != "/" {
+			fmt.Fprint(w, `{"_links": {"pb:webhooks": []}}`)
+			return
+		}
+		fmt.Fprint(w, `{"_links": {"self": {"href": "/"}, "pb:webhooks": {"href": "/api/webhooks"}}}`)
+	}))
+	defer server.Close()
+
+	meta, err := configureProvider(schema.TestResourceDataRaw(t, Provider().Schema, map[string]interface{}{
+		"host": server.URL,
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c := meta.(*client.Client)
+	if c.Config.BrokerType != ossBrokerType || !c.Config.BrokerTypeDetected {
+		t.Fatalf("expected an OSS broker to be detected, got %q", c.Config.BrokerType)
+	}
+	if _, err := c.ListWebhooks(); err != nil || requested != "/api/webhooks" {
+		t.Fatalf("expected the index links to be followed, got %q (%v)", requested, err)
+	}
+}
+
+func TestDetectBrokerType(t *testing.T) {
+	index := `{"_links": {"self": {"href": "/"}}}`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/hal+json")
+		fmt.Fprint(w, index)
+	}))
+	defer server.Close()
+
+	configure := func(brokerType string) *client.Client {
+		c, err := configureProvider(schema.TestResourceDataRaw(t, Provider().Schema, map[string]interface{}{
+			"host":         server.URL,
+			"access_token": "token",
+			"broker_type":  brokerType,
+		}))
+		if err != nil {
+			t.Fatalf("unexpected error configuring the provider: %s", err)
+		}
+		return c.(*client.Client)
+	}
+
+	if c := configure(""); c.Config.BrokerType != ossBrokerType || !c.Config.BrokerTypeDetected {
+		t.Fatalf("expected an OSS broker to be detected, got %q", c.Config.BrokerType)
+	}
+
+	read := pactflowOnly("pact_team", &schema.Resource{
+		Read: func(d *schema.ResourceData, meta interface{}) error { return nil },
+	}).Read
+	if err := read(nil, configure("")); err == nil || !strings.Contains(err.Error(), "detected from its API index") {
+		t.Fatalf("expected a not supported error for a detected OSS broker, got %v", err)
+	}
+
+	index = `{"_links": {"self": {"href": "/"}, "pf:admin-users": {"href": "/admin/users"}}}`
+	if c := configure(""); c.Config.BrokerType != pactflowBrokerType {
+		t.Fatalf("expected PactFlow to be detected, got %q", c.Config.BrokerType)
+	}
+	if c := configure(ossBrokerType); c.Config.BrokerType != ossBrokerType || c.Config.BrokerTypeDetected {
+		t.Fatalf("expected the configured broker type to be kept, got %q", c.Config.BrokerType)
+	}
+}
+
+func TestPactflowOnly(t *testing.T) {
+	read := pactflowOnly("pact_team", &schema.Resource{
+		Read: func(d *schema.ResourceData, meta interface{}) error { return nil },
+	}).Read
+
+	if err := read(nil, &client.Client{Config: client.Config{BrokerType: ossBrokerType}}); err == nil || !strings.Contains(err.Error(), "pact_team is not supported by this broker") {
+		t.Fatalf("expected a not supported error for an OSS broker, got %v", err)
+	}
+	for _, brokerType := range []string{pactflowBrokerType, ""} {
+		if err := read(nil, &client.Client{Config: client.Config{BrokerType: brokerType}}); err != nil {
+			t.Fatalf("expected no error for broker type %q, got %s", brokerType, err)
+		}
+	}
+}
+
+// A mistyped environment should fail the plan, rather than with a 404 from the broker
+func TestEnvironmentAttributesAreValidated(t *testing.T) {
+	dataSources := Provider().DataSourcesMap
+
+	for _, name := range []string{"pact_currently_deployed_versions", "pact_currently_supported_released_versions", "pact_version_deployment_status"} {
+		validate := dataSources[name].Schema["environment"].ValidateFunc
+		if validate == nil {
+			t.Fatalf("expected %s to validate the environment", name)
+		}
+		if _, errs := validate("production", "environment"); len(errs) == 0 {
+			t.Fatalf("expected %s to reject an environment that isn't a UUID", name)
+		}
+		if _, errs := validate("e2b6d3f6-6a3c-4b8e-9a7f-2f5c1d0e8b4a", "environment"); len(errs) != 0 {
+			t.Fatalf("expected %s to accept an environment UUID, got %v", name, errs)
+		}
+	}
+}
@@ -0,0 +1,135 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/pactflow/terraform/broker"
+)
+
+var allowedAnnouncementLevels = []string{
+	"info",
+	"warning",
+	"error",
+}
+
+func announcement() *schema.Resource {
+	return &schema.Resource{
+		Importer: &schema.ResourceImporter{State: schema.ImportStatePassthrough},
+		Create:   announcementCreate,
+		Read:     announcementRead,
+		Update:   announcementUpdate,
+		Delete:   announcementDelete,
+		Timeouts: defaultTimeouts(),
+		Schema: map[string]*schema.Schema{
+			"message": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringLenBetween(1, 1000),
+				Description:  "The message to display in the banner",
+			},
+			"level": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "info",
+				ValidateFunc: validation.StringInSlice(allowedAnnouncementLevels, false),
+				Description:  "The severity of the message, which controls how it is displayed (info, warning or error)",
+			},
+			"enabled": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Whether the banner is currently displayed",
+			},
+		},
+	}
+}
+
+func announcementFromState(d *schema.ResourceData) broker.Announcement {
+	return broker.Announcement{
+		Message: d.Get("message").(string),
+		Level:   d.Get("level").(string),
+		Enabled: d.Get("enabled").(bool),
+	}
+}
+
+func setAnnouncementState(d *schema.ResourceData, a *broker.Announcement) error {
+	logDebug("pact_announcement", d, "setting announcement state", "announcement", a)
+
+	if err := d.Set("message", a.Message); err != nil {
+		return fmt.Errorf("error setting key 'message': %w", err)
+	}
+	if err := d.Set("level", a.Level); err != nil {
+		return fmt.Errorf("error setting key 'level': %w", err)
+	}
+	if err := d.Set("enabled", a.Enabled); err != nil {
+		return fmt.Errorf("error setting key 'enabled': %w", err)
+	}
+
+	return nil
+}
+
+func announcementCreate(d *schema.ResourceData, meta interface{}) error {
+	client, cancel := timeoutClient(d, meta, schema.TimeoutCreate)
+	defer cancel()
+
+	announcement := announcementFromState(d)
+
+	updated, err := client.SetAnnouncement(announcement)
+
+	if err != nil {
+		return fmt.Errorf("error setting announcement: %w", err)
+	}
+
+	d.SetId(client.Config.BaseURL.Host)
+
+	if err = setAnnouncementState(d, updated); err != nil {
+		return fmt.Errorf("error setting announcement state: %w", err)
+	}
+
+	return nil
+}
+
+func announcementRead(d *schema.ResourceData, meta interface{}) error {
+	client, cancel := timeoutClient(d, meta, schema.TimeoutRead)
+	defer cancel()
+
+	announcement, err := client.ReadAnnouncement()
+
+	if err != nil {
+		return fmt.Errorf("error reading announcement: %w", err)
+	}
+
+	if err = setAnnouncementState(d, announcement); err != nil {
+		return fmt.Errorf("error setting announcement state: %w", err)
+	}
+
+	return nil
+}
+
+func announcementUpdate(d *schema.ResourceData, meta interface{}) error {
+	if !hasRemoteChanges(d, announcement().Schema) {
+		logDebug("pact_announcement", d, "no changes to send to the broker")
+		return nil
+	}
+
+	return announcementCreate(d, meta)
+}
+
+func announcementDelete(d *schema.ResourceData, meta interface{}) error {
+	client, cancel := timeoutClient(d, meta, schema.TimeoutDelete)
+	defer cancel()
+
+	logDebug("pact_announcement", d, "deleting (clearing) announcement")
+
+	_, err := client.SetAnnouncement(broker.Announcement{})
+
+	if err != nil {
+		return fmt.Errorf("error deleting announcement: %w", err)
+	}
+
+	d.SetId("")
+
+	return nil
+}
@@ -2,26 +2,25 @@ package main
 
 import (
 	"fmt"
-	"log"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/pactflow/terraform/broker"
-	"github.com/pactflow/terraform/client"
 )
 
 func application() *schema.Resource {
 	return &schema.Resource{
-		Create:   applicationCreate,
-		Update:   applicationUpdate,
-		Read:     applicationRead,
-		Delete:   applicationDelete,
-		Importer: &schema.ResourceImporter{State: schema.ImportStatePassthrough},
+		Create:        applicationCreate,
+		Update:        applicationUpdate,
+		Read:          applicationRead,
+		Delete:        applicationDelete,
+		Timeouts:      defaultTimeouts(),
+		Importer:      &schema.ResourceImporter{State: importWithDeletionProtection},
+		CustomizeDiff: applicationCustomizeDiff,
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Type:        schema.TypeString,
 				Required:    true,
-				ForceNew:    true,
-				Description: "Name of the Pacticipant",
+				Description: "Name of the Pacticipant. Changing the name renames the pacticipant, keeping its history",
 			},
 			"repository_url": {
 				Type:        schema.TypeString,
@@ -38,18 +37,24 @@ func application() *schema.Resource {
 				Optional:    true,
 				Description: "The display name of the pacticipant",
 			},
+			"deletion_protection": deletionProtectionType,
 		},
 	}
 }
 
 func applicationCreate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*client.Client)
+	client, cancel := timeoutClient(d, meta, schema.TimeoutCreate)
+	defer cancel()
+
 	name := d.Get("name").(string)
 	url := d.Get("repository_url").(string)
 	branch := d.Get("main_branch").(string)
 	displayName := d.Get("display_name").(string)
 
-	log.Println("[DEBUG] creating pacticipant", name)
+	unlock := lockPacticipants(name)
+	defer unlock()
+
+	logDebug("pact_application", d, "creating pacticipant", "name", name)
 
 	pacticipant := broker.Pacticipant{
 		Name:          name,
@@ -59,11 +64,29 @@ func applicationCreate(d *schema.ResourceData, meta interface{}) error {
 	}
 	_, err := client.CreatePacticipant(pacticipant)
 
+	if isConflict(err) && adoptExistingResources(meta) {
+		unlock()
+
+		// Pacticipants are identified by name, so there is nothing to look up
+		return adoptExisting(d, meta, "application", func() (string, error) {
+			return name, nil
+		}, applicationUpdate)
+	}
 	if err != nil {
-		return fmt.Errorf("error creating application: %w", err)
+		return fmt.Errorf("error creating %s: %w", describeResource("application", d), err)
 	}
 
+	// Unlike the other resources, the ID is the pacticipant's name rather than a UUID: the broker's API only
+	// addresses pacticipants by name, and OSS brokers don't assign them any other identifier. A rename is
+	// applied in place (see applicationUpdate), so the ID changing with it doesn't replace the resource
 	d.SetId(name)
+
+	if err := waitUntilVisible(client.Context(), d, "application", func() error {
+		_, err := client.ReadPacticipant(name)
+		return err
+	}); err != nil {
+		return err
+	}
 	d.Set("name", pacticipant.Name)
 	d.Set("repository_url", pacticipant.RepositoryURL)
 	d.Set("main_branch", pacticipant.MainBranch)
@@ -73,13 +96,26 @@ func applicationCreate(d *schema.ResourceData, meta interface{}) error {
 }
 
 func applicationUpdate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*client.Client)
+	if !hasRemoteChanges(d, application().Schema) {
+		logDebug("pact_application", d, "no changes to send to the broker")
+		return nil
+	}
+
+	client, cancel := timeoutClient(d, meta, schema.TimeoutUpdate)
+	defer cancel()
+
 	name := d.Get("name").(string)
 	url := d.Get("repository_url").(string)
 	branch := d.Get("main_branch").(string)
 	displayName := d.Get("display_name").(string)
 
-	log.Println("[DEBUG] updating pacticipant", name)
+	// The ID is the pacticipant's current name. It is also set when an existing pacticipant is adopted,
+	// which has no previous name in state
+	current := d.Id()
+	unlock := lockPacticipants(current, name)
+	defer unlock()
+
+	logDebug("pact_application", d, "updating pacticipant", "name", name)
 
 	pacticipant := broker.Pacticipant{
 		Name:          name,
@@ -87,10 +123,18 @@ func applicationUpdate(d *schema.ResourceData, meta interface{}) error {
 		MainBranch:    branch,
 		DisplayName:   displayName,
 	}
-	_, err := client.UpdatePacticipant(pacticipant)
+
+	// A rename has to address the pacticipant by its current name
+	var err error
+	if current != "" && current != name {
+		logDebug("pact_application", d, "renaming pacticipant", "from", current, "to", name)
+		_, err = client.RenamePacticipant(current, pacticipant)
+	} else {
+		_, err = client.UpdatePacticipant(pacticipant)
+	}
 
 	if err != nil {
-		return fmt.Errorf("error updating application: %w", err)
+		return fmt.Errorf("error updating %s: %w", describeResource("application", d), err)
 	}
 
 	d.SetId(name)
@@ -103,15 +147,20 @@ func applicationUpdate(d *schema.ResourceData, meta interface{}) error {
 }
 
 func applicationRead(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*client.Client)
-	log.Println("[DEBUG] reading pacticipant", d.Id())
+	client, cancel := timeoutClient(d, meta, schema.TimeoutRead)
+	defer cancel()
+
+	logDebug("pact_application", d, "reading pacticipant")
 
 	pacticipant, err := client.ReadPacticipant(d.Id())
 
-	log.Println("[DEBUG] have pacticipant for READ", pacticipant)
+	logDebug("pact_application", d, "read pacticipant", "pacticipant", pacticipant)
 
+	if removeFromStateIfNotFound(d, err) {
+		return nil
+	}
 	if err != nil {
-		return fmt.Errorf("error reading application: %w", err)
+		return fmt.Errorf("error reading %s: %w", describeResource("application", d), err)
 	}
 
 	d.SetId(pacticipant.Name)
@@ -124,10 +173,16 @@ func applicationRead(d *schema.ResourceData, meta interface{}) error {
 }
 
 func applicationDelete(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*client.Client)
+	client, cancel := timeoutClient(d, meta, schema.TimeoutDelete)
+	defer cancel()
+
 	name := d.Get("name").(string)
 
-	log.Println("[DEBUG] deleting pacticipant", name)
+	if err := checkDeletionProtection(d, "pacticipant"); err != nil {
+		return err
+	}
+
+	logDebug("pact_application", d, "deleting pacticipant", "name", name)
 
 	err := client.DeletePacticipant(broker.Pacticipant{
 		Name: name,
@@ -135,7 +190,7 @@ func applicationDelete(d *schema.ResourceData, meta interface{}) error {
 
 	if err != nil {
 		d.SetId("")
-		return fmt.Errorf("error deleting application: %w", err)
+		return fmt.Errorf("error deleting %s: %w", describeResource("application", d), err)
 	}
 
 	return nil
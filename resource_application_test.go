@@ -0,0 +1,83 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/pactflow/terraform/broker"
+	"github.com/pactflow/terraform/brokertest"
+)
+
+func TestApplicationAdoptExisting(t *testing.T) {
+	server := brokertest.NewServer()
+	defer server.Close()
+
+	server.PutPacticipant(broker.Pacticipant{Name: "Foo", DisplayName: "Created elsewhere"})
+
+	meta, err := configureProvider(schema.TestResourceDataRaw(t, Provider().Schema, map[string]interface{}{
+		"host":                     server.URL,
+		"adopt_existing_resources": true,
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	d := schema.TestResourceDataRaw(t, application().Schema, map[string]interface{}{
+		"name":           "Foo",
+		"display_name":   "Foo UI",
+		"repository_url": "https://github.com/example/foo",
+	})
+
+	if err := applicationCreate(d, meta); err != nil {
+		t.Fatalf("error adopting the existing pacticipant: %s", err)
+	}
+	if d.Id() != "Foo" {
+		t.Fatalf("expected the adopted pacticipant's name as the ID, got %q", d.Id())
+	}
+
+	stored, ok := server.Pacticipant("Foo")
+	if !ok {
+		t.Fatal("expected the adopted pacticipant to keep its name")
+	}
+	if stored.DisplayName != "Foo UI" || stored.RepositoryURL != "https://github.com/example/foo" {
+		t.Fatalf("expected the configured attributes to be applied to the adopted pacticipant, got %+v", stored)
+	}
+}
+
+// The ID is the pacticipant's name, so a rename has to move the ID with it rather than replace the resource
+func TestApplicationRenameInPlace(t *testing.T) {
+	if application().Schema["name"].ForceNew {
+		t.Fatal("expected changing the name to rename the pacticipant, not recreate it")
+	}
+
+	server := brokertest.NewServer()
+	defer server.Close()
+
+	server.PutPacticipant(broker.Pacticipant{Name: "Foo", MainBranch: "main"})
+
+	meta, err := configureProvider(schema.TestResourceDataRaw(t, Provider().Schema, map[string]interface{}{
+		"host": server.URL,
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	d := schema.TestResourceDataRaw(t, application().Schema, map[string]interface{}{
+		"name":        "Bar",
+		"main_branch": "main",
+	})
+	d.SetId("Foo")
+
+	if err := applicationUpdate(d, meta); err != nil {
+		t.Fatalf("error renaming the pacticipant: %s", err)
+	}
+	if d.Id() != "Bar" {
+		t.Fatalf("expected the ID to follow the new name, got %q", d.Id())
+	}
+	if _, ok := server.Pacticipant("Foo"); ok {
+		t.Fatal("expected the pacticipant to no longer have its old name")
+	}
+	if _, ok := server.Pacticipant("Bar"); !ok {
+		t.Fatal("expected the pacticipant to have been renamed")
+	}
+}
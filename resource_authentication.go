@@ -2,11 +2,9 @@ package main
 
 import (
 	"fmt"
-	"log"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/pactflow/terraform/broker"
-	"github.com/pactflow/terraform/client"
 )
 
 func authentication() *schema.Resource {
@@ -16,6 +14,7 @@ func authentication() *schema.Resource {
 		Read:     authenticationRead,
 		Update:   authenticationUpdate,
 		Delete:   authenticationDelete,
+		Timeouts: defaultTimeouts(),
 		Schema: map[string]*schema.Schema{
 			"github_organizations": {
 				Type: schema.TypeSet,
@@ -52,7 +51,7 @@ func authenticationFromState(d *schema.ResourceData) broker.AuthenticationSettin
 }
 
 func authenticationState(d *schema.ResourceData, r *broker.AuthenticationSettings) error {
-	log.Printf("[DEBUG] setting authentication state: %v \n", r)
+	logDebug("pact_authentication", d, "setting authentication state", "settings", r)
 
 	if len(r.Providers.Google.EmailDomains) > 0 {
 		if err := d.Set("google_domains", r.Providers.Google.EmailDomains); err != nil {
@@ -70,7 +69,9 @@ func authenticationState(d *schema.ResourceData, r *broker.AuthenticationSetting
 }
 
 func authenticationCreate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*client.Client)
+	client, cancel := timeoutClient(d, meta, schema.TimeoutCreate)
+	defer cancel()
+
 	authentication := authenticationFromState(d)
 
 	created, err := client.SetTenantAuthenticationSettings(authentication)
@@ -89,7 +90,9 @@ func authenticationCreate(d *schema.ResourceData, meta interface{}) error {
 }
 
 func authenticationRead(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*client.Client)
+	client, cancel := timeoutClient(d, meta, schema.TimeoutRead)
+	defer cancel()
+
 	authentication, err := client.ReadTenantAuthenticationSettings()
 
 	if err != nil {
@@ -104,13 +107,19 @@ func authenticationRead(d *schema.ResourceData, meta interface{}) error {
 }
 
 func authenticationUpdate(d *schema.ResourceData, meta interface{}) error {
+	if !hasRemoteChanges(d, authentication().Schema) {
+		logDebug("pact_authentication", d, "no changes to send to the broker")
+		return nil
+	}
+
 	return authenticationCreate(d, meta)
 }
 
 func authenticationDelete(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*client.Client)
+	client, cancel := timeoutClient(d, meta, schema.TimeoutDelete)
+	defer cancel()
 
-	log.Println("[DEBUG] deleting (clearing) authentication settings")
+	logDebug("pact_authentication", d, "deleting (clearing) authentication settings")
 
 	_, err := client.SetTenantAuthenticationSettings(broker.AuthenticationSettings{})
 
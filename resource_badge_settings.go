@@ -0,0 +1,107 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/pactflow/terraform/broker"
+)
+
+func badgeSettings() *schema.Resource {
+	return &schema.Resource{
+		Importer: &schema.ResourceImporter{State: schema.ImportStatePassthrough},
+		Create:   badgeSettingsCreate,
+		Read:     badgeSettingsRead,
+		Update:   badgeSettingsUpdate,
+		Delete:   badgeSettingsDelete,
+		Timeouts: defaultTimeouts(),
+		Schema: map[string]*schema.Schema{
+			"public_read_access": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Allow badges to be viewed without authentication (e.g. embedded in a README)",
+			},
+		},
+	}
+}
+
+func badgeSettingsFromState(d *schema.ResourceData) broker.BadgeSettings {
+	return broker.BadgeSettings{
+		PublicReadAccess: d.Get("public_read_access").(bool),
+	}
+}
+
+func setBadgeSettingsState(d *schema.ResourceData, s *broker.BadgeSettings) error {
+	logDebug("pact_badge_settings", d, "setting badge settings state", "settings", s)
+
+	if err := d.Set("public_read_access", s.PublicReadAccess); err != nil {
+		return fmt.Errorf("error setting key 'public_read_access': %w", err)
+	}
+
+	return nil
+}
+
+func badgeSettingsCreate(d *schema.ResourceData, meta interface{}) error {
+	client, cancel := timeoutClient(d, meta, schema.TimeoutCreate)
+	defer cancel()
+
+	settings := badgeSettingsFromState(d)
+
+	updated, err := client.SetBadgeSettings(settings)
+
+	if err != nil {
+		return fmt.Errorf("error setting badge settings: %w", err)
+	}
+
+	d.SetId(client.Config.BaseURL.Host)
+
+	if err = setBadgeSettingsState(d, updated); err != nil {
+		return fmt.Errorf("error setting badge settings state: %w", err)
+	}
+
+	return nil
+}
+
+func badgeSettingsRead(d *schema.ResourceData, meta interface{}) error {
+	client, cancel := timeoutClient(d, meta, schema.TimeoutRead)
+	defer cancel()
+
+	settings, err := client.ReadBadgeSettings()
+
+	if err != nil {
+		return fmt.Errorf("error reading badge settings: %w", err)
+	}
+
+	if err = setBadgeSettingsState(d, settings); err != nil {
+		return fmt.Errorf("error setting badge settings state: %w", err)
+	}
+
+	return nil
+}
+
+func badgeSettingsUpdate(d *schema.ResourceData, meta interface{}) error {
+	if !hasRemoteChanges(d, badgeSettings().Schema) {
+		logDebug("pact_badge_settings", d, "no changes to send to the broker")
+		return nil
+	}
+
+	return badgeSettingsCreate(d, meta)
+}
+
+func badgeSettingsDelete(d *schema.ResourceData, meta interface{}) error {
+	client, cancel := timeoutClient(d, meta, schema.TimeoutDelete)
+	defer cancel()
+
+	logDebug("pact_badge_settings", d, "deleting (disabling) public badge access")
+
+	_, err := client.SetBadgeSettings(broker.BadgeSettings{})
+
+	if err != nil {
+		return fmt.Errorf("error deleting badge settings: %w", err)
+	}
+
+	d.SetId("")
+
+	return nil
+}
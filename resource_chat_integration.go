@@ -0,0 +1,236 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/pactflow/terraform/broker"
+)
+
+const (
+	slackChatType   = "slack"
+	msTeamsChatType = "ms_teams"
+)
+
+var allowedChatTypes = []string{
+	slackChatType,
+	msTeamsChatType,
+}
+
+func chatIntegration() *schema.Resource {
+	return &schema.Resource{
+		Create:        chatIntegrationCreate,
+		Update:        chatIntegrationUpdate,
+		Read:          chatIntegrationRead,
+		Delete:        chatIntegrationDelete,
+		Timeouts:      defaultTimeouts(),
+		Importer:      &schema.ResourceImporter{State: schema.ImportStatePassthrough},
+		SchemaVersion: 1,
+		StateUpgraders: []schema.StateUpgrader{
+			{
+				Type:    chatIntegrationV0().CoreConfigSchema().ImpliedType(),
+				Upgrade: chatIntegrationStateUpgradeV0,
+				Version: 0,
+			},
+		},
+		Schema: map[string]*schema.Schema{
+			"type": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice(allowedChatTypes, false),
+				Description:  "The chat platform to integrate with (slack or ms_teams)",
+			},
+			"workspace": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The Slack workspace to post to (Slack only)",
+			},
+			"channel": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The channel to post notifications to",
+			},
+			"webhook_url": {
+				Type:             schema.TypeString,
+				Required:         true,
+				Sensitive:        true,
+				ValidateFunc:     validateURL,
+				DiffSuppressFunc: suppressHashedValueDiff,
+				Description:      "The incoming webhook URL provided by Slack or Microsoft Teams",
+			},
+			"events": eventsType,
+			"enabled": {
+				Type:     schema.TypeBool,
+				Default:  true,
+				Optional: true,
+			},
+			"team": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "The team this integration should be associated with (uuid). Leave empty for an account wide integration",
+				ValidateFunc: validateUUID,
+			},
+			"uuid": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The UUID of the integration",
+			},
+		},
+	}
+}
+
+func getChatIntegrationFromState(d *schema.ResourceData) broker.ChatIntegration {
+	integration := broker.ChatIntegration{
+		UUID:       d.Id(),
+		Type:       d.Get("type").(string),
+		Workspace:  d.Get("workspace").(string),
+		Channel:    d.Get("channel").(string),
+		WebhookURL: unhashedValue(d.Get("webhook_url").(string)),
+		TeamUUID:   d.Get("team").(string),
+		Enabled:    d.Get("enabled").(bool),
+		Events:     []broker.WebhookEvent{},
+	}
+
+	for _, event := range ExpandStringSet(d.Get("events").(*schema.Set)) {
+		integration.Events = append(integration.Events, broker.WebhookEvent{
+			Name: event,
+		})
+	}
+
+	return integration
+}
+
+func chatIntegrationCreate(d *schema.ResourceData, meta interface{}) error {
+	client, cancel := timeoutClient(d, meta, schema.TimeoutCreate)
+	defer cancel()
+
+	integration := getChatIntegrationFromState(d)
+
+	logDebug("pact_chat_integration", d, "creating chat integration", "type", integration.Type, "channel", integration.Channel)
+
+	created, err := client.CreateChatIntegration(integration)
+
+	if err != nil {
+		return fmt.Errorf("error creating %s: %w", describeResource("chat integration", d), err)
+	}
+
+	d.SetId(created.UUID)
+
+	if err := waitUntilVisible(client.Context(), d, "chat integration", func() error {
+		_, err := client.ReadChatIntegration(created.UUID)
+		return err
+	}); err != nil {
+		return err
+	}
+
+	return setChatIntegrationState(d, created.ChatIntegration)
+}
+
+func chatIntegrationUpdate(d *schema.ResourceData, meta interface{}) error {
+	if !hasRemoteChanges(d, chatIntegration().Schema) {
+		logDebug("pact_chat_integration", d, "no changes to send to the broker")
+		return nil
+	}
+
+	client, cancel := timeoutClient(d, meta, schema.TimeoutUpdate)
+	defer cancel()
+
+	integration := getChatIntegrationFromState(d)
+
+	logDebug("pact_chat_integration", d, "updating chat integration")
+
+	updated, err := client.UpdateChatIntegration(integration)
+
+	if isNotFound(err) {
+		// An unchanged webhook URL is only held as a hash, so there is nothing to create the integration with
+		if isHashedValue(d.Get("webhook_url").(string)) {
+			return recreateAfterNotFound(d, meta, "chat integration", nil)
+		}
+		return recreateAfterNotFound(d, meta, "chat integration", chatIntegrationCreate)
+	}
+	if err != nil {
+		return fmt.Errorf("error updating %s: %w", describeResource("chat integration", d), err)
+	}
+
+	return setChatIntegrationState(d, updated.ChatIntegration)
+}
+
+func chatIntegrationRead(d *schema.ResourceData, meta interface{}) error {
+	client, cancel := timeoutClient(d, meta, schema.TimeoutRead)
+	defer cancel()
+
+	logDebug("pact_chat_integration", d, "reading chat integration")
+
+	integration, err := client.ReadChatIntegration(d.Id())
+
+	if removeFromStateIfNotFound(d, err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("error reading %s: %w", describeResource("chat integration", d), err)
+	}
+
+	return setChatIntegrationState(d, integration.ChatIntegration)
+}
+
+func chatIntegrationDelete(d *schema.ResourceData, meta interface{}) error {
+	client, cancel := timeoutClient(d, meta, schema.TimeoutDelete)
+	defer cancel()
+
+	logDebug("pact_chat_integration", d, "deleting chat integration")
+
+	err := client.DeleteChatIntegration(broker.ChatIntegration{
+		UUID: d.Id(),
+	})
+
+	if err != nil {
+		return fmt.Errorf("error deleting %s: %w", describeResource("chat integration", d), err)
+	}
+
+	d.SetId("")
+
+	return nil
+}
+
+func setChatIntegrationState(d *schema.ResourceData, integration broker.ChatIntegration) error {
+	logDebug("pact_chat_integration", d, "setting chat integration state", "uuid", integration.UUID)
+
+	if err := d.Set("type", integration.Type); err != nil {
+		return fmt.Errorf("error setting key 'type': %w", err)
+	}
+	if err := d.Set("workspace", integration.Workspace); err != nil {
+		return fmt.Errorf("error setting key 'workspace': %w", err)
+	}
+	if err := d.Set("channel", integration.Channel); err != nil {
+		return fmt.Errorf("error setting key 'channel': %w", err)
+	}
+	if err := d.Set("team", integration.TeamUUID); err != nil {
+		return fmt.Errorf("error setting key 'team': %w", err)
+	}
+	if err := d.Set("enabled", integration.Enabled); err != nil {
+		return fmt.Errorf("error setting key 'enabled': %w", err)
+	}
+	if err := d.Set("uuid", integration.UUID); err != nil {
+		return fmt.Errorf("error setting key 'uuid': %w", err)
+	}
+
+	events := make([]string, len(integration.Events))
+	for i, e := range integration.Events {
+		events[i] = e.Name
+	}
+	if err := d.Set("events", events); err != nil {
+		return fmt.Errorf("error setting key 'events': %w", err)
+	}
+
+	// The broker never returns the webhook URL as it contains a credential, so only a hash of the
+	// configured value is kept in state to detect changes to it
+	if original, ok := d.GetOk("webhook_url"); ok {
+		if err := d.Set("webhook_url", hashSensitiveValue(original.(string))); err != nil {
+			return fmt.Errorf("error setting key 'webhook_url': %w", err)
+		}
+	}
+
+	return nil
+}
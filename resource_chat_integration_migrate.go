@@ -0,0 +1,53 @@
+package main
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+// chatIntegrationV0 is the schema prior to version 1, used only to decode old states for upgrading
+func chatIntegrationV0() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"type": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"workspace": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"channel": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"webhook_url": {
+				Type:      schema.TypeString,
+				Required:  true,
+				Sensitive: true,
+			},
+			"events": eventsType,
+			"enabled": {
+				Type:     schema.TypeBool,
+				Default:  true,
+				Optional: true,
+			},
+			"team": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"uuid": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+// Version 0 stored the webhook URL (which embeds a credential) in plaintext, version 1 stores a salted hash of it
+func chatIntegrationStateUpgradeV0(rawState map[string]interface{}, meta interface{}) (map[string]interface{}, error) {
+	if url, ok := rawState["webhook_url"].(string); ok {
+		rawState["webhook_url"] = hashSensitiveValue(url)
+	}
+
+	return rawState, nil
+}
@@ -0,0 +1,19 @@
+package main
+
+import (
+	"testing"
+)
+
+func TestChatIntegrationStateUpgradeV0(t *testing.T) {
+	url := "https://hooks.slack.com/services/T000/B000/XXXX"
+
+	actual, err := chatIntegrationStateUpgradeV0(map[string]interface{}{"webhook_url": url}, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	upgraded := actual["webhook_url"].(string)
+	if !isHashedValue(upgraded) || !sensitiveValueMatches(upgraded, url) {
+		t.Fatalf("expected the webhook URL to be hashed, got %q", upgraded)
+	}
+}
@@ -2,23 +2,23 @@ package main
 
 import (
 	"fmt"
-	"log"
 	"regexp"
 	"sort"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
 	"github.com/pactflow/terraform/broker"
-	"github.com/pactflow/terraform/client"
 )
 
 func environment() *schema.Resource {
 	return &schema.Resource{
-		Create:   environmentCreate,
-		Update:   environmentUpdate,
-		Read:     environmentRead,
-		Delete:   environmentDelete,
-		Importer: &schema.ResourceImporter{State: schema.ImportStatePassthrough},
+		Create:        environmentCreate,
+		Update:        environmentUpdate,
+		Read:          environmentRead,
+		Delete:        environmentDelete,
+		Timeouts:      defaultTimeouts(),
+		Importer:      &schema.ResourceImporter{State: importWithDeletionProtection},
+		CustomizeDiff: pactflowOnlyAttributes("teams"),
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Type:         schema.TypeString,
@@ -42,7 +42,8 @@ func environment() *schema.Resource {
 				Optional:    true,
 				Description: "A list of teams (as uuids) that may use the environment",
 				Elem: &schema.Schema{
-					Type: schema.TypeString,
+					Type:         schema.TypeString,
+					ValidateFunc: validateUUID,
 				},
 			},
 			"uuid": {
@@ -50,6 +51,7 @@ func environment() *schema.Resource {
 				Computed:    true,
 				Description: "The UUID of environment",
 			},
+			"deletion_protection": deletionProtectionType,
 		},
 	}
 }
@@ -86,35 +88,68 @@ func environmentFromCRUD(environment broker.EnvironmentCreateOrUpdateResponse) b
 }
 
 func environmentCreate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*client.Client)
+	client, cancel := timeoutClient(d, meta, schema.TimeoutCreate)
+	defer cancel()
+
 	environment := getEnvironmentFromState(d)
 
 	teams := ExpandStringSet(d.Get("teams").(*schema.Set))
-	log.Println("[DEBUG] creating environment", environment, teams)
+	logDebug("pact_environment", d, "creating environment", "environment", environment, "teams", teams)
 
 	created, err := client.CreateEnvironment(environmentToCRUD(environment, teams))
 
+	if isConflict(err) && adoptExistingResources(meta) {
+		return adoptExisting(d, meta, "environment", func() (string, error) {
+			res, err := client.ListEnvironments(environment.Name)
+			if err != nil {
+				return "", err
+			}
+			for _, e := range res.Embedded.Environments {
+				if e.Name == environment.Name {
+					return e.UUID, nil
+				}
+			}
+			return "", fmt.Errorf("no environment named %q", environment.Name)
+		}, environmentUpdate)
+	}
 	if err != nil {
-		return err
+		return fmt.Errorf("error creating %s: %w", describeResource("environment", d), err)
 	}
 
 	d.SetId(created.UUID)
+
+	if err := waitUntilVisible(client.Context(), d, "environment", func() error {
+		_, err := client.ReadEnvironment(created.UUID)
+		return err
+	}); err != nil {
+		return err
+	}
 	setEnvironmentState(d, environmentFromCRUD(*created))
 
 	return nil
 }
 
 func environmentUpdate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*client.Client)
+	if !hasRemoteChanges(d, environment().Schema) {
+		logDebug("pact_environment", d, "no changes to send to the broker")
+		return nil
+	}
+
+	client, cancel := timeoutClient(d, meta, schema.TimeoutUpdate)
+	defer cancel()
+
 	environment := getEnvironmentFromState(d)
 	teams := ExpandStringSet(d.Get("teams").(*schema.Set))
 
-	log.Println("[DEBUG] updating environment", environment)
+	logDebug("pact_environment", d, "updating environment", "environment", environment)
 
 	updated, err := client.UpdateEnvironment(environmentToCRUD(environment, teams))
 
+	if isNotFound(err) {
+		return recreateAfterNotFound(d, meta, "environment", environmentCreate)
+	}
 	if err != nil {
-		return err
+		return fmt.Errorf("error updating %s: %w", describeResource("environment", d), err)
 	}
 
 	setEnvironmentState(d, environmentFromCRUD(*updated))
@@ -123,58 +158,63 @@ func environmentUpdate(d *schema.ResourceData, meta interface{}) error {
 }
 
 func environmentRead(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*client.Client)
+	client, cancel := timeoutClient(d, meta, schema.TimeoutRead)
+	defer cancel()
+
 	uuid := d.Id()
 
-	log.Println("[DEBUG] reading environment", uuid)
+	logDebug("pact_environment", d, "reading environment", "uuid", uuid)
 
 	environment, err := client.ReadEnvironment(uuid)
 
-	if err == nil {
-		d.SetId(environment.UUID)
-		setEnvironmentState(d, *environment)
+	if removeFromStateIfNotFound(d, err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("error reading %s: %w", describeResource("environment", d), err)
 	}
 
-	return err
+	d.SetId(environment.UUID)
+
+	return setEnvironmentState(d, *environment)
 }
 
 func environmentDelete(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*client.Client)
+	client, cancel := timeoutClient(d, meta, schema.TimeoutDelete)
+	defer cancel()
+
+	if err := checkDeletionProtection(d, "environment"); err != nil {
+		return err
+	}
 
-	log.Println("[DEBUG] deleting environment", d.Id())
+	logDebug("pact_environment", d, "deleting environment")
 
 	err := client.DeleteEnvironment(getEnvironmentFromState(d))
 
 	if err != nil {
-		d.SetId("")
-		return fmt.Errorf("unable to delete environment %s: %w", d.Id(), err)
+		return fmt.Errorf("error deleting %s: %w", describeResource("environment", d), err)
 	}
 
 	return nil
 }
 
 func setEnvironmentState(d *schema.ResourceData, environment broker.Environment) error {
-	log.Printf("[DEBUG] setting environment state: %+v \n", environment)
+	logDebug("pact_environment", d, "setting environment state", "environment", environment)
 
 	if err := d.Set("name", environment.Name); err != nil {
-		log.Println("[ERROR] error setting key 'name'", err)
-		return err
+		return fmt.Errorf("error setting key 'name': %w", err)
 	}
 	if err := d.Set("display_name", environment.DisplayName); err != nil {
-		log.Println("[ERROR] error setting key 'displayName'", err)
-		return err
+		return fmt.Errorf("error setting key 'display_name': %w", err)
 	}
 	if err := d.Set("production", environment.Production); err != nil {
-		log.Println("[ERROR] error setting key 'production'", err)
-		return err
+		return fmt.Errorf("error setting key 'production': %w", err)
 	}
 	if err := d.Set("uuid", environment.UUID); err != nil {
-		log.Println("[ERROR] error setting key 'uuid'", err)
-		return err
+		return fmt.Errorf("error setting key 'uuid': %w", err)
 	}
 	if err := d.Set("teams", teamsFromEnvironment(environment)); err != nil {
-		log.Println("[ERROR] error setting key 'teams'", err)
-		return err
+		return fmt.Errorf("error setting key 'teams': %w", err)
 	}
 
 	return nil
@@ -0,0 +1,168 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/pactflow/terraform/broker"
+	"github.com/pactflow/terraform/client"
+)
+
+var allowedDigestFrequencies = []string{
+	"daily",
+	"weekly",
+}
+
+func notificationSettings() *schema.Resource {
+	return &schema.Resource{
+		Importer: &schema.ResourceImporter{State: schema.ImportStatePassthrough},
+		Create:   notificationSettingsCreate,
+		Read:     notificationSettingsRead,
+		Update:   notificationSettingsUpdate,
+		Delete:   notificationSettingsDelete,
+		Timeouts: defaultTimeouts(),
+		Schema: map[string]*schema.Schema{
+			"team": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				Description:  "The team (uuid) to configure notifications for. Leave empty to configure the account wide settings",
+				ValidateFunc: validateUUID,
+			},
+			"verification_failure_digest": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Send a digest email summarising failed verifications",
+			},
+			"digest_frequency": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "daily",
+				ValidateFunc: validation.StringInSlice(allowedDigestFrequencies, false),
+				Description:  "How often the digest should be sent (daily or weekly)",
+			},
+			"recipients": {
+				Type: schema.TypeSet,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Optional:    true,
+				Description: "The email addresses that should receive notifications",
+			},
+		},
+	}
+}
+
+func notificationSettingsFromState(d *schema.ResourceData) broker.NotificationSettings {
+	return broker.NotificationSettings{
+		TeamUUID:                  d.Get("team").(string),
+		VerificationFailureDigest: d.Get("verification_failure_digest").(bool),
+		DigestFrequency:           d.Get("digest_frequency").(string),
+		Recipients:                ExpandStringSet(d.Get("recipients").(*schema.Set)),
+	}
+}
+
+func setNotificationSettingsState(d *schema.ResourceData, s *broker.NotificationSettings) error {
+	logDebug("pact_notification_settings", d, "setting notification settings state", "settings", s)
+
+	if err := d.Set("verification_failure_digest", s.VerificationFailureDigest); err != nil {
+		return fmt.Errorf("error setting key 'verification_failure_digest': %w", err)
+	}
+	if s.DigestFrequency != "" {
+		if err := d.Set("digest_frequency", s.DigestFrequency); err != nil {
+			return fmt.Errorf("error setting key 'digest_frequency': %w", err)
+		}
+	}
+	if err := d.Set("recipients", s.Recipients); err != nil {
+		return fmt.Errorf("error setting key 'recipients': %w", err)
+	}
+
+	return nil
+}
+
+// The ID is the team UUID for team scoped settings, or the broker host for the account wide settings
+func notificationSettingsID(c *client.Client, s broker.NotificationSettings) string {
+	if s.TeamUUID != "" {
+		return s.TeamUUID
+	}
+	return c.Config.BaseURL.Host
+}
+
+func notificationSettingsCreate(d *schema.ResourceData, meta interface{}) error {
+	client, cancel := timeoutClient(d, meta, schema.TimeoutCreate)
+	defer cancel()
+
+	settings := notificationSettingsFromState(d)
+
+	logDebug("pact_notification_settings", d, "setting notification settings", "settings", settings)
+
+	updated, err := client.SetNotificationSettings(settings)
+
+	if err != nil {
+		return fmt.Errorf("error setting notification settings: %w", err)
+	}
+
+	d.SetId(notificationSettingsID(client, settings))
+
+	if err = setNotificationSettingsState(d, updated); err != nil {
+		return fmt.Errorf("error setting notification settings state: %w", err)
+	}
+
+	return nil
+}
+
+func notificationSettingsRead(d *schema.ResourceData, meta interface{}) error {
+	client, cancel := timeoutClient(d, meta, schema.TimeoutRead)
+	defer cancel()
+
+	team := d.Get("team").(string)
+	if team == "" && client.Config.BaseURL != nil && d.Id() != client.Config.BaseURL.Host {
+		// Imported team settings only have the ID to go on
+		team = d.Id()
+	}
+
+	settings, err := client.ReadNotificationSettings(team)
+
+	if err != nil {
+		return fmt.Errorf("error reading notification settings: %w", err)
+	}
+
+	d.Set("team", team)
+
+	if err = setNotificationSettingsState(d, settings); err != nil {
+		return fmt.Errorf("error setting notification settings state: %w", err)
+	}
+
+	return nil
+}
+
+func notificationSettingsUpdate(d *schema.ResourceData, meta interface{}) error {
+	if !hasRemoteChanges(d, notificationSettings().Schema) {
+		logDebug("pact_notification_settings", d, "no changes to send to the broker")
+		return nil
+	}
+
+	return notificationSettingsCreate(d, meta)
+}
+
+func notificationSettingsDelete(d *schema.ResourceData, meta interface{}) error {
+	client, cancel := timeoutClient(d, meta, schema.TimeoutDelete)
+	defer cancel()
+
+	logDebug("pact_notification_settings", d, "deleting (clearing) notification settings")
+
+	_, err := client.SetNotificationSettings(broker.NotificationSettings{
+		TeamUUID:   d.Get("team").(string),
+		Recipients: []string{},
+	})
+
+	if err != nil {
+		return fmt.Errorf("error deleting notification settings: %w", err)
+	}
+
+	d.SetId("")
+
+	return nil
+}
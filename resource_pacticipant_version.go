@@ -0,0 +1,267 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/pactflow/terraform/broker"
+)
+
+// Publishes a version of a pacticipant, so that a service whose first deployment is done by Terraform is
+// known to the broker without a separate pact CLI step. The version is left in the broker on destroy, as
+// later deployments and verifications of the pacticipant depend on it
+func pacticipantVersion() *schema.Resource {
+	return &schema.Resource{
+		Create:   pacticipantVersionCreate,
+		Read:     pacticipantVersionRead,
+		Update:   pacticipantVersionUpdate,
+		Delete:   pacticipantVersionDelete,
+		Timeouts: defaultTimeouts(),
+		Importer: &schema.ResourceImporter{State: pacticipantVersionImport},
+		Schema: map[string]*schema.Schema{
+			"pacticipant": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The name of the pacticipant (application) the version belongs to",
+			},
+			"version": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The version number (e.g. the git sha)",
+			},
+			"branch": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "The branch the version was built from. The broker doesn't allow it to be changed once set",
+			},
+			"build_url": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The URL of the CI build that created the version",
+			},
+			"tags": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Description: "Tags to apply to the version. Tags added outside of Terraform are left alone",
+			},
+			"environment": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validateUUID,
+				Description:  "The environment (uuid) to record the version as deployed to when it is created",
+			},
+			"application_instance": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "The application instance the version was deployed to, when more than one instance is deployed to the environment",
+			},
+			"deployed_version_uuid": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The UUID of the deployment recorded for the version",
+			},
+		},
+	}
+}
+
+func pacticipantVersionCreate(d *schema.ResourceData, meta interface{}) error {
+	client, cancel := timeoutClient(d, meta, schema.TimeoutCreate)
+	defer cancel()
+
+	name := d.Get("pacticipant").(string)
+	number := d.Get("version").(string)
+
+	logDebug("pact_pacticipant_version", d, "publishing pacticipant version", "pacticipant", name, "version", number)
+
+	// Publishing a version creates the pacticipant if it doesn't already exist
+	unlock := lockPacticipants(name)
+	_, err := client.CreateOrUpdatePacticipantVersion(name, number, broker.VersionCreateOrUpdateRequest{
+		Branch:   d.Get("branch").(string),
+		BuildURL: d.Get("build_url").(string),
+	})
+	unlock()
+
+	if err != nil {
+		return fmt.Errorf("error publishing %s: %w", describeResource("pacticipant version", d), err)
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s", name, number))
+
+	for _, tag := range ExpandStringSet(d.Get("tags").(*schema.Set)) {
+		if err := client.TagPacticipantVersion(name, number, tag); err != nil {
+			return fmt.Errorf("error tagging %s with %q: %w", describeResource("pacticipant version", d), tag, err)
+		}
+	}
+
+	if environment := d.Get("environment").(string); environment != "" {
+		logDebug("pact_pacticipant_version", d, "recording deployment of pacticipant version", "environment", environment)
+
+		deployed, err := client.RecordDeployment(name, number, environment, broker.RecordDeploymentRequest{
+			ApplicationInstance: d.Get("application_instance").(string),
+		})
+
+		if err != nil {
+			return fmt.Errorf("error recording deployment of %s: %w", describeResource("pacticipant version", d), err)
+		}
+
+		d.Set("deployed_version_uuid", deployed.UUID)
+	}
+
+	return pacticipantVersionRead(d, meta)
+}
+
+func pacticipantVersionRead(d *schema.ResourceData, meta interface{}) error {
+	client, cancel := timeoutClient(d, meta, schema.TimeoutRead)
+	defer cancel()
+
+	name, number, err := splitPacticipantVersionID(d.Id())
+
+	if err != nil {
+		return err
+	}
+
+	logDebug("pact_pacticipant_version", d, "reading pacticipant version")
+
+	version, err := client.ReadPacticipantVersion(name, number)
+
+	if removeFromStateIfNotFound(d, err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("error reading %s: %w", describeResource("pacticipant version", d), err)
+	}
+
+	return setPacticipantVersionState(d, name, version)
+}
+
+// pacticipantVersionImport reads all of the version's tags into state. Read only keeps the tags Terraform
+// manages, and nothing is managed yet when a version is imported, so otherwise none would be imported
+func pacticipantVersionImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	client, cancel := timeoutClient(d, meta, schema.TimeoutRead)
+	defer cancel()
+
+	name, number, err := splitPacticipantVersionID(d.Id())
+
+	if err != nil {
+		return nil, err
+	}
+
+	version, err := client.ReadPacticipantVersion(name, number)
+
+	if err != nil {
+		return nil, fmt.Errorf("error importing %s: %w", describeResource("pacticipant version", d), err)
+	}
+
+	tags := make([]string, 0)
+	if version.Embedded != nil {
+		for _, tag := range version.Embedded.Tags {
+			tags = append(tags, tag.Name)
+		}
+	}
+	if err := d.Set("tags", tags); err != nil {
+		return nil, fmt.Errorf("error setting key 'tags': %w", err)
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func pacticipantVersionUpdate(d *schema.ResourceData, meta interface{}) error {
+	client, cancel := timeoutClient(d, meta, schema.TimeoutUpdate)
+	defer cancel()
+
+	name, number, err := splitPacticipantVersionID(d.Id())
+
+	if err != nil {
+		return err
+	}
+
+	if d.HasChange("build_url") {
+		logDebug("pact_pacticipant_version", d, "updating pacticipant version")
+
+		_, err := client.CreateOrUpdatePacticipantVersion(name, number, broker.VersionCreateOrUpdateRequest{
+			Branch:   d.Get("branch").(string),
+			BuildURL: d.Get("build_url").(string),
+		})
+
+		if err != nil {
+			return fmt.Errorf("error updating %s: %w", describeResource("pacticipant version", d), err)
+		}
+	}
+
+	if d.HasChange("tags") {
+		old, new := d.GetChange("tags")
+
+		for _, tag := range ExpandStringSet(old.(*schema.Set).Difference(new.(*schema.Set))) {
+			if err := client.DeletePacticipantVersionTag(name, number, tag); err != nil && !isNotFound(err) {
+				return fmt.Errorf("error removing tag %q from %s: %w", tag, describeResource("pacticipant version", d), err)
+			}
+		}
+		for _, tag := range ExpandStringSet(new.(*schema.Set).Difference(old.(*schema.Set))) {
+			if err := client.TagPacticipantVersion(name, number, tag); err != nil {
+				return fmt.Errorf("error tagging %s with %q: %w", describeResource("pacticipant version", d), tag, err)
+			}
+		}
+	}
+
+	return pacticipantVersionRead(d, meta)
+}
+
+func pacticipantVersionDelete(d *schema.ResourceData, meta interface{}) error {
+	logDebug("pact_pacticipant_version", d, "removing pacticipant version from state, it is left in the broker")
+
+	d.SetId("")
+
+	return nil
+}
+
+func setPacticipantVersionState(d *schema.ResourceData, name string, version *broker.Version) error {
+	logDebug("pact_pacticipant_version", d, "setting pacticipant version state", "version", version)
+
+	if err := d.Set("pacticipant", name); err != nil {
+		return fmt.Errorf("error setting key 'pacticipant': %w", err)
+	}
+	if err := d.Set("version", version.Number); err != nil {
+		return fmt.Errorf("error setting key 'version': %w", err)
+	}
+	if err := d.Set("branch", version.Branch); err != nil {
+		return fmt.Errorf("error setting key 'branch': %w", err)
+	}
+	if err := d.Set("build_url", version.BuildURL); err != nil {
+		return fmt.Errorf("error setting key 'build_url': %w", err)
+	}
+
+	// CI pipelines usually go on to tag the version themselves, so only the tags Terraform manages are tracked.
+	// An imported version manages all the tags it had when it was imported (see pacticipantVersionImport)
+	managed := d.Get("tags").(*schema.Set)
+	tags := make([]string, 0)
+	if version.Embedded != nil {
+		for _, tag := range version.Embedded.Tags {
+			if managed.Contains(tag.Name) {
+				tags = append(tags, tag.Name)
+			}
+		}
+	}
+	if err := d.Set("tags", tags); err != nil {
+		return fmt.Errorf("error setting key 'tags': %w", err)
+	}
+
+	return nil
+}
+
+func splitPacticipantVersionID(id string) (string, string, error) {
+	parts := strings.SplitN(id, "/", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", fmt.Errorf("invalid pacticipant version id %q, expected <pacticipant>/<version>", id)
+	}
+
+	return parts[0], parts[1], nil
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/pactflow/terraform/broker"
+)
+
+func TestSetPacticipantVersionState(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, pacticipantVersion().Schema, map[string]interface{}{
+		"pacticipant": "orders",
+		"version":     "1.0.0",
+		"tags":        []interface{}{"prod", "removed"},
+	})
+
+	err := setPacticipantVersionState(d, "orders", &broker.Version{
+		Number:   "1.0.0",
+		Branch:   "main",
+		Embedded: &broker.VersionEmbeddedItems{Tags: []broker.Tag{{Name: "prod"}, {Name: "added-by-ci"}}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tags := ExpandStringSet(d.Get("tags").(*schema.Set))
+	if len(tags) != 1 || tags[0] != "prod" {
+		t.Fatalf("expected only the managed tags still in the broker to be kept, got %v", tags)
+	}
+	if d.Get("branch") != "main" {
+		t.Fatalf("expected the branch to be read from the broker, got %v", d.Get("branch"))
+	}
+}
+
+func TestPacticipantVersionImport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/pacticipants/orders/versions/1.0.0" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/hal+json")
+		fmt.Fprint(w, `{"number": "1.0.0", "branch": "main", "_embedded": {"tags": [{"name": "prod"}, {"name": "added-by-ci"}]}}`)
+	}))
+	defer server.Close()
+
+	meta, err := configureProvider(schema.TestResourceDataRaw(t, Provider().Schema, map[string]interface{}{
+		"host":                        server.URL,
+		"skip_credentials_validation": true,
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	d := pacticipantVersion().Data(nil)
+	d.SetId("orders/1.0.0")
+
+	imported, err := pacticipantVersionImport(d, meta)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := pacticipantVersionRead(imported[0], meta); err != nil {
+		t.Fatal(err)
+	}
+
+	tags := ExpandStringSet(imported[0].Get("tags").(*schema.Set))
+	sort.Strings(tags)
+	if expected := []string{"added-by-ci", "prod"}; !reflect.DeepEqual(tags, expected) {
+		t.Fatalf("expected all of the version's tags to be imported, got %v", tags)
+	}
+}
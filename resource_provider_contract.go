@@ -0,0 +1,278 @@
+package main
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/pactflow/terraform/broker"
+)
+
+// Published contracts are immutable, so every user facing attribute forces a new publication
+func providerContract() *schema.Resource {
+	return &schema.Resource{
+		Create: providerContractCreate,
+		Read:   providerContractRead,
+		Delete: providerContractDelete,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultTimeout),
+			Read:   schema.DefaultTimeout(defaultTimeout),
+			Delete: schema.DefaultTimeout(defaultTimeout),
+		},
+		Importer: &schema.ResourceImporter{State: schema.ImportStatePassthrough},
+		Schema: map[string]*schema.Schema{
+			"provider_name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The name of the provider the contract belongs to",
+			},
+			"version": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The provider version the contract was generated from",
+			},
+			"branch": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "The branch of the provider version",
+			},
+			"tags": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				ForceNew: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Description: "Tags to apply to the provider version",
+			},
+			"build_url": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "The URL of the CI build that generated the contract",
+			},
+			"content": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The contract document (e.g. the OAS as YAML or JSON)",
+			},
+			"content_type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      "application/yaml",
+				ValidateFunc: validation.StringInSlice([]string{"application/yaml", "application/json"}, false),
+				Description:  "The content type of the contract document",
+			},
+			"specification": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     "oas",
+				Description: "The type of contract document",
+			},
+			"verification_success": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     false,
+				Description: "Whether the provider successfully verified itself against the contract",
+			},
+			"verification_results": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "The output of the provider verifying itself against the contract",
+			},
+			"verification_results_content_type": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     "text/plain",
+				Description: "The content type of the verification results",
+			},
+			"verification_results_format": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     "text",
+				Description: "The format of the verification results",
+			},
+			"verifier": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "The tool used to verify the provider (e.g. schemathesis, dredd)",
+			},
+			"verifier_version": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "The version of the verifier tool",
+			},
+		},
+	}
+}
+
+func parseProviderContract(d *schema.ResourceData) broker.ProviderContractPublishRequest {
+	request := broker.ProviderContractPublishRequest{
+		Provider:                 d.Get("provider_name").(string),
+		PacticipantVersionNumber: d.Get("version").(string),
+		Branch:                   d.Get("branch").(string),
+		Tags:                     ExpandStringSet(d.Get("tags").(*schema.Set)),
+		BuildURL:                 d.Get("build_url").(string),
+		Contract: broker.ProviderContract{
+			Content:       base64.StdEncoding.EncodeToString([]byte(d.Get("content").(string))),
+			ContentType:   d.Get("content_type").(string),
+			Specification: d.Get("specification").(string),
+			SelfVerificationResults: &broker.SelfVerificationResults{
+				Success:         d.Get("verification_success").(bool),
+				ContentType:     d.Get("verification_results_content_type").(string),
+				Format:          d.Get("verification_results_format").(string),
+				Verifier:        d.Get("verifier").(string),
+				VerifierVersion: d.Get("verifier_version").(string),
+			},
+		},
+	}
+
+	if results := d.Get("verification_results").(string); results != "" {
+		request.Contract.SelfVerificationResults.Content = base64.StdEncoding.EncodeToString([]byte(results))
+	}
+
+	return request
+}
+
+func providerContractCreate(d *schema.ResourceData, meta interface{}) error {
+	client, cancel := timeoutClient(d, meta, schema.TimeoutCreate)
+	defer cancel()
+
+	request := parseProviderContract(d)
+
+	logDebug("pact_provider_contract", d, "publishing provider contract", "provider", request.Provider, "version", request.PacticipantVersionNumber)
+
+	err := client.PublishProviderContract(request)
+
+	if err != nil {
+		return fmt.Errorf("error publishing %s: %w", describeResource("provider contract", d), err)
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s", request.Provider, request.PacticipantVersionNumber))
+
+	return nil
+}
+
+func providerContractRead(d *schema.ResourceData, meta interface{}) error {
+	client, cancel := timeoutClient(d, meta, schema.TimeoutRead)
+	defer cancel()
+
+	provider, version, err := splitProviderContractID(d.Id())
+
+	if err != nil {
+		return err
+	}
+
+	logDebug("pact_provider_contract", d, "reading provider contract")
+
+	contract, err := client.ReadProviderContract(provider, version)
+
+	if removeFromStateIfNotFound(d, err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("error reading %s: %w", describeResource("provider contract", d), err)
+	}
+
+	d.Set("provider_name", provider)
+	d.Set("version", version)
+
+	// Imported contracts only have the ID to go on, so populate the contract from the broker.
+	// Otherwise the configured values are kept, as the broker may normalise the document
+	if d.Get("content").(string) == "" {
+		setImportedProviderContractState(d, contract.ProviderContract)
+	}
+
+	return nil
+}
+
+func setImportedProviderContractState(d *schema.ResourceData, contract broker.ProviderContract) {
+	// Every attribute forces a new publication, so start from the defaults to stop an imported contract
+	// (or config generated from it) planning a replacement for values the broker doesn't return
+	for k, s := range providerContract().Schema {
+		if s.Default != nil {
+			d.Set(k, s.Default)
+		}
+	}
+
+	content := contract.Content
+	if decoded, err := base64.StdEncoding.DecodeString(content); err == nil {
+		content = string(decoded)
+	}
+
+	d.Set("content", content)
+	if contract.ContentType != "" {
+		d.Set("content_type", contract.ContentType)
+	}
+	if contract.Specification != "" {
+		d.Set("specification", contract.Specification)
+	}
+
+	results := contract.SelfVerificationResults
+	if results == nil {
+		return
+	}
+
+	d.Set("verification_success", results.Success)
+	if results.Content != "" {
+		content := results.Content
+		if decoded, err := base64.StdEncoding.DecodeString(content); err == nil {
+			content = string(decoded)
+		}
+		d.Set("verification_results", content)
+	}
+	if results.ContentType != "" {
+		d.Set("verification_results_content_type", results.ContentType)
+	}
+	if results.Format != "" {
+		d.Set("verification_results_format", results.Format)
+	}
+	d.Set("verifier", results.Verifier)
+	d.Set("verifier_version", results.VerifierVersion)
+}
+
+func providerContractDelete(d *schema.ResourceData, meta interface{}) error {
+	client, cancel := timeoutClient(d, meta, schema.TimeoutDelete)
+	defer cancel()
+
+	provider, version, err := splitProviderContractID(d.Id())
+
+	if err != nil {
+		return err
+	}
+
+	logDebug("pact_provider_contract", d, "deleting provider contract")
+
+	err = client.DeleteProviderContract(provider, version)
+
+	if err != nil {
+		return fmt.Errorf("error deleting %s: %w", describeResource("provider contract", d), err)
+	}
+
+	d.SetId("")
+
+	return nil
+}
+
+func splitProviderContractID(id string) (string, string, error) {
+	parts := strings.SplitN(id, "/", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", fmt.Errorf("invalid provider contract id %q, expected <provider>/<version>", id)
+	}
+
+	return parts[0], parts[1], nil
+}
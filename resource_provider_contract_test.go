@@ -0,0 +1,37 @@
+package main
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/pactflow/terraform/broker"
+)
+
+func TestSetImportedProviderContractState(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, providerContract().Schema, map[string]interface{}{})
+
+	setImportedProviderContractState(d, broker.ProviderContract{
+		Content:       base64.StdEncoding.EncodeToString([]byte("openapi: 3.0.1")),
+		ContentType:   "application/yaml",
+		Specification: "oas",
+		SelfVerificationResults: &broker.SelfVerificationResults{
+			Success:  true,
+			Content:  base64.StdEncoding.EncodeToString([]byte("Tests passed")),
+			Verifier: "schemathesis",
+		},
+	})
+
+	for k, expected := range map[string]interface{}{
+		"content":                           "openapi: 3.0.1",
+		"verification_success":              true,
+		"verification_results":              "Tests passed",
+		"verification_results_content_type": "text/plain",
+		"verification_results_format":       "text",
+		"verifier":                          "schemathesis",
+	} {
+		if actual := d.Get(k); actual != expected {
+			t.Fatalf("expected %s to be %v, got %v", k, expected, actual)
+		}
+	}
+}
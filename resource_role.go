@@ -2,12 +2,10 @@ package main
 
 import (
 	"fmt"
-	"log"
 	"sort"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/pactflow/terraform/broker"
-	"github.com/pactflow/terraform/client"
 )
 
 func role() *schema.Resource {
@@ -17,6 +15,7 @@ func role() *schema.Resource {
 		Read:     roleRead,
 		Update:   roleUpdate,
 		Delete:   roleDelete,
+		Timeouts: defaultTimeouts(),
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Type:        schema.TypeString,
@@ -86,7 +85,7 @@ func scopesFromUser(u broker.Role) []string {
 }
 
 func setRoleState(d *schema.ResourceData, r *broker.Role) error {
-	log.Printf("[DEBUG] setting role state: %v \n", r)
+	logDebug("pact_role", d, "setting role state", "role", r)
 
 	if err := d.Set("uuid", r.UUID); err != nil {
 		return fmt.Errorf("error creating key 'uuid': %w", err)
@@ -102,17 +101,40 @@ func setRoleState(d *schema.ResourceData, r *broker.Role) error {
 }
 
 func roleCreate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*client.Client)
+	client, cancel := timeoutClient(d, meta, schema.TimeoutCreate)
+	defer cancel()
+
 	role := getRoleFromState(d)
 
 	created, err := client.CreateRole(role)
 
+	if isConflict(err) && adoptExistingResources(meta) {
+		return adoptExisting(d, meta, "role", func() (string, error) {
+			res, err := client.ListRoles()
+			if err != nil {
+				return "", err
+			}
+			for _, r := range res.Roles {
+				if r.Name == role.Name {
+					return r.UUID, nil
+				}
+			}
+			return "", fmt.Errorf("no role named %q", role.Name)
+		}, roleUpdate)
+	}
 	if err != nil {
-		return fmt.Errorf("error creating role: %w", err)
+		return fmt.Errorf("error creating %s: %w", describeResource("role", d), err)
 	}
 
 	d.SetId(created.UUID)
 
+	if err := waitUntilVisible(client.Context(), d, "role", func() error {
+		_, err := client.ReadRole(created.UUID)
+		return err
+	}); err != nil {
+		return err
+	}
+
 	if err = setRoleState(d, created); err != nil {
 		return fmt.Errorf("error setting role state: %w", err)
 	}
@@ -121,11 +143,16 @@ func roleCreate(d *schema.ResourceData, meta interface{}) error {
 }
 
 func roleRead(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*client.Client)
+	client, cancel := timeoutClient(d, meta, schema.TimeoutRead)
+	defer cancel()
+
 	role, err := client.ReadRole(d.Id())
 
+	if removeFromStateIfNotFound(d, err) {
+		return nil
+	}
 	if err != nil {
-		return fmt.Errorf("error reading role: %w", err)
+		return fmt.Errorf("error reading %s: %w", describeResource("role", d), err)
 	}
 
 	if err = setRoleState(d, role); err != nil {
@@ -136,12 +163,22 @@ func roleRead(d *schema.ResourceData, meta interface{}) error {
 }
 
 func roleUpdate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*client.Client)
+	if !hasRemoteChanges(d, role().Schema) {
+		logDebug("pact_role", d, "no changes to send to the broker")
+		return nil
+	}
+
+	client, cancel := timeoutClient(d, meta, schema.TimeoutUpdate)
+	defer cancel()
+
 	role := getRoleFromState(d)
 	updated, err := client.UpdateRole(role)
 
+	if isNotFound(err) {
+		return recreateAfterNotFound(d, meta, "role", roleCreate)
+	}
 	if err != nil {
-		return fmt.Errorf("error updating role: %w", err)
+		return fmt.Errorf("error updating %s: %w", describeResource("role", d), err)
 	}
 
 	if err = setRoleState(d, updated); err != nil {
@@ -152,17 +189,19 @@ func roleUpdate(d *schema.ResourceData, meta interface{}) error {
 }
 
 func roleDelete(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*client.Client)
+	client, cancel := timeoutClient(d, meta, schema.TimeoutDelete)
+	defer cancel()
+
 	uuid := d.Get("uuid").(string)
 
-	log.Println("[DEBUG] deleting role for user with UUID:", uuid)
+	logDebug("pact_role", d, "deleting role for user", "user_uuid", uuid)
 
 	err := client.DeleteRole(broker.Role{
 		UUID: uuid,
 	})
 
 	if err != nil {
-		return fmt.Errorf("error deleting role: %w", err)
+		return fmt.Errorf("error deleting %s: %w", describeResource("role", d), err)
 	}
 
 	d.SetId("")
@@ -2,11 +2,9 @@ package main
 
 import (
 	"fmt"
-	"log"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/pactflow/terraform/broker"
-	"github.com/pactflow/terraform/client"
 )
 
 const (
@@ -34,6 +32,11 @@ func roleV1() *schema.Resource {
 		Create:             roleV1Create,
 		Read:               roleV1Read,
 		Delete:             roleV1Delete,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultTimeout),
+			Read:   schema.DefaultTimeout(defaultTimeout),
+			Delete: schema.DefaultTimeout(defaultTimeout),
+		},
 		Schema: map[string]*schema.Schema{
 			"role": {
 				Type:         schema.TypeString,
@@ -43,10 +46,11 @@ func roleV1() *schema.Resource {
 				ForceNew:     true,
 			},
 			"user": {
-				Type:        schema.TypeString,
-				Description: "UUID of the user of which to apply the role",
-				Required:    true,
-				ForceNew:    true,
+				Type:         schema.TypeString,
+				Description:  "UUID of the user of which to apply the role",
+				ValidateFunc: validateUUID,
+				Required:     true,
+				ForceNew:     true,
 			},
 			"name": {
 				Type:        schema.TypeString,
@@ -63,11 +67,13 @@ func roleV1() *schema.Resource {
 }
 
 func roleV1Create(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*client.Client)
+	client, cancel := timeoutClient(d, meta, schema.TimeoutCreate)
+	defer cancel()
+
 	userUUID := d.Get("user").(string)
 
 	// NOTE: we only support the admin role at this time
-	log.Println("[DEBUG] creating role for user with UUID:", userUUID)
+	logDebug("pact_role_v1", d, "creating role for user", "user_uuid", userUUID)
 	_, err := client.AddAdminRoleToUser(broker.User{
 		UUID: userUUID,
 	})
@@ -85,10 +91,12 @@ func roleV1Read(d *schema.ResourceData, meta interface{}) error {
 }
 
 func roleV1Delete(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*client.Client)
+	client, cancel := timeoutClient(d, meta, schema.TimeoutDelete)
+	defer cancel()
+
 	userUUID := d.Get("user").(string)
 
-	log.Println("[DEBUG] deleting role for user with UUID:", userUUID)
+	logDebug("pact_role_v1", d, "deleting role for user", "user_uuid", userUUID)
 
 	_, err := client.RemoveAdminRoleFromUser(broker.User{
 		UUID: userUUID,
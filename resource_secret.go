@@ -2,13 +2,10 @@ package main
 
 import (
 	"fmt"
-	"log"
 	"regexp"
-	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/pactflow/terraform/broker"
-	"github.com/pactflow/terraform/client"
 )
 
 var secretType = &schema.Schema{
@@ -19,11 +16,20 @@ var secretType = &schema.Schema{
 
 func secret() *schema.Resource {
 	return &schema.Resource{
-		Create:   secretCreate,
-		Update:   secretUpdate,
-		Read:     secretRead,
-		Delete:   secretDelete,
-		Importer: &schema.ResourceImporter{State: schema.ImportStatePassthrough},
+		Create:        secretCreate,
+		Update:        secretUpdate,
+		Read:          secretRead,
+		Delete:        secretDelete,
+		Timeouts:      defaultTimeouts(),
+		Importer:      &schema.ResourceImporter{State: schema.ImportStatePassthrough},
+		SchemaVersion: 1,
+		StateUpgraders: []schema.StateUpgrader{
+			{
+				Type:    secretV0().CoreConfigSchema().ImpliedType(),
+				Upgrade: secretStateUpgradeV0,
+				Version: 0,
+			},
+		},
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Type:         schema.TypeString,
@@ -37,10 +43,11 @@ func secret() *schema.Resource {
 				Description: "A longer description for the secret",
 			},
 			"value": {
-				Type:        schema.TypeString,
-				Required:    true,
-				Sensitive:   true,
-				Description: "The actual secret",
+				Type:             schema.TypeString,
+				Required:         true,
+				Sensitive:        true,
+				DiffSuppressFunc: suppressHashedValueDiff,
+				Description:      "The actual secret",
 			},
 			"uuid": {
 				Type:        schema.TypeString,
@@ -48,9 +55,10 @@ func secret() *schema.Resource {
 				Description: "The UUID of secret",
 			},
 			"team": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				Description: "The team this secret should be associated with (uuid). Leave empty for a non-team secret",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "The team this secret should be associated with (uuid). Leave empty for a non-team secret",
+				ValidateFunc: validateUUID,
 			},
 		},
 	}
@@ -65,7 +73,7 @@ func validateName(val interface{}, key string) (warns []string, errs []error) {
 }
 
 func parseSecret(d *schema.ResourceData, meta interface{}) (broker.Secret, error) {
-	log.Printf("[DEBUG] create or update secret with data %+v \n", d)
+	logDebug("pact_secret", d, "parsing secret")
 	name := d.Get("name").(string)
 	description := d.Get("description").(string)
 	value := d.Get("value").(string)
@@ -74,7 +82,7 @@ func parseSecret(d *schema.ResourceData, meta interface{}) (broker.Secret, error
 	secret := broker.Secret{
 		Name:        name,
 		Description: description,
-		Value:       value,
+		Value:       unhashedValue(value),
 		TeamUUID:    team,
 	}
 
@@ -87,83 +95,123 @@ func parseSecret(d *schema.ResourceData, meta interface{}) (broker.Secret, error
 }
 
 func secretCreate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*client.Client)
+	client, cancel := timeoutClient(d, meta, schema.TimeoutCreate)
+	defer cancel()
+
 	secret, _ := parseSecret(d, meta)
-	log.Println("[DEBUG] creating secret", secret)
+	logDebug("pact_secret", d, "creating secret", "secret", secret)
 
 	res, err := client.CreateSecret(secret)
 
-	if err == nil {
-		items := strings.Split(res.Links["self"].Href, "/")
-		id := items[len(items)-1]
-		d.SetId(id)
+	if isConflict(err) && adoptExistingResources(meta) {
+		return adoptExisting(d, meta, "secret", func() (string, error) {
+			res, err := client.ListSecrets()
+			if err != nil {
+				return "", err
+			}
+			for _, s := range res.Embedded.Secrets {
+				if s.Name == secret.Name && s.TeamUUID == secret.TeamUUID {
+					return idFromSelfLink(s.Links["self"].Href), nil
+				}
+			}
+			return "", fmt.Errorf("no secret named %q", secret.Name)
+		}, secretUpdate)
+	}
+	if err != nil {
+		return fmt.Errorf("error creating %s: %w", describeResource("secret", d), err)
+	}
 
-		return setSecretState(d, secret)
+	d.SetId(idFromSelfLink(res.Links["self"].Href))
+
+	if err := waitUntilVisible(client.Context(), d, "secret", func() error {
+		_, err := client.ReadSecret(d.Id())
+		return err
+	}); err != nil {
+		return err
 	}
 
-	return err
+	return setSecretState(d, secret)
 }
 
 func secretUpdate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*client.Client)
+	if !hasRemoteChanges(d, secret().Schema) {
+		logDebug("pact_secret", d, "no changes to send to the broker")
+		return nil
+	}
+
+	client, cancel := timeoutClient(d, meta, schema.TimeoutUpdate)
+	defer cancel()
+
 	secret, _ := parseSecret(d, meta)
 
-	log.Println("[DEBUG] updatding secret", secret)
+	logDebug("pact_secret", d, "updating secret", "secret", secret)
 
 	_, err := client.UpdateSecret(secret)
 
-	if err == nil {
-		return setSecretState(d, secret)
+	if isNotFound(err) {
+		// An unchanged value is only held as a hash, so there is nothing to create the secret with
+		if isHashedValue(d.Get("value").(string)) {
+			return recreateAfterNotFound(d, meta, "secret", nil)
+		}
+		return recreateAfterNotFound(d, meta, "secret", secretCreate)
+	}
+	if err != nil {
+		return fmt.Errorf("error updating %s: %w", describeResource("secret", d), err)
 	}
 
-	return err
+	return setSecretState(d, secret)
 }
 
 func secretRead(d *schema.ResourceData, meta interface{}) error {
-	httpClient := meta.(*client.Client)
+	httpClient, cancel := timeoutClient(d, meta, schema.TimeoutRead)
+	defer cancel()
 
 	secret, err := httpClient.ReadSecret(d.Id())
+	if removeFromStateIfNotFound(d, err) {
+		return nil
+	}
 	if err != nil {
-		return err
+		return fmt.Errorf("error reading %s: %w", describeResource("secret", d), err)
 	}
 
 	return setSecretState(d, secret.Secret)
 }
 
 func secretDelete(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*client.Client)
+	client, cancel := timeoutClient(d, meta, schema.TimeoutDelete)
+	defer cancel()
+
 	secret, _ := parseSecret(d, meta)
 
-	log.Println("[DEBUG] deleting secret", secret)
+	logDebug("pact_secret", d, "deleting secret", "secret", secret)
 
 	err := client.DeleteSecret(secret)
 
-	if err == nil {
-		d.SetId("")
+	if err != nil {
+		return fmt.Errorf("error deleting %s: %w", describeResource("secret", d), err)
 	}
 
-	return err
+	d.SetId("")
+
+	return nil
 }
 
 func setSecretState(d *schema.ResourceData, secret broker.Secret) error {
-	log.Printf("[DEBUG] setting secret state: %+v \n", secret)
+	logDebug("pact_secret", d, "setting secret state", "secret", secret)
 
 	d.Set("name", secret.Name)
 	d.Set("uuid", secret.UUID)
 	d.Set("description", secret.Description)
 	d.Set("team", secret.TeamUUID)
 
+	// The broker does not return the value, so only a hash of the configured value is kept in state
+	// to detect changes to it
 	if secret.Value != "" {
-		// First time, set the value
-		d.Set("value", secret.Value)
+		d.Set("value", hashSensitiveValue(secret.Value))
+	} else if original, ok := d.GetOk("value"); ok {
+		d.Set("value", hashSensitiveValue(original.(string)))
 	} else {
-		// Broker does not return the value, to prevent it always thinking the value is ""
-		// and requires an update, set to original
-		if original, ok := d.GetOk("value"); ok {
-			d.Set("value", original.(string))
-		} else {
-			log.Println("[DEBUG] could not find original value for 'value'")
-		}
+		logDebug("pact_secret", d, "could not find original value for 'value'")
 	}
 
 	return nil
@@ -2,7 +2,6 @@ package main
 
 import (
 	"fmt"
-	"log"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/pactflow/terraform/broker"
@@ -15,7 +14,8 @@ func team() *schema.Resource {
 		Update:   teamUpdate,
 		Read:     teamRead,
 		Delete:   teamDelete,
-		Importer: &schema.ResourceImporter{State: schema.ImportStatePassthrough},
+		Timeouts: defaultTimeouts(),
+		Importer: &schema.ResourceImporter{State: importWithDeletionProtection},
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Type:        schema.TypeString,
@@ -38,16 +38,19 @@ func team() *schema.Resource {
 				Type:     schema.TypeSet,
 				Optional: true,
 				Elem: &schema.Schema{
-					Type: schema.TypeString,
+					Type:         schema.TypeString,
+					ValidateFunc: validateUUID,
 				},
 			},
 			"administrators": {
 				Type:     schema.TypeSet,
 				Optional: true,
 				Elem: &schema.Schema{
-					Type: schema.TypeString,
+					Type:         schema.TypeString,
+					ValidateFunc: validateUUID,
 				},
 			},
+			"deletion_protection": deletionProtectionType,
 		},
 	}
 }
@@ -61,9 +64,8 @@ func getTeamFromResourceData(d *schema.ResourceData) broker.Team {
 	}
 
 	pacticipants := ExpandStringSet(d.Get("pacticipants").(*schema.Set))
-	log.Println("[DEBUG] resource_team.go pacticipants?", pacticipants)
+	logDebug("pact_team", d, "parsed team pacticipants", "pacticipants", pacticipants)
 	if len(pacticipants) > 0 {
-		log.Println("[DEBUG] resource_team.go have pacticipants", len(pacticipants), pacticipants)
 		items := make([]broker.Pacticipant, len(pacticipants))
 		for i, p := range pacticipants {
 			items[i] = broker.Pacticipant{
@@ -74,9 +76,8 @@ func getTeamFromResourceData(d *schema.ResourceData) broker.Team {
 	}
 
 	administrators := ExpandStringSet(d.Get("administrators").(*schema.Set))
-	log.Println("[DEBUG] resource_team.go administrators?", administrators)
+	logDebug("pact_team", d, "parsed team administrators", "administrators", administrators)
 	if len(administrators) > 0 {
-		log.Println("[DEBUG] resource_team.go have administrators", len(administrators), administrators)
 		items := make([]broker.User, len(administrators))
 		for i, p := range administrators {
 			items[i] = broker.User{
@@ -90,18 +91,17 @@ func getTeamFromResourceData(d *schema.ResourceData) broker.Team {
 }
 
 // Removes any users from the team that shouldn't be there, and adds those that should
-func assignTeamUsers(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*client.Client)
+func assignTeamUsers(d *schema.ResourceData, client *client.Client) error {
 	uuid := d.Id()
 
-	log.Println("[DEBUG] assigning users to team", uuid)
+	logDebug("pact_team", d, "assigning users to team", "uuid", uuid)
 
 	if d.HasChange("users") {
 		old, new := d.GetChange("users")
-		log.Println("[DEBUG] teamAssignmentCreate - change. old:", old, "new:", new)
+		logDebug("pact_team", d, "team users changed", "old", old.(*schema.Set).List(), "new", new.(*schema.Set).List())
 
 		usersToAdd := ExpandStringSet(new.(*schema.Set))
-		log.Println("[DEBUG] teamAssignmentCreate - setting users:", usersToAdd)
+		logDebug("pact_team", d, "setting team users", "users", usersToAdd)
 
 		req := broker.TeamsAssignmentRequest{
 			UUID:  uuid,
@@ -140,100 +140,148 @@ func teamToCRUDRequest(t broker.Team) broker.TeamCreateOrUpdateRequest {
 }
 
 func teamCreate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*client.Client)
+	client, cancel := timeoutClient(d, meta, schema.TimeoutCreate)
+	defer cancel()
+
 	team := getTeamFromResourceData(d)
 	create := teamToCRUDRequest(team)
 
-	log.Println("[DEBUG] creating team", team)
+	logDebug("pact_team", d, "creating team", "team", team)
 
 	created, err := client.CreateTeam(create)
 
+	if isConflict(err) && adoptExistingResources(meta) {
+		return adoptExisting(d, meta, "team", func() (string, error) {
+			res, err := client.ListTeams()
+			if err != nil {
+				return "", err
+			}
+			for _, t := range res.Teams {
+				if t.Name == team.Name {
+					return t.UUID, nil
+				}
+			}
+			return "", fmt.Errorf("no team named %q", team.Name)
+		}, teamUpdate)
+	}
 	if err != nil {
-		return fmt.Errorf("error creating team: %w", err)
+		return fmt.Errorf("error creating %s: %w", describeResource("team", d), err)
 	}
 
 	team.UUID = created.UUID
 	d.SetId(created.UUID)
+
+	if err := waitUntilVisible(client.Context(), d, "team", func() error {
+		_, err := client.ReadTeam(broker.Team{UUID: created.UUID})
+		return err
+	}); err != nil {
+		return err
+	}
 	setTeamState(d, *created)
 
-	err = assignTeamUsers(d, meta)
+	err = assignTeamUsers(d, client)
 	if err != nil {
 		d.Partial(true)
-		log.Printf("\n\n[DEBUG] error assigning team users: %v \n\n", err)
-		return fmt.Errorf("error assigning team users: %w", err)
+		logError("pact_team", d, "error assigning team users", "error", err)
+		return fmt.Errorf("error assigning users to %s: %w", describeResource("team", d), err)
 	}
 
 	return err
 }
 
 func teamUpdate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*client.Client)
+	if !hasRemoteChanges(d, team().Schema) {
+		logDebug("pact_team", d, "no changes to send to the broker")
+		return nil
+	}
+
+	client, cancel := timeoutClient(d, meta, schema.TimeoutUpdate)
+	defer cancel()
+
 	team := getTeamFromResourceData(d)
 	update := teamToCRUDRequest(team)
 
-	log.Println("[DEBUG] updating team", team)
+	logDebug("pact_team", d, "updating team", "team", team)
 
 	updated, err := client.UpdateTeam(update)
 
-	if err == nil {
-		setTeamState(d, *updated)
+	if isNotFound(err) {
+		// Users are only assigned when they change, so creating the team here would leave it empty
+		return recreateAfterNotFound(d, meta, "team", nil)
+	}
+	if err != nil {
+		return fmt.Errorf("error updating %s: %w", describeResource("team", d), err)
 	}
 
-	err = assignTeamUsers(d, meta)
+	setTeamState(d, *updated)
+
+	err = assignTeamUsers(d, client)
 	if err != nil {
 		d.Partial(true)
-		return fmt.Errorf("error assigning team users: %w", err)
+		return fmt.Errorf("error assigning users to %s: %w", describeResource("team", d), err)
 	}
 
 	return err
 }
 
 func teamRead(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*client.Client)
+	client, cancel := timeoutClient(d, meta, schema.TimeoutRead)
+	defer cancel()
+
 	read := getTeamFromResourceData(d)
 
-	log.Println("[DEBUG] reading team", read)
+	logDebug("pact_team", d, "reading team", "read", read)
 
 	team, err := client.ReadTeam(read)
 
-	log.Println("[DEBUG] have team for READ", team)
+	logDebug("pact_team", d, "read team", "team", team)
 
-	if err == nil {
-		d.SetId(team.UUID)
-		setTeamState(d, *team)
+	if removeFromStateIfNotFound(d, err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("error reading %s: %w", describeResource("team", d), err)
 	}
 
-	return err
+	d.SetId(team.UUID)
+
+	return setTeamState(d, *team)
 }
 
 func teamDelete(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*client.Client)
+	client, cancel := timeoutClient(d, meta, schema.TimeoutDelete)
+	defer cancel()
+
 	uuid := d.Id()
 	team := broker.Team{
 		UUID: uuid,
 	}
 
-	log.Println("[DEBUG] deleting team", team)
+	if err := checkDeletionProtection(d, "team"); err != nil {
+		return err
+	}
+
+	logDebug("pact_team", d, "deleting team", "team", team)
 
 	err := client.DeleteTeam(team)
 
 	if err != nil {
-		d.SetId("")
+		return fmt.Errorf("error deleting %s: %w", describeResource("team", d), err)
 	}
 
-	return err
+	d.SetId("")
+
+	return nil
 }
 
 func setTeamState(d *schema.ResourceData, team broker.Team) error {
-	log.Printf("[DEBUG] setting team state: %+v \n", team)
+	logDebug("pact_team", d, "setting team state", "team", team)
 
 	if err := d.Set("name", team.Name); err != nil {
-		log.Println("[ERROR] error setting key 'name'", err)
-		return err
+		return fmt.Errorf("error setting key 'name': %w", err)
 	}
 	if err := d.Set("uuid", team.UUID); err != nil {
-		log.Println("[ERROR] error setting key 'uuid'", err)
-		return err
+		return fmt.Errorf("error setting key 'uuid': %w", err)
 	}
 
 	if len(team.Embedded.Pacticipants) > 0 {
@@ -243,34 +291,31 @@ func setTeamState(d *schema.ResourceData, team broker.Team) error {
 		}
 
 		if err := d.Set("pacticipants", pacticipants); err != nil {
-			log.Println("[ERROR] error setting key 'pacticipants'", err)
-			return err
+			return fmt.Errorf("error setting key 'pacticipants': %w", err)
 		}
 	}
 
 	if len(team.Embedded.Members) > 0 {
 		members := make([]string, len(team.Embedded.Members))
 		for i, m := range team.Embedded.Members {
-			log.Println("[DEBUG] adding team member with UUID", m.UUID)
+			logDebug("pact_team", d, "adding team member", "user_uuid", m.UUID)
 			members[i] = m.UUID
 		}
 
 		if err := d.Set("users", members); err != nil {
-			log.Println("[ERROR] error setting key 'users'", err)
-			return err
+			return fmt.Errorf("error setting key 'users': %w", err)
 		}
 	}
 
 	if len(team.Embedded.Administrators) > 0 {
 		administrators := make([]string, len(team.Embedded.Administrators))
 		for i, a := range team.Embedded.Administrators {
-			log.Println("[DEBUG] adding administrator with UUID", a.UUID)
+			logDebug("pact_team", d, "adding administrator", "user_uuid", a.UUID)
 			administrators[i] = a.UUID
 		}
 
 		if err := d.Set("administrators", administrators); err != nil {
-			log.Println("[ERROR] error setting key 'administrators'", err)
-			return err
+			return fmt.Errorf("error setting key 'administrators': %w", err)
 		}
 	}
 
@@ -278,12 +323,11 @@ func setTeamState(d *schema.ResourceData, team broker.Team) error {
 }
 
 func setTeamAssignmentState(d *schema.ResourceData, team *broker.TeamsAssignmentResponse) error {
-	log.Printf("[DEBUG] setting team assignment state: %+v \n", team)
+	logDebug("pact_team", d, "setting team assignment state", "team", team)
 
 	if team != nil {
 		if err := d.Set("users", extractUsersFromAPIResponse(team)); err != nil {
-			log.Println("[ERROR] error setting key 'users'", err)
-			return err
+			return fmt.Errorf("error setting key 'users': %w", err)
 		}
 	}
 
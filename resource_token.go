@@ -2,7 +2,6 @@ package main
 
 import (
 	"fmt"
-	"log"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/pactflow/terraform/broker"
@@ -41,6 +40,7 @@ func token() *schema.Resource {
 		Update:             tokenUpdate,
 		Read:               tokenRead,
 		Delete:             tokenDelete,
+		Timeouts:           defaultTimeouts(),
 		Importer:           &schema.ResourceImporter{State: schema.ImportStatePassthrough},
 		Schema: map[string]*schema.Schema{
 			"name": {
@@ -83,7 +83,7 @@ func validateTokenType(val interface{}, key string) (warns []string, errs []erro
 }
 
 func parseToken(d *schema.ResourceData, meta interface{}) (apiTokenDefinition, error) {
-	log.Printf("[DEBUG] create or update token with data %+v \n", d)
+	logDebug("pact_token", d, "parsing token")
 	name := d.Get("name").(string)
 	tokenType := d.Get("type").(string)
 	description := d.Get("description").(string)
@@ -97,22 +97,24 @@ func parseToken(d *schema.ResourceData, meta interface{}) (apiTokenDefinition, e
 		Value:       value,
 	}
 
-	log.Printf("[DEBUG] Have a parsed token %+v \n", token)
+	logDebug("pact_token", d, "parsed token", "token", token)
 
 	return token, nil
 }
 
 // Basically just does a regenerate
 func tokenCreate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*client.Client)
+	httpClient, cancel := timeoutClient(d, meta, schema.TimeoutCreate)
+	defer cancel()
+
 	token, _ := parseToken(d, meta)
 
 	// If token UUID is empty, read from remote
 	if token.UUID == "" {
-		log.Println("[DEBUG] importing resource as no existing UUID was found")
-		t, err := client.FindTokenByType(token.Type)
+		logDebug("pact_token", d, "importing resource as no existing UUID was found")
+		t, err := httpClient.FindTokenByType(token.Type)
 		if err != nil {
-			return err
+			return fmt.Errorf("error finding %s: %w", describeResource("token", d), err)
 		}
 		token.UUID = t.UUID
 		// TF definition specific fields
@@ -129,25 +131,33 @@ func tokenCreate(d *schema.ResourceData, meta interface{}) error {
 
 // Regenerate
 func tokenUpdate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*client.Client)
+	if !hasRemoteChanges(d, token().Schema) {
+		logDebug("pact_token", d, "no changes to send to the broker")
+		return nil
+	}
+
+	httpClient, cancel := timeoutClient(d, meta, schema.TimeoutUpdate)
+	defer cancel()
+
 	token, _ := parseToken(d, meta)
 
-	log.Println("[DEBUG] updating (regenearting) token", token)
+	logDebug("pact_token", d, "updating (regenerating) token", "token", token)
+
+	updatedToken, err := httpClient.RegenerateToken(broker.APIToken{UUID: token.UUID})
 
-	updatedToken, err := client.RegenerateToken(broker.APIToken{UUID: token.UUID})
+	if err != nil {
+		return fmt.Errorf("error regenerating %s: %w", describeResource("token", d), err)
+	}
 
 	// At the moment, if you regenerate the access token - you need to use it for new requests!
+	// This must update the provider's client, as the one used here is a copy bound to the timeout
 	if token.Type == readWriteTokenType {
-		if client.Config.AccessToken != "" {
-			log.Println("[INFO] updating access token as read-write token was re-generated")
-			client.Config.AccessToken = updatedToken.Value
+		if providerClient := meta.(*client.Client); providerClient.Config.AccessToken != "" {
+			logInfo("pact_token", d, "updating access token as read-write token was re-generated")
+			providerClient.Config.AccessToken = updatedToken.Value
 		}
 	}
 
-	if err != nil {
-		return err
-	}
-
 	// TF definition specific fields
 	d.Set("type", token.Type)
 	d.Set("name", token.Name)
@@ -156,12 +166,17 @@ func tokenUpdate(d *schema.ResourceData, meta interface{}) error {
 }
 
 func tokenRead(d *schema.ResourceData, meta interface{}) error {
-	httpClient := meta.(*client.Client)
+	httpClient, cancel := timeoutClient(d, meta, schema.TimeoutRead)
+	defer cancel()
+
 	uuid := d.Id()
 
 	token, err := httpClient.ReadToken(uuid)
+	if removeFromStateIfNotFound(d, err) {
+		return nil
+	}
 	if err != nil {
-		return err
+		return fmt.Errorf("error reading %s: %w", describeResource("token", d), err)
 	}
 	return setTokenState(d, *token)
 }
@@ -169,14 +184,14 @@ func tokenRead(d *schema.ResourceData, meta interface{}) error {
 // Uncouples from broker
 func tokenDelete(d *schema.ResourceData, meta interface{}) error {
 
-	log.Println("[INFO] Deleting API token is currently a no-op, setting id to ''")
+	logInfo("pact_token", d, "deleting an API token is a no-op, removing it from state")
 	d.SetId("")
 
 	return nil
 }
 
 func setTokenState(d *schema.ResourceData, token broker.APIToken) error {
-	log.Printf("[DEBUG] setting token state: %+v \n", token)
+	logDebug("pact_token", d, "setting token state", "token", token)
 
 	d.SetId(token.UUID)
 	d.Set("description", token.Description)
@@ -2,11 +2,9 @@ package main
 
 import (
 	"fmt"
-	"log"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/pactflow/terraform/broker"
-	"github.com/pactflow/terraform/client"
 )
 
 func user() *schema.Resource {
@@ -15,6 +13,7 @@ func user() *schema.Resource {
 		Update:   userUpdate,
 		Read:     userRead,
 		Delete:   userDelete,
+		Timeouts: defaultTimeouts(),
 		Importer: &schema.ResourceImporter{State: schema.ImportStatePassthrough},
 		Schema: map[string]*schema.Schema{
 			"name": {
@@ -51,7 +50,8 @@ func user() *schema.Resource {
 				Optional:    true,
 				Description: "A list of roles (as uuids) to apply to the user",
 				Elem: &schema.Schema{
-					Type: schema.TypeString,
+					Type:         schema.TypeString,
+					ValidateFunc: validateUUID,
 				},
 			},
 		},
@@ -92,11 +92,13 @@ func validateUserType(val interface{}, key string) (warns []string, errs []error
 }
 
 func userCreate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*client.Client)
+	client, cancel := timeoutClient(d, meta, schema.TimeoutCreate)
+	defer cancel()
+
 	user := getUserFromState(d)
 
 	roles := ExpandStringSet(d.Get("roles").(*schema.Set))
-	log.Println("[DEBUG] creating user", user, roles)
+	logDebug("pact_user", d, "creating user", "user", user, "roles", roles)
 
 	var created *broker.User
 	var err error
@@ -107,14 +109,21 @@ func userCreate(d *schema.ResourceData, meta interface{}) error {
 	}
 
 	if err != nil {
-		return err
+		return fmt.Errorf("error creating %s: %w", describeResource("user", d), err)
 	}
 
 	d.SetId(created.UUID)
 
+	if err := waitUntilVisible(client.Context(), d, "user", func() error {
+		_, err := client.ReadUser(created.UUID)
+		return err
+	}); err != nil {
+		return err
+	}
+
 	setUserState(d, *created)
 
-	log.Println("[DEBUG] updating user roles", d.Id(), roles)
+	logDebug("pact_user", d, "updating user roles", "roles", roles)
 
 	err = client.SetUserRoles(d.Id(), broker.SetUserRolesRequest{
 		Roles: roles,
@@ -123,7 +132,7 @@ func userCreate(d *schema.ResourceData, meta interface{}) error {
 	if err != nil {
 		// Creating a user is a non-atomic transaction, because roles is a separate API call
 		d.Partial(true)
-		log.Println("[ERROR] error updating user roles", err)
+		logError("pact_user", d, "error updating user roles", "error", err)
 		return fmt.Errorf("error updating roles for user (%s / %s): %w", d.Id(), created.Email, err)
 	}
 
@@ -133,22 +142,29 @@ func userCreate(d *schema.ResourceData, meta interface{}) error {
 }
 
 func userUpdate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*client.Client)
+	if !hasRemoteChanges(d, user().Schema) {
+		logDebug("pact_user", d, "no changes to send to the broker")
+		return nil
+	}
+
+	client, cancel := timeoutClient(d, meta, schema.TimeoutUpdate)
+	defer cancel()
+
 	user := getUserFromState(d)
 
-	log.Println("[DEBUG] updating user", user)
+	logDebug("pact_user", d, "updating user", "user", user)
 
 	updated, err := client.UpdateUser(user)
 
 	if err != nil {
-		return err
+		return fmt.Errorf("error updating %s: %w", describeResource("user", d), err)
 	}
 
 	setUserState(d, *updated)
 
 	if d.HasChange("roles") {
 		roles := rolesFromStateChange(d)
-		log.Println("[DEBUG] updating user roles", roles)
+		logDebug("pact_user", d, "updating user roles", "roles", roles)
 
 		err = client.SetUserRoles(d.Id(), broker.SetUserRolesRequest{
 			Roles: roles,
@@ -156,8 +172,8 @@ func userUpdate(d *schema.ResourceData, meta interface{}) error {
 
 		if err != nil {
 			d.Partial(true) // updating users is non-atomic, let the diff applier know this
-			log.Println("[ERROR] error updating user roles", err)
-			return fmt.Errorf("error updating roles for user (%s): %s", d.Id(), err)
+			logError("pact_user", d, "error updating user roles", "error", err)
+			return fmt.Errorf("error updating roles for %s: %w", describeResource("user", d), err)
 		}
 
 		d.Set("roles", roles)
@@ -167,34 +183,42 @@ func userUpdate(d *schema.ResourceData, meta interface{}) error {
 }
 
 func userRead(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*client.Client)
+	client, cancel := timeoutClient(d, meta, schema.TimeoutRead)
+	defer cancel()
+
 	uuid := d.Id()
 
-	log.Println("[DEBUG] reading user", uuid)
+	logDebug("pact_user", d, "reading user", "uuid", uuid)
 
 	user, err := client.ReadUser(uuid)
 
-	if err == nil {
-		d.SetId(user.UUID)
-		setUserState(d, *user)
+	if removeFromStateIfNotFound(d, err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("error reading %s: %w", describeResource("user", d), err)
 	}
 
-	return err
+	d.SetId(user.UUID)
+
+	return setUserState(d, *user)
 }
 
 func userDelete(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*client.Client)
+	client, cancel := timeoutClient(d, meta, schema.TimeoutDelete)
+	defer cancel()
+
 	uuid := d.Id()
 
-	log.Println("[DEBUG] deleting user", d.Id())
+	logDebug("pact_user", d, "deleting user")
 
 	// TODO: Delete attached resources Roles and Teams, because a Users aren't deleted, but simply disabled
 	user, err := client.ReadUser(uuid)
 	if err != nil {
-		log.Println("[ERROR] unable to fetch user for delete", user)
+		logError("pact_user", d, "unable to fetch user for delete", "user", user)
 		return fmt.Errorf("unable to fetch user for delete: %w", err)
 	}
-	log.Println("[DEBUG] have user for delete", user)
+	logDebug("pact_user", d, "have user for delete", "user", user)
 
 	rolesToRemove := make([]string, len(user.Embedded.Roles))
 	for i, r := range user.Embedded.Roles {
@@ -229,31 +253,25 @@ func userDelete(d *schema.ResourceData, meta interface{}) error {
 }
 
 func setUserState(d *schema.ResourceData, user broker.User) error {
-	log.Printf("[DEBUG] setting user state: %+v \n", user)
+	logDebug("pact_user", d, "setting user state", "user", user)
 
 	if err := d.Set("name", user.Name); err != nil {
-		log.Println("[ERROR] error setting key 'name'", err)
-		return err
+		return fmt.Errorf("error setting key 'name': %w", err)
 	}
 	if err := d.Set("email", user.Email); err != nil {
-		log.Println("[ERROR] error setting key 'email'", err)
-		return err
+		return fmt.Errorf("error setting key 'email': %w", err)
 	}
 	if err := d.Set("active", user.Active); err != nil {
-		log.Println("[ERROR] error setting key 'active'", err)
-		return err
+		return fmt.Errorf("error setting key 'active': %w", err)
 	}
 	if err := d.Set("uuid", user.UUID); err != nil {
-		log.Println("[ERROR] error setting key 'uuid'", err)
-		return err
+		return fmt.Errorf("error setting key 'uuid': %w", err)
 	}
 	if err := d.Set("type", user.Type); err != nil {
-		log.Println("[ERROR] error setting key 'type'", err)
-		return err
+		return fmt.Errorf("error setting key 'type': %w", err)
 	}
 	if err := d.Set("roles", rolesFromUser(user)); err != nil {
-		log.Println("[ERROR] error setting key 'roles'", err)
-		return err
+		return fmt.Errorf("error setting key 'roles': %w", err)
 	}
 
 	return nil
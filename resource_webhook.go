@@ -1,9 +1,10 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"log"
 	"net/url"
 	"reflect"
 	"regexp"
@@ -11,6 +12,7 @@ import (
 	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
 	"github.com/mitchellh/mapstructure"
 	"github.com/pactflow/terraform/broker"
 	"github.com/pactflow/terraform/client"
@@ -25,17 +27,25 @@ var allowedEvents = []string{
 	"contract_requiring_verification_published",
 }
 
+// deprecatedEvents maps event names that older brokers and documentation used to the name the broker
+// now expects. They are still accepted, but a warning suggesting the replacement is shown at plan time,
+// as newer brokers silently ignore them and the webhook never fires
+var deprecatedEvents = map[string]string{
+	"contract_changed":                      "contract_content_changed",
+	"contract_changed_event":                "contract_content_changed",
+	"contract_content_changed_event":        "contract_content_changed",
+	"verification_published":                "provider_verification_published",
+	"provider_verification_published_event": "provider_verification_published",
+}
+
 var pacticipantType = &schema.Schema{
 	Type:     schema.TypeMap,
 	Optional: true,
-	Computed: true,
-	ForceNew: true,
 	Elem: &schema.Resource{
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Type:        schema.TypeString,
 				Required:    true,
-				ForceNew:    true,
 				Description: "A short description of the webhook",
 			},
 		},
@@ -75,35 +85,61 @@ var requestType = &schema.Schema{
 				Description: "An optional (basic auth) username to send with the request",
 			},
 			"password": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				Sensitive:   true,
-				Description: "An optional (basic auth) password to send with the request",
+				Type:             schema.TypeString,
+				Optional:         true,
+				Sensitive:        true,
+				DiffSuppressFunc: suppressHashedValueDiff,
+				Description:      "An optional (basic auth) password to send with the request",
 			},
 			"headers": {
-				Type:        schema.TypeMap,
-				Optional:    true,
-				Elem:        &schema.Schema{Type: schema.TypeString},
-				Description: "Request headers to send with the request",
+				Type:         schema.TypeMap,
+				Optional:     true,
+				Elem:         &schema.Schema{Type: schema.TypeString},
+				ValidateFunc: validateHeaders,
+				Description:  "Request headers to send with the request",
 			},
 			"body": {
 				Type:             schema.TypeString,
 				Optional:         true,
 				Description:      "A request body to send with the request",
 				DiffSuppressFunc: ignoreJSONFormatting,
+				StateFunc:        prettyJSONStateFunc,
+			},
+			"body_file": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"request.0.body"},
+				ValidateFunc:  validateBodyFile,
+				StateFunc:     bodyFileStateFunc,
+				Description:   "A file to read the request body from, instead of setting body. Changes to the file's content are detected through a hash of it",
+			},
+			"target_type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice(allowedTargetTypes, false),
+				Description:  "The system the webhook calls (slack, ms_teams or github_status), to check the body is one it accepts at plan time. Not sent to the broker",
 			},
 		},
 	},
 }
 
+// stringContains reports whether s contains searchterm. It scans s rather than sorting it, which would
+// reorder the caller's slice
 func stringContains(s []string, searchterm string) bool {
-	sort.Strings(s)
-	i := sort.SearchStrings(s, searchterm)
-	return i < len(s) && s[i] == searchterm
+	for _, v := range s {
+		if v == searchterm {
+			return true
+		}
+	}
+	return false
 }
 
 func validateEvents(val interface{}, key string) (warns []string, errs []error) {
 	v := val.(string)
+	if replacement, ok := deprecatedEvents[v]; ok {
+		warns = append(warns, fmt.Sprintf("%q: the event %q is deprecated and may be ignored by the broker, use %q instead", key, v, replacement))
+		return
+	}
 	if !stringContains(allowedEvents, v) {
 		errs = append(errs, fmt.Errorf("%q must be one of the allowed events %v, got %v", key, allowedEvents, v))
 	}
@@ -128,34 +164,139 @@ func validateMethod(val interface{}, key string) (warns []string, errs []error)
 	return
 }
 
+// headerNameRegexp matches a token as defined by RFC 7230 section 3.2.6, which a header name must be
+var headerNameRegexp = regexp.MustCompile("^[!#$%&'*+\\-.^_`|~0-9A-Za-z]+$")
+
+func validateHeaders(val interface{}, key string) (warns []string, errs []error) {
+	headers, ok := val.(map[string]interface{})
+	if !ok {
+		return
+	}
+
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if !headerNameRegexp.MatchString(name) {
+			errs = append(errs, fmt.Errorf("%q contains an invalid header name %q: header names may only contain letters, digits and !#$%%&'*+-.^_`|~ (no spaces, colons or other separators)", key, name))
+		} else if strings.Contains(name, "_") {
+			warns = append(warns, fmt.Sprintf("%q contains the header name %q: underscores are valid but some proxies (e.g. nginx) drop headers containing them by default, consider using hyphens", key, name))
+		}
+	}
+
+	return
+}
+
+// requireHTTPSWebhookURL enforces the provider's require_https_webhooks policy
+func requireHTTPSWebhookURL(d *schema.ResourceDiff, meta interface{}) error {
+	c, ok := meta.(*client.Client)
+	if !ok || !c.Config.RequireHTTPSWebhooks || !d.NewValueKnown("request.0.url") {
+		return nil
+	}
+
+	raw, _ := d.Get("request.0.url").(string)
+	u, err := url.Parse(raw)
+	if err != nil {
+		// Malformed URLs are reported by validateURL
+		return nil
+	}
+
+	if !strings.EqualFold(u.Scheme, "https") {
+		return fmt.Errorf("request.0.url: webhook URL %q must use https, as require_https_webhooks is enabled for the provider", raw)
+	}
+
+	return nil
+}
+
 func webhook() *schema.Resource {
 	return &schema.Resource{
-		Create:   webhookCreate,
-		Update:   webhookUpdate,
-		Read:     webhookRead,
-		Delete:   webhookDelete,
-		Importer: &schema.ResourceImporter{State: schema.ImportStatePassthrough},
-		Schema: map[string]*schema.Schema{
-			"description": {
-				Type:     schema.TypeString,
-				Optional: true,
-			},
-			"webhook_provider": pacticipantType,
-			"webhook_consumer": pacticipantType,
-			"request":          requestType,
-			"events":           eventsType,
-			"enabled": {
-				Type:     schema.TypeBool,
-				Default:  true,
-				Optional: true,
+		Create:        webhookCreate,
+		Update:        webhookUpdate,
+		Read:          webhookRead,
+		Delete:        webhookDelete,
+		Timeouts:      defaultTimeouts(),
+		Importer:      &schema.ResourceImporter{State: importWithDeletionProtection},
+		CustomizeDiff: allCustomizeDiffs(requireHTTPSWebhookURL, webhookCustomizeDiff, webhookTargetTypeCustomizeDiff, webhookETagCustomizeDiff, pactflowOnlyAttributes("team")),
+		SchemaVersion: 2,
+		StateUpgraders: []schema.StateUpgrader{
+			{
+				Type:    webhookV0().CoreConfigSchema().ImpliedType(),
+				Upgrade: webhookStateUpgradeV0,
+				Version: 0,
 			},
-			"team": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				Description: "The team this webhook should be associated with (uuid). Leave empty for a non-team Webhook",
+			{
+				Type:    webhookV1().CoreConfigSchema().ImpliedType(),
+				Upgrade: webhookStateUpgradeV1,
+				Version: 1,
 			},
 		},
+		Schema: webhookSchema(),
+	}
+}
+
+// webhookSchema is shared with webhookV1, as version 2 only changed how the ID is stored
+func webhookSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"description": {
+			Type:     schema.TypeString,
+			Optional: true,
+		},
+		"webhook_provider": pacticipantType,
+		"webhook_consumer": pacticipantType,
+		"request":          requestType,
+		"events":           eventsType,
+		"enabled": {
+			Type:     schema.TypeBool,
+			Default:  true,
+			Optional: true,
+		},
+		"team": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Description:  "The team this webhook should be associated with (uuid). Leave empty for a non-team Webhook",
+			ValidateFunc: validateUUID,
+		},
+		"deletion_protection": deletionProtectionType,
+		"uuid": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The UUID of the webhook",
+		},
+		"consumer_name": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The name of the consumer the broker has the webhook scoped to, empty when it fires for all consumers",
+		},
+		"provider_name": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The name of the provider the broker has the webhook scoped to, empty when it fires for all providers",
+		},
+		"etag": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The version of the webhook last read from the broker. Updates and deletes fail if it has changed since",
+		},
+	}
+}
+
+// webhookETagCustomizeDiff plans a new etag whenever the webhook will be updated, as the broker
+// versions it again
+func webhookETagCustomizeDiff(d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() == "" || len(d.GetChangedKeysPrefix("")) == 0 {
+		return nil
 	}
+
+	return d.SetNewComputed("etag")
+}
+
+// webhookChangedError explains that the webhook was changed in the broker (e.g. in the UI) between
+// reading it and applying the change, rather than overwriting or deleting whatever was changed
+func webhookChangedError(action string, d *schema.ResourceData, err error) error {
+	return fmt.Errorf("error %s %s, it was changed in the broker after it was last read. Run terraform plan to review the changes, then apply again: %w", action, describeResource("webhook", d), err)
 }
 
 func parseWebhook(d *schema.ResourceData, meta interface{}) (broker.Webhook, error) {
@@ -165,7 +306,7 @@ func parseWebhook(d *schema.ResourceData, meta interface{}) (broker.Webhook, err
 		Events:  []broker.WebhookEvent{},
 	}
 
-	log.Printf("[DEBUG] create or update webhook with data %+v \n", d)
+	logDebug("pact_webhook", d, "parsing webhook")
 
 	webhook.Description = d.Get("description").(string)
 
@@ -179,10 +320,10 @@ func parseWebhook(d *schema.ResourceData, meta interface{}) (broker.Webhook, err
 	// Provider
 	if rawProvider, ok := d.GetOk("webhook_provider"); ok {
 		provider := new(broker.Pacticipant)
-		log.Printf("[DEBUG] raw provider %+v \n", rawProvider)
+		logDebug("pact_webhook", d, "raw provider", "raw_provider", rawProvider)
 		err := mapstructure.Decode(rawProvider, provider)
 		if err != nil {
-			log.Println("[ERROR] error decoding webhook config: webhook_provider", err)
+			logError("pact_webhook", d, "error decoding webhook config: webhook_provider", "error", err)
 			return *webhook, err
 		}
 
@@ -194,10 +335,10 @@ func parseWebhook(d *schema.ResourceData, meta interface{}) (broker.Webhook, err
 	// Consumer
 	if rawConsumer, ok := d.GetOk("webhook_consumer"); ok {
 		consumer := new(broker.Pacticipant)
-		log.Printf("[DEBUG] raw consumer %+v \n", rawConsumer)
+		logDebug("pact_webhook", d, "raw consumer", "raw_consumer", rawConsumer)
 		err := mapstructure.Decode(rawConsumer, consumer)
 		if err != nil {
-			log.Println("[ERROR] error decoding webhook config: webhook_consumer", err)
+			logError("pact_webhook", d, "error decoding webhook config: webhook_consumer", "error", err)
 			return *webhook, err
 		}
 
@@ -210,7 +351,7 @@ func parseWebhook(d *schema.ResourceData, meta interface{}) (broker.Webhook, err
 	if eventsRaw, ok := d.GetOk("events"); ok {
 		events := eventsRaw.(*schema.Set)
 		for _, event := range ExpandStringSet(events) {
-			log.Printf("[DEBUG]event item %+v\n", event)
+			logDebug("pact_webhook", d, "event item", "event", event)
 			webhook.Events = append(webhook.Events, broker.WebhookEvent{
 				Name: event,
 			})
@@ -218,13 +359,13 @@ func parseWebhook(d *schema.ResourceData, meta interface{}) (broker.Webhook, err
 	}
 
 	// Request
-	log.Println("[DEBUG] checking request")
+	logDebug("pact_webhook", d, "checking request")
 	if rawRequest, ok := d.GetOk("request"); ok {
-		log.Printf("[DEBUG] have raw request of %+v \n", rawRequest)
+		logDebug("pact_webhook", d, "have raw request", "raw_request", rawRequest)
 
 		rawRequestList := rawRequest.([]interface{})
 		requestMap := rawRequestList[0].(map[string]interface{})
-		log.Printf("[DEBUG] have converted request %+v \n", requestMap)
+		logDebug("pact_webhook", d, "have converted request", "request_map", requestMap)
 
 		// Method
 		if method, ok := requestMap["method"]; ok {
@@ -236,9 +377,9 @@ func parseWebhook(d *schema.ResourceData, meta interface{}) (broker.Webhook, err
 			request.Username = username.(string)
 		}
 
-		// Username
+		// Password
 		if password, ok := requestMap["password"]; ok {
-			request.Password = password.(string)
+			request.Password = unhashedValue(password.(string))
 		}
 
 		// URL
@@ -251,38 +392,44 @@ func parseWebhook(d *schema.ResourceData, meta interface{}) (broker.Webhook, err
 			request.Headers = make(map[string]string)
 			if headers, ok := headers.(map[string]interface{}); ok {
 				for k, v := range headers {
-					fmt.Println("[DEBUG] Key", k, "Value", v, "Type", reflect.TypeOf(v))
 					request.Headers[k] = v.(string)
 				}
 			} else {
 				err := fmt.Errorf("unable parse request headers into a map[string]interface, got %v", reflect.TypeOf(requestMap["headers"]))
-				log.Print("[ERROR] error", err)
+				logError("pact_webhook", d, "error parsing webhook headers", "error", err)
 				return *webhook, err
 			}
 		} else {
-			log.Printf("[ERROR] 'headers' is a required field")
+			logError("pact_webhook", d, "'headers' is a required field")
 			return *webhook, fmt.Errorf("headers is a mandatory field")
 		}
 
 		// Body
-		if body, ok := requestMap["body"]; ok {
+		body, ok := requestMap["body"].(string)
+		if file, _ := requestMap["body_file"].(string); file != "" {
+			content, err := readBodyFile(file)
+			if err != nil {
+				return *webhook, err
+			}
+			body, ok = content, true
+		}
+		if ok {
 			// parse JSON into an intermediate object if possible, as this will avoid double escaping of the
 			// JSON (e.g. quotes) when it's sent over the wire
-			var i interface{}
-			err := json.Unmarshal([]byte(body.(string)), &i)
+			i, err := decodeJSON(body)
 			if err != nil {
-				log.Println("[DEBUG] unable to parse JSON, default to string")
-				request.Body = body.(string)
+				logDebug("pact_webhook", d, "unable to parse JSON, default to string")
+				request.Body = body
 			} else {
 				request.Body = i
 			}
 		}
 
-		log.Printf("[DEBUG] have fully serialised request %+v \n", request)
+		logDebug("pact_webhook", d, "have fully serialised request", "request", request)
 
 		webhook.Request = *request
 	} else {
-		log.Println("[ERROR] request attribute not found")
+		logError("pact_webhook", d, "request attribute not found")
 		return *webhook, fmt.Errorf("request is a mandatory field")
 	}
 
@@ -295,28 +442,44 @@ func parseWebhook(d *schema.ResourceData, meta interface{}) (broker.Webhook, err
 }
 
 func setWebhookState(d *schema.ResourceData, webhook broker.Webhook) error {
-	log.Printf("[DEBUG] setting webhook state: %+v \n", webhook)
+	logDebug("pact_webhook", d, "setting webhook state", "webhook", webhook)
 	if err := d.Set("description", webhook.Description); err != nil {
-		log.Println("[ERROR] error setting key 'description'", err)
-		return err
+		return fmt.Errorf("error setting key 'description': %w", err)
 	}
 
 	if err := d.Set("enabled", webhook.Enabled); err != nil {
-		log.Println("[ERROR] error setting key 'enabled'", err)
-		return err
+		return fmt.Errorf("error setting key 'enabled': %w", err)
 	}
 
 	if err := d.Set("team", webhook.TeamUUID); err != nil {
-		log.Println("[ERROR] error setting key 'team'", err)
-		return err
+		return fmt.Errorf("error setting key 'team': %w", err)
+	}
+
+	if err := d.Set("uuid", d.Id()); err != nil {
+		return fmt.Errorf("error setting key 'uuid': %w", err)
+	}
+
+	consumerName, providerName := "", ""
+	if webhook.Consumer != nil {
+		consumerName = webhook.Consumer.Name
+	}
+	if webhook.Provider != nil {
+		providerName = webhook.Provider.Name
+	}
+
+	if err := d.Set("consumer_name", consumerName); err != nil {
+		return fmt.Errorf("error setting key 'consumer_name': %w", err)
+	}
+
+	if err := d.Set("provider_name", providerName); err != nil {
+		return fmt.Errorf("error setting key 'provider_name': %w", err)
 	}
 
 	if webhook.Consumer != nil {
 		if err := d.Set("webhook_consumer", map[string]interface{}{
 			"name": webhook.Consumer.Name,
 		}); err != nil {
-			log.Println("[ERROR] error setting key 'webhook_consumer'", err)
-			return err
+			return fmt.Errorf("error setting key 'webhook_consumer': %w", err)
 		}
 	} else {
 		d.Set("webhook_consumer", nil)
@@ -326,21 +489,18 @@ func setWebhookState(d *schema.ResourceData, webhook broker.Webhook) error {
 		if err := d.Set("webhook_provider", map[string]interface{}{
 			"name": webhook.Provider.Name,
 		}); err != nil {
-			log.Println("[ERROR] error setting key 'webhook_provider", err)
-			return err
+			return fmt.Errorf("error setting key 'webhook_provider': %w", err)
 		}
 	} else {
 		d.Set("webhook_provider", nil)
 	}
 
 	if err := d.Set("events", flattenEvents(webhook)); err != nil {
-		log.Println("[ERROR] error setting key 'events'", err)
-		return err
+		return fmt.Errorf("error setting key 'events': %w", err)
 	}
 
 	if err := d.Set("request", flattenRequest(d, webhook.Request)); err != nil {
-		log.Println("[ERROR] error setting key 'request'", err)
-		return err
+		return fmt.Errorf("error setting key 'request': %w", err)
 	}
 	return nil
 }
@@ -363,31 +523,38 @@ func flattenRequest(d *schema.ResourceData, r broker.Request) []interface{} {
 	m["method"] = r.Method
 	m["username"] = r.Username
 
-	if r.Password != "" && !strings.HasPrefix(r.Password, "*****") {
-		// First time, set the value
-		log.Println("[DEBUG] setting webhook password")
-		m["password"] = r.Password
-	} else {
-		// Broker obscures the value to "******", set the value to what it was previously
-		// to prevent it always thinking the value is ""
-		if original, ok := d.GetOk("request.0.password"); ok {
-			m["password"] = original.(string)
-		} else {
-			log.Println("[DEBUG] could not find original value for 'password'")
-		}
+	// The broker never returns the password (it is masked), so the state only ever holds a hash of
+	// the configured value. An imported webhook has no password in state, so it is sent on the next apply
+	if original, ok := d.GetOk("request.0.password"); ok {
+		m["password"] = hashSensitiveValue(original.(string))
 	}
 	m["headers"] = mapStringStringToMapStringInterface(r.Headers) // TODO
 
+	// The target type only exists in the configuration, to validate the body against
+	if targetType, ok := d.GetOk("request.0.target_type"); ok {
+		m["target_type"] = targetType.(string)
+	}
+
+	// A body read from a file is tracked by the file's hash, so only the body_file is kept in state.
+	// On create and update the configured path is returned (without the hash), so hash it again
+	if file, ok := d.GetOk("request.0.body_file"); ok {
+		m["body_file"] = file.(string)
+		if !bodyFileHashSuffix.MatchString(file.(string)) {
+			m["body_file"] = bodyFileStateFunc(file)
+		}
+		return []interface{}{m}
+	}
+
 	// We want to store the body as a string in the state file
 	// Try to parse body into JSON, fallback to a string if not
 	if bodyAsStr, ok := r.Body.(string); ok {
-		log.Println("[DEBUG] parsed webhook body as string", bodyAsStr)
+		logDebug("pact_webhook", d, "parsed webhook body as string", "body", bodyAsStr)
 		m["body"] = bodyAsStr
 	} else if bytes, err := json.Marshal(r.Body); err == nil {
-		log.Println("[DEBUG] parsed webhook body as JSON", string(bytes))
-		m["body"] = string(bytes)
+		logDebug("pact_webhook", d, "parsed webhook body as JSON", "body", string(bytes))
+		m["body"] = prettyJSON(string(bytes))
 	} else {
-		log.Println("[DEBUG] unable to parse the body as a JSON string or a plain string!")
+		logDebug("pact_webhook", d, "unable to parse the body as a JSON string or a plain string!")
 	}
 
 	return []interface{}{m}
@@ -402,99 +569,218 @@ func mapStringStringToMapStringInterface(in map[string]string) map[string]interf
 	return out
 }
 
+// webhookAPI is the part of the broker client the webhook resource depends on. The provider's
+// *client.Client satisfies it, and tests can pass any other implementation as meta to exercise the
+// resource without HTTP
+type webhookAPI interface {
+	CreateWebhook(w broker.Webhook) (*broker.WebhookResponse, error)
+	ReadWebhook(id string) (*broker.WebhookResponse, error)
+	UpdateWebhook(w broker.Webhook) (*broker.WebhookResponse, error)
+	DeleteWebhook(w broker.Webhook) error
+	Context() context.Context
+}
+
+var _ webhookAPI = (*client.Client)(nil)
+
+// webhookClient returns the webhook API for an operation. The provider's client is bounded by the
+// operation's timeout, and sends the etag (if there is one) with its updates and deletes. Any other
+// implementation is used as is
+func webhookClient(d *schema.ResourceData, meta interface{}, operation, etag string) (webhookAPI, context.CancelFunc) {
+	c, ok := meta.(*client.Client)
+	if !ok {
+		return meta.(webhookAPI), func() {}
+	}
+
+	bounded, cancel := timeoutClient(d, c, operation)
+	return bounded.WithIfMatch(etag), cancel
+}
+
 func webhookCreate(d *schema.ResourceData, meta interface{}) error {
-	httpClient := meta.(*client.Client)
+	httpClient, cancel := webhookClient(d, meta, schema.TimeoutCreate, "")
+	defer cancel()
+
 	webhook, err := parseWebhook(d, meta)
 	if err != nil {
 		return err
 	}
 
+	unlock := lockPacticipants(webhookPacticipantName(webhook.Consumer), webhookPacticipantName(webhook.Provider))
+	defer unlock()
+
 	res, err := httpClient.CreateWebhook(webhook)
-	log.Printf("[DEBUG] response from creating webhook %+v\n", res)
+	logDebug("pact_webhook", d, "response from creating webhook", "response", res)
 
 	if err == nil {
-		items := strings.Split(res.Links["self"].Href, "/")
-		id := items[len(items)-1]
-		d.SetId(id)
+		d.SetId(idFromSelfLink(res.Links["self"].Href))
+
+		if err := waitUntilVisible(httpClient.Context(), d, "webhook", func() error {
+			_, err := httpClient.ReadWebhook(d.Id())
+			return err
+		}); err != nil {
+			return err
+		}
+
+		if err := d.Set("etag", res.ETag); err != nil {
+			return fmt.Errorf("error setting key 'etag': %w", err)
+		}
 
 		return setWebhookState(d, webhook)
 	}
 
-	log.Println("[ERROR] webhook creation failed", err)
+	logError("pact_webhook", d, "webhook creation failed", "error", err)
 	d.SetId("")
-	return err
+	return fmt.Errorf("error creating %s: %w", describeResource("webhook", d), err)
 }
 
 func webhookUpdate(d *schema.ResourceData, meta interface{}) error {
-	httpClient := meta.(*client.Client)
+	if !hasRemoteChanges(d, webhook().Schema, "request") && !webhookRequestChanged(d) {
+		logDebug("pact_webhook", d, "no changes to send to the broker")
+
+		// Nothing was sent, so the broker's version of the webhook is unchanged
+		etag, _ := d.GetChange("etag")
+		return d.Set("etag", etag)
+	}
+
+	// A new etag is planned, so the one read before the plan is the old value
+	etag, _ := d.GetChange("etag")
+	httpClient, cancel := webhookClient(d, meta, schema.TimeoutUpdate, etag.(string))
+	defer cancel()
+
 	webhook, err := parseWebhook(d, meta)
 	if err != nil {
 		return err
 	}
 
+	unlock := lockPacticipants(webhookPacticipantName(webhook.Consumer), webhookPacticipantName(webhook.Provider))
+	defer unlock()
+
 	res, err := httpClient.UpdateWebhook(webhook)
-	log.Printf("[DEBUG] response from updating webhook %+v\n", res)
+	logDebug("pact_webhook", d, "response from updating webhook", "response", res)
+
+	if isNotFound(err) {
+		unlock()
 
+		// An unchanged password is only held as a hash, so the webhook can't be created as configured
+		if isHashedValue(d.Get("request.0.password").(string)) {
+			return recreateAfterNotFound(d, meta, "webhook", nil)
+		}
+		return recreateAfterNotFound(d, meta, "webhook", webhookCreate)
+	}
+	if errors.Is(err, client.ErrPreconditionFailed) {
+		return webhookChangedError("updating", d, err)
+	}
 	if err != nil {
-		log.Println("[ERROR] webhook creation failed", err)
-		d.SetId("")
+		return fmt.Errorf("error updating %s: %w", describeResource("webhook", d), err)
 	}
-	d.Set("webhook", res)
 
-	return nil
+	if err := d.Set("etag", res.ETag); err != nil {
+		return fmt.Errorf("error setting key 'etag': %w", err)
+	}
+
+	return setWebhookState(d, webhook)
+}
+
+// webhookRequestChanged compares the request semantically, so that a reformatted body alone is
+// not treated as a change
+func webhookRequestChanged(d *schema.ResourceData) bool {
+	if !d.HasChange("request") {
+		return false
+	}
+
+	old, new := d.GetChange("request")
+	oldList, newList := old.([]interface{}), new.([]interface{})
+	if len(oldList) != 1 || len(newList) != 1 {
+		return len(oldList) != len(newList)
+	}
+
+	oldRequest, _ := oldList[0].(map[string]interface{})
+	newRequest, _ := newList[0].(map[string]interface{})
+	if len(oldRequest) != len(newRequest) {
+		return true
+	}
+
+	for k, v := range newRequest {
+		if k == "target_type" {
+			continue
+		}
+		if k == "body" {
+			oldBody, _ := oldRequest[k].(string)
+			newBody, _ := v.(string)
+			if !ignoreJSONFormatting(k, oldBody, newBody, d) {
+				return true
+			}
+			continue
+		}
+		if !reflect.DeepEqual(oldRequest[k], v) {
+			return true
+		}
+	}
+
+	return false
 }
 
 func webhookRead(d *schema.ResourceData, meta interface{}) error {
-	httpClient := meta.(*client.Client)
+	httpClient, cancel := webhookClient(d, meta, schema.TimeoutRead, "")
+	defer cancel()
+
 	res, err := httpClient.ReadWebhook(d.Id())
-	log.Printf("[DEBUG] response from reading webhook %+v\n", res)
+	logDebug("pact_webhook", d, "response from reading webhook", "response", res)
 
-	if err != nil {
-		log.Println("[ERROR] webhook read failed", err)
-		d.SetId("")
+	if removeFromStateIfNotFound(d, err) {
 		return nil
 	}
-	return setWebhookState(d, *res)
+	if err != nil {
+		return fmt.Errorf("error reading %s: %w", describeResource("webhook", d), err)
+	}
+
+	// Older versions of the provider could store an ID that isn't the broker's UUID (e.g. a legacy
+	// numeric ID, or one with a trailing slash), so always use the UUID from the self link
+	if self, ok := res.Links["self"]; ok && self.Href != "" {
+		if id := idFromSelfLink(self.Href); id != d.Id() {
+			logInfo("pact_webhook", d, "updating webhook ID", "new_id", id)
+			d.SetId(id)
+		}
+	}
+
+	if err := d.Set("etag", res.ETag); err != nil {
+		return fmt.Errorf("error setting key 'etag': %w", err)
+	}
+
+	return setWebhookState(d, res.Webhook)
 }
 
 func webhookDelete(d *schema.ResourceData, meta interface{}) error {
-	log.Printf("[DEBUG] deleting webhook with data %+v\n", d)
-	httpClient := meta.(*client.Client)
+	httpClient, cancel := webhookClient(d, meta, schema.TimeoutDelete, d.Get("etag").(string))
+	defer cancel()
+
+	if err := checkDeletionProtection(d, "webhook"); err != nil {
+		return err
+	}
 	webhook, err := parseWebhook(d, meta)
 	if err != nil {
 		return err
 	}
 
-	log.Println("[DEBUG] deleting webhook", webhook)
+	logDebug("pact_webhook", d, "deleting webhook", "webhook", webhook)
 
 	err = httpClient.DeleteWebhook(webhook)
-	if err == nil {
-		d.SetId("")
+	if errors.Is(err, client.ErrPreconditionFailed) {
+		return webhookChangedError("deleting", d, err)
 	}
-
-	return err
-}
-
-func tryParseJSONObject(s string) interface{} {
-	log.Println("[DEBUG] checking if", s, "is a JSON string")
-	var i interface{}
-	err := json.Unmarshal([]byte(s), &i)
-
 	if err != nil {
-		log.Println("[DEBUG] input body is not JSON")
-		return nil
+		return fmt.Errorf("error deleting %s: %w", describeResource("webhook", d), err)
 	}
 
-	return i
+	d.SetId("")
+
+	return nil
 }
 
 func ignoreJSONFormatting(k, old, new string, d *schema.ResourceData) bool {
-	// old = strings.TrimSpace(tryParseJSONString(old))
-	// new = strings.TrimSpace(tryParseJSONString(new))
-	log.Println("[DEBUG] checking if we should ignore white space and JSON formatting", old, new)
+	logDebug("pact_webhook", d, "checking if we should ignore white space and JSON formatting", "old", old, "new", new)
 
-	if tryParseJSONObject(old) != nil && reflect.DeepEqual(tryParseJSONObject(old), tryParseJSONObject(new)) {
-		log.Println("[DEBUG] JSON bodies are identical")
+	if jsonEqual(old, new) {
+		logDebug("pact_webhook", d, "JSON bodies are identical")
 		return true
 	}
 
@@ -0,0 +1,257 @@
+package main
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/pactflow/terraform/client"
+)
+
+// webhookV0 is the schema prior to version 1, used only to decode old states for upgrading. It is a
+// frozen copy, so changes to the live schema don't change how version 0 states are decoded
+func webhookV0() *schema.Resource {
+	pacticipant := &schema.Schema{
+		Type:     schema.TypeMap,
+		Optional: true,
+		Computed: true,
+		ForceNew: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"name": {
+					Type:     schema.TypeString,
+					Required: true,
+					ForceNew: true,
+				},
+			},
+		},
+	}
+
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"webhook_provider": pacticipant,
+			"webhook_consumer": pacticipant,
+			"request": {
+				Type:     schema.TypeList,
+				MaxItems: 1,
+				Required: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"url": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"method": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"username": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"password": {
+							Type:      schema.TypeString,
+							Optional:  true,
+							Sensitive: true,
+						},
+						"headers": {
+							Type:     schema.TypeMap,
+							Optional: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+						"body": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+					},
+				},
+			},
+			"events": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"enabled": {
+				Type:     schema.TypeBool,
+				Default:  true,
+				Optional: true,
+			},
+			"team": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+		},
+	}
+}
+
+// Version 0 copied the password from the broker unless it looked masked, so a state could end up
+// holding the masked value. Version 1 only ever stores a hash of the configured password, so drop any
+// masked value (letting the next apply send the configured password again) and hash the rest
+func webhookStateUpgradeV0(rawState map[string]interface{}, meta interface{}) (map[string]interface{}, error) {
+	requests, ok := rawState["request"].([]interface{})
+	if !ok {
+		return rawState, nil
+	}
+
+	for _, r := range requests {
+		request, ok := r.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		password, ok := request["password"].(string)
+		if !ok {
+			continue
+		}
+
+		if strings.HasPrefix(password, "*****") {
+			logDebug("pact_webhook", nil, "removing masked webhook password from state")
+			request["password"] = ""
+		} else {
+			request["password"] = hashSensitiveValue(password)
+		}
+	}
+
+	return rawState, nil
+}
+
+// webhookV1 is the schema prior to version 2, used only to decode old states for upgrading. It is a
+// frozen copy of the last version 1 schema, which the earlier version 1 states are a subset of
+func webhookV1() *schema.Resource {
+	pacticipant := &schema.Schema{
+		Type:     schema.TypeMap,
+		Optional: true,
+		ForceNew: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"name": {
+					Type:     schema.TypeString,
+					Required: true,
+					ForceNew: true,
+				},
+			},
+		},
+	}
+
+	return &schema.Resource{
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultTimeout),
+			Read:   schema.DefaultTimeout(defaultTimeout),
+			Update: schema.DefaultTimeout(defaultTimeout),
+			Delete: schema.DefaultTimeout(defaultTimeout),
+		},
+		Schema: map[string]*schema.Schema{
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"webhook_provider": pacticipant,
+			"webhook_consumer": pacticipant,
+			"request": {
+				Type:     schema.TypeList,
+				MaxItems: 1,
+				Required: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"url": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"method": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"username": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"password": {
+							Type:      schema.TypeString,
+							Optional:  true,
+							Sensitive: true,
+						},
+						"headers": {
+							Type:     schema.TypeMap,
+							Optional: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+						"body": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+					},
+				},
+			},
+			"events": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"enabled": {
+				Type:     schema.TypeBool,
+				Default:  true,
+				Optional: true,
+			},
+			"team": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"deletion_protection": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"uuid": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"consumer_name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"provider_name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+// Version 1 used the last segment of the webhook's self link as the ID as is, so the ID could be a full
+// link, have a trailing slash or be a legacy numeric ID rather than the broker's UUID. Normalise the ID,
+// and look up the UUID for numeric IDs (if the broker can't be reached, webhookRead fixes it on refresh)
+func webhookStateUpgradeV1(rawState map[string]interface{}, meta interface{}) (map[string]interface{}, error) {
+	id, ok := rawState["id"].(string)
+	if !ok || id == "" {
+		return rawState, nil
+	}
+
+	canonical := idFromSelfLink(id)
+
+	if _, err := strconv.Atoi(canonical); err == nil {
+		if c, ok := meta.(*client.Client); ok && c != nil {
+			c, cancel := boundedClient(c)
+			res, err := c.ReadWebhook(canonical)
+			cancel()
+			if err == nil && res.Links["self"].Href != "" {
+				canonical = idFromSelfLink(res.Links["self"].Href)
+			} else {
+				logWarn("pact_webhook", nil, "unable to resolve the UUID for legacy webhook ID", "legacy_id", id, "error", err)
+			}
+		}
+	}
+
+	if canonical != id {
+		logDebug("pact_webhook", nil, "upgrading webhook ID", "from", id, "to", canonical)
+		rawState["id"] = canonical
+		if _, ok := rawState["uuid"]; ok {
+			rawState["uuid"] = canonical
+		}
+	}
+
+	return rawState, nil
+}
@@ -0,0 +1,180 @@
+package main
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	ctyjson "github.com/zclconf/go-cty/cty/json"
+)
+
+// webhookStateV0 is a webhook's state as written by version 0 of the schema, with the masked password
+// it could copy from the broker
+const webhookStateV0 = `{
+	"id": "2e4bf0e6-b0cf-451f-b05b-69048955f019",
+	"description": "a webhook",
+	"webhook_provider": {"name": "Bar"},
+	"webhook_consumer": {"name": "Foo"},
+	"request": [{
+		"url": "https://example.com/hooks",
+		"method": "POST",
+		"username": "user",
+		"password": "*****",
+		"headers": {"Content-Type": "application/json"},
+		"body": "{\"a\":1}"
+	}],
+	"events": ["contract_content_changed"],
+	"enabled": true,
+	"team": ""
+}`
+
+// decodeState decodes a raw JSON state as Terraform does for the given schema version, failing the test
+// if the state doesn't fit it, and returns it as the upgraders receive it
+func decodeState(t *testing.T, state string, r *schema.Resource) map[string]interface{} {
+	t.Helper()
+
+	if _, err := ctyjson.Unmarshal([]byte(state), r.CoreConfigSchema().ImpliedType()); err != nil {
+		t.Fatalf("unable to decode the state with the schema: %s", err)
+	}
+
+	var rawState map[string]interface{}
+	if err := json.Unmarshal([]byte(state), &rawState); err != nil {
+		t.Fatal(err)
+	}
+
+	return rawState
+}
+
+func encodeState(t *testing.T, rawState map[string]interface{}) string {
+	t.Helper()
+
+	b, err := json.Marshal(rawState)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return string(b)
+}
+
+func TestWebhookStateUpgradeV0_RawState(t *testing.T) {
+	upgraded, err := webhookStateUpgradeV0(decodeState(t, webhookStateV0, webhookV0()), nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	request := upgraded["request"].([]interface{})[0].(map[string]interface{})
+	if request["password"] != "" {
+		t.Fatalf("expected the masked password to be removed, got %q", request["password"])
+	}
+	if request["url"] != "https://example.com/hooks" || upgraded["webhook_consumer"].(map[string]interface{})["name"] != "Foo" {
+		t.Fatalf("expected the rest of the state to be kept, got %v", upgraded)
+	}
+
+	// The upgraded state must be a valid version 1 state
+	decodeState(t, encodeState(t, upgraded), webhookV1())
+}
+
+func TestWebhookStateUpgradeV0(t *testing.T) {
+	upgrade := func(password string) string {
+		rawState := map[string]interface{}{
+			"description": "a webhook",
+			"request": []interface{}{
+				map[string]interface{}{
+					"url":      "https://example.com",
+					"password": password,
+				},
+			},
+		}
+
+		actual, err := webhookStateUpgradeV0(rawState, nil)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		return actual["request"].([]interface{})[0].(map[string]interface{})["password"].(string)
+	}
+
+	if actual := upgrade("*****"); actual != "" {
+		t.Fatalf("expected the masked password to be removed, got %q", actual)
+	}
+	if actual := upgrade(""); actual != "" {
+		t.Fatalf("expected an empty password to be kept, got %q", actual)
+	}
+	if actual := upgrade("password1"); !isHashedValue(actual) || !sensitiveValueMatches(actual, "password1") {
+		t.Fatalf("expected the password to be hashed, got %q", actual)
+	}
+}
+
+func TestWebhookStateUpgradeV1(t *testing.T) {
+	for id, expected := range map[string]string{
+		"2e4bf0e6-b0cf-451f-b05b-69048955f019":                               "2e4bf0e6-b0cf-451f-b05b-69048955f019",
+		"2e4bf0e6-b0cf-451f-b05b-69048955f019/":                              "2e4bf0e6-b0cf-451f-b05b-69048955f019",
+		"https://broker.example.com/webhooks/ZBztO9l5poBdBDyUNewbNw?foo=bar": "ZBztO9l5poBdBDyUNewbNw",
+		"/webhooks/ZBztO9l5poBdBDyUNewbNw":                                   "ZBztO9l5poBdBDyUNewbNw",
+		"42":                                                                 "42",
+	} {
+		actual, err := webhookStateUpgradeV1(map[string]interface{}{"id": id}, nil)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if actual["id"] != expected {
+			t.Fatalf("expected ID %q to be upgraded to %q, got %q", id, expected, actual["id"])
+		}
+	}
+}
+
+// webhookStateV1 is a webhook's state as written by version 1 of the schema, with the webhook's full self
+// link as its ID
+const webhookStateV1 = `{
+	"id": "https://broker.example.com/webhooks/2e4bf0e6-b0cf-451f-b05b-69048955f019",
+	"description": "a webhook",
+	"webhook_provider": {"name": "Bar"},
+	"webhook_consumer": {"name": "Foo"},
+	"request": [{
+		"url": "https://example.com/hooks",
+		"method": "POST",
+		"username": "user",
+		"password": "",
+		"headers": {"Content-Type": "application/json"},
+		"body": "{\n  \"a\": 1\n}"
+	}],
+	"events": ["contract_content_changed"],
+	"enabled": true,
+	"team": "",
+	"deletion_protection": false,
+	"uuid": "https://broker.example.com/webhooks/2e4bf0e6-b0cf-451f-b05b-69048955f019",
+	"consumer_name": "Foo",
+	"provider_name": "Bar",
+	"timeouts": null
+}`
+
+func TestWebhookStateUpgradeV1_RawState(t *testing.T) {
+	upgraded, err := webhookStateUpgradeV1(decodeState(t, webhookStateV1, webhookV1()), nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if upgraded["id"] != "2e4bf0e6-b0cf-451f-b05b-69048955f019" || upgraded["uuid"] != upgraded["id"] {
+		t.Fatalf("expected the ID and UUID to be the webhook's UUID, got %q and %q", upgraded["id"], upgraded["uuid"])
+	}
+
+	// The upgraded state must be a valid state for the current schema
+	decodeState(t, encodeState(t, upgraded), webhook())
+}
+
+func TestSensitiveValueMatches(t *testing.T) {
+	hashed := hashSensitiveValue("password1")
+
+	if hashed == hashSensitiveValue("password1") {
+		t.Fatal("expected each hash to be salted differently")
+	}
+	if hashSensitiveValue(hashed) != hashed {
+		t.Fatal("expected an already hashed value not to be hashed again")
+	}
+	if !sensitiveValueMatches(hashed, "password1") {
+		t.Fatal("expected the hash to match the original value")
+	}
+	if sensitiveValueMatches(hashed, "password2") {
+		t.Fatal("expected the hash not to match a different value")
+	}
+}
@@ -0,0 +1,347 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+	"github.com/pactflow/terraform/broker"
+	"github.com/pactflow/terraform/brokertest"
+	"github.com/pactflow/terraform/client"
+)
+
+func TestValidateHeaders(t *testing.T) {
+	warns, errs := validateHeaders(map[string]interface{}{
+		"Content-Type":  "application/json",
+		"X-Api-Key":     "abc",
+		"Authorization": "Bearer abc",
+	}, "headers")
+	if len(warns) != 0 || len(errs) != 0 {
+		t.Fatalf("expected valid headers to pass, got warnings %v and errors %v", warns, errs)
+	}
+
+	warns, errs = validateHeaders(map[string]interface{}{"X_Api_Key": "abc"}, "headers")
+	if len(warns) != 1 || len(errs) != 0 {
+		t.Fatalf("expected a warning for an underscore, got warnings %v and errors %v", warns, errs)
+	}
+
+	for _, name := range []string{"Content Type", "X-Api-Key:", "", "Ünicode"} {
+		if _, errs := validateHeaders(map[string]interface{}{name: "abc"}, "headers"); len(errs) != 1 {
+			t.Fatalf("expected an error for header name %q, got %v", name, errs)
+		}
+	}
+}
+
+func TestValidateEvents(t *testing.T) {
+	if warns, errs := validateEvents("contract_published", "events"); len(warns) != 0 || len(errs) != 0 {
+		t.Fatalf("expected a current event to pass, got warnings %v and errors %v", warns, errs)
+	}
+
+	warns, errs := validateEvents("contract_changed", "events")
+	if len(warns) != 1 || len(errs) != 0 {
+		t.Fatalf("expected a warning for a deprecated event, got warnings %v and errors %v", warns, errs)
+	}
+	if !strings.Contains(warns[0], "contract_content_changed") {
+		t.Fatalf("expected the warning to suggest the replacement event, got %q", warns[0])
+	}
+
+	if _, errs := validateEvents("contract_deleted", "events"); len(errs) != 1 {
+		t.Fatalf("expected an error for an unknown event, got %v", errs)
+	}
+}
+
+func TestValidateWebhookTargetBody(t *testing.T) {
+	valid := map[string][]string{
+		slackTargetType: {
+			`{"text": "Verification of ${pactbroker.consumerName} failed"}`,
+			`{"blocks": [{"type": "section", "text": {"type": "mrkdwn", "text": "hello"}}]}`,
+		},
+		msTeamsTargetType: {
+			`{"@type": "MessageCard", "@context": "https://schema.org/extensions", "text": "hello"}`,
+			`{"type": "message", "attachments": [{"contentType": "application/vnd.microsoft.card.adaptive", "content": {"type": "AdaptiveCard"}}]}`,
+		},
+		githubStatusTargetType: {
+			`{"state": "${pactbroker.githubVerificationStatus}", "context": "pact"}`,
+			`{"state": "success"}`,
+		},
+	}
+	invalid := map[string][]string{
+		slackTargetType: {
+			`not json`,
+			`["text"]`,
+			`{"username": "pact"}`,
+			`{"blocks": [{"type": "header", "text": {"type": "plain_text", "text": "` + strings.Repeat("x", 151) + `"}}]}`,
+			`{"blocks": [{"text": {"text": "no type"}}]}`,
+		},
+		msTeamsTargetType: {
+			`{"@type": "AdaptiveCard", "text": "hello"}`,
+			`{"type": "message", "attachments": []}`,
+			`{"text": "hello"}`,
+		},
+		githubStatusTargetType: {
+			`{"context": "pact"}`,
+			`{"state": "passed"}`,
+			`{"state": "success", "description": "` + strings.Repeat("x", 141) + `"}`,
+		},
+	}
+
+	for targetType, bodies := range valid {
+		for _, body := range bodies {
+			if err := validateWebhookTargetBody(targetType, body); err != nil {
+				t.Fatalf("expected %s body %s to be valid, got %s", targetType, body, err)
+			}
+		}
+	}
+	for targetType, bodies := range invalid {
+		for _, body := range bodies {
+			if err := validateWebhookTargetBody(targetType, body); err == nil {
+				t.Fatalf("expected %s body %s to be invalid", targetType, body)
+			}
+		}
+	}
+}
+
+func TestBodyFileStateFunc(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "body.json")
+	if err := ioutil.WriteFile(path, []byte(`{"text": "hello"}`), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	stored := bodyFileStateFunc(path)
+	if !bodyFileHashSuffix.MatchString(stored) || bodyFilePath(stored) != path {
+		t.Fatalf("expected the path followed by a content hash, got %q", stored)
+	}
+	if bodyFileStateFunc(stored) != stored {
+		t.Fatal("expected a stored value to hash to itself while the file is unchanged")
+	}
+
+	if err := ioutil.WriteFile(path, []byte(`{"text": "goodbye"}`), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if bodyFileStateFunc(path) == stored {
+		t.Fatal("expected changing the file content to change the stored value")
+	}
+
+	if _, errs := validateBodyFile(filepath.Join(t.TempDir(), "missing.json"), "body_file"); len(errs) != 1 {
+		t.Fatalf("expected an error for a missing file, got %v", errs)
+	}
+}
+
+func TestWebhookLifecycle(t *testing.T) {
+	server := brokertest.NewServer()
+	defer server.Close()
+
+	meta, err := configureProvider(schema.TestResourceDataRaw(t, Provider().Schema, map[string]interface{}{
+		"host": server.URL,
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	d := schema.TestResourceDataRaw(t, webhook().Schema, map[string]interface{}{
+		"description":      "notify the team",
+		"webhook_provider": map[string]interface{}{"name": "terraform-provider"},
+		"events":           []interface{}{"contract_published"},
+		"request": []interface{}{map[string]interface{}{
+			"url":      "https://example.com/hooks",
+			"method":   "POST",
+			"username": "hooks",
+			"password": "secret",
+			"headers":  map[string]interface{}{"Content-Type": "application/json"},
+			"body":     `{"pact": "${pactbroker.pactUrl}"}`,
+		}},
+	})
+
+	if err := webhookCreate(d, meta); err != nil {
+		t.Fatalf("error creating the webhook: %s", err)
+	}
+	stored, ok := server.Webhook(d.Id())
+	if !ok {
+		t.Fatalf("expected the webhook to be created with ID %q", d.Id())
+	}
+	if stored.Provider == nil || stored.Provider.Name != "terraform-provider" || stored.Request.Password != "secret" {
+		t.Fatalf("expected the configured webhook to be sent to the broker, got %+v", stored)
+	}
+
+	// The broker never returns the password, so reading it back must not drop it from state
+	if err := webhookRead(d, meta); err != nil {
+		t.Fatalf("error reading the webhook: %s", err)
+	}
+	if d.Get("request.0.password").(string) == "" {
+		t.Fatal("expected the password to be kept in state after a read")
+	}
+
+	stored.Description = "changed outside of Terraform"
+	server.PutWebhook(d.Id(), stored)
+	if err := webhookRead(d, meta); err != nil {
+		t.Fatalf("error reading the webhook: %s", err)
+	}
+	if description := d.Get("description").(string); description != stored.Description {
+		t.Fatalf("expected the drifted description to be read, got %q", description)
+	}
+
+	server.DeleteWebhook(d.Id())
+	if err := webhookRead(d, meta); err != nil {
+		t.Fatalf("error reading the deleted webhook: %s", err)
+	}
+	if d.Id() != "" {
+		t.Fatalf("expected a webhook deleted outside of Terraform to be removed from state, got ID %q", d.Id())
+	}
+}
+
+func TestWebhookUpdateConflict(t *testing.T) {
+	server := brokertest.NewServer()
+	defer server.Close()
+
+	provider := Provider()
+	if err := provider.Configure(terraform.NewResourceConfigRaw(map[string]interface{}{"host": server.URL})); err != nil {
+		t.Fatal(err)
+	}
+	r := provider.ResourcesMap["pact_webhook"]
+
+	apply := func(state *terraform.InstanceState, description string) (*terraform.InstanceState, error) {
+		diff, err := r.Diff(state, terraform.NewResourceConfigRaw(map[string]interface{}{
+			"description": description,
+			"request": []interface{}{map[string]interface{}{
+				"url":    "https://example.com/hooks",
+				"method": "POST",
+			}},
+		}), provider.Meta())
+		if err != nil {
+			t.Fatalf("error planning the webhook: %s", err)
+		}
+		return r.Apply(state, diff, provider.Meta())
+	}
+
+	state, err := apply(nil, "notify the team")
+	if err != nil {
+		t.Fatalf("error creating the webhook: %s", err)
+	}
+	if state.Attributes["etag"] == "" {
+		t.Fatal("expected the etag of the created webhook to be stored")
+	}
+
+	state, err = apply(state, "notify the whole team")
+	if err != nil {
+		t.Fatalf("error updating the webhook: %s", err)
+	}
+
+	// Someone edits the webhook in the UI between the plan and the apply
+	stored, _ := server.Webhook(state.ID)
+	stored.Description = "edited in the UI"
+	server.PutWebhook(state.ID, stored)
+
+	if _, err := apply(state, "notify everyone"); !errors.Is(err, client.ErrPreconditionFailed) {
+		t.Fatalf("expected the update to fail as the webhook was changed since it was read, got %v", err)
+	}
+	if _, err := r.Apply(state, &terraform.InstanceDiff{Destroy: true}, provider.Meta()); !errors.Is(err, client.ErrPreconditionFailed) {
+		t.Fatalf("expected the delete to fail as the webhook was changed since it was read, got %v", err)
+	}
+	if stored, _ := server.Webhook(state.ID); stored.Description != "edited in the UI" {
+		t.Fatalf("expected the change made in the UI to be kept, got %q", stored.Description)
+	}
+}
+
+// fakeWebhooks is a webhookAPI holding webhooks in memory, for testing the resource without HTTP
+type fakeWebhooks map[string]broker.Webhook
+
+func (f fakeWebhooks) CreateWebhook(w broker.Webhook) (*broker.WebhookResponse, error) {
+	w.ID = fmt.Sprintf("00000000-0000-0000-0000-%012d", len(f)+1)
+	f[w.ID] = w
+
+	return f.response(w), nil
+}
+
+func (f fakeWebhooks) ReadWebhook(id string) (*broker.WebhookResponse, error) {
+	w, ok := f[id]
+	if !ok {
+		return nil, client.ErrNotFound
+	}
+
+	// The broker never returns the password
+	w.Request.Password = ""
+	return f.response(w), nil
+}
+
+func (f fakeWebhooks) UpdateWebhook(w broker.Webhook) (*broker.WebhookResponse, error) {
+	if _, ok := f[w.ID]; !ok {
+		return nil, client.ErrNotFound
+	}
+	f[w.ID] = w
+
+	return f.response(w), nil
+}
+
+func (f fakeWebhooks) DeleteWebhook(w broker.Webhook) error {
+	if _, ok := f[w.ID]; !ok {
+		return client.ErrNotFound
+	}
+	delete(f, w.ID)
+
+	return nil
+}
+
+func (f fakeWebhooks) Context() context.Context {
+	return context.Background()
+}
+
+func (f fakeWebhooks) response(w broker.Webhook) *broker.WebhookResponse {
+	return &broker.WebhookResponse{
+		Webhook: w,
+		HalDoc:  broker.HalDoc{Links: broker.HalLinks{"self": {Href: "https://broker.example.com/webhooks/" + w.ID}}},
+	}
+}
+
+func TestWebhookCRUD_WithoutHTTP(t *testing.T) {
+	webhooks := fakeWebhooks{}
+
+	d := schema.TestResourceDataRaw(t, webhook().Schema, map[string]interface{}{
+		"description":      "notify the team",
+		"webhook_consumer": map[string]interface{}{"name": "terraform-consumer"},
+		"events":           []interface{}{"contract_published"},
+		"request": []interface{}{map[string]interface{}{
+			"url":      "https://example.com/hooks",
+			"method":   "POST",
+			"password": "secret",
+			"headers":  map[string]interface{}{"X-Api-Key": "abc"},
+		}},
+	})
+
+	if err := webhookCreate(d, webhooks); err != nil {
+		t.Fatalf("error creating the webhook: %s", err)
+	}
+	stored, ok := webhooks[d.Id()]
+	if !ok || stored.Consumer == nil || stored.Consumer.Name != "terraform-consumer" || stored.Request.Headers["X-Api-Key"] != "abc" {
+		t.Fatalf("expected the configured webhook to be created, got %+v", webhooks)
+	}
+
+	stored.Enabled = false
+	webhooks[d.Id()] = stored
+	if err := webhookRead(d, webhooks); err != nil {
+		t.Fatalf("error reading the webhook: %s", err)
+	}
+	if d.Get("enabled").(bool) || d.Get("request.0.password").(string) == "" {
+		t.Fatalf("expected the drift to be read and the password kept, got enabled %v", d.Get("enabled"))
+	}
+
+	d.Set("description", "notify everyone")
+	if err := webhookUpdate(d, webhooks); err != nil {
+		t.Fatalf("error updating the webhook: %s", err)
+	}
+	if webhooks[d.Id()].Description != "notify everyone" {
+		t.Fatalf("expected the description to be updated, got %q", webhooks[d.Id()].Description)
+	}
+
+	if err := webhookDelete(d, webhooks); err != nil {
+		t.Fatalf("error deleting the webhook: %s", err)
+	}
+	if len(webhooks) != 0 {
+		t.Fatalf("expected the webhook to be deleted, got %+v", webhooks)
+	}
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+// Sensitive values (webhook passwords, secret values) are write only: the broker never returns them,
+// so the state only needs enough to detect a change to the configured value. Rather than storing the
+// plaintext, a salted hash in the form sha256:<salt>:<hash> is stored
+const hashedValuePrefix = "sha256:"
+
+func isHashedValue(s string) bool {
+	return strings.HasPrefix(s, hashedValuePrefix) && len(strings.Split(s, ":")) == 3
+}
+
+// hashSensitiveValue returns a salted hash of the value, or the value itself if it is empty or already hashed
+func hashSensitiveValue(value string) string {
+	if value == "" || isHashedValue(value) {
+		return value
+	}
+
+	salt := make([]byte, 16)
+	if _, err := rand.Read(salt); err != nil {
+		// Never fall back to storing the plaintext
+		logError("", nil, "unable to generate a salt for a sensitive value", "error", err)
+		return ""
+	}
+
+	return hashedValuePrefix + hex.EncodeToString(salt) + ":" + sensitiveValueDigest(hex.EncodeToString(salt), value)
+}
+
+func sensitiveValueDigest(salt, value string) string {
+	sum := sha256.Sum256([]byte(salt + value))
+	return hex.EncodeToString(sum[:])
+}
+
+// sensitiveValueMatches checks whether the plaintext is the value that was hashed
+func sensitiveValueMatches(hashed, plaintext string) bool {
+	if !isHashedValue(hashed) {
+		return hashed == plaintext
+	}
+
+	parts := strings.Split(hashed, ":")
+	expected := sensitiveValueDigest(parts[1], plaintext)
+
+	return subtle.ConstantTimeCompare([]byte(parts[2]), []byte(expected)) == 1
+}
+
+// suppressHashedValueDiff compares the configured plaintext against the hash held in state
+func suppressHashedValueDiff(k, old, new string, d *schema.ResourceData) bool {
+	return isHashedValue(old) && sensitiveValueMatches(old, new)
+}
+
+// unhashedValue returns the value to send to the broker. A hashed value means the configured value is
+// unchanged (its diff was suppressed), in which case nothing is sent and the broker keeps the existing value
+func unhashedValue(value string) string {
+	if isHashedValue(value) {
+		return ""
+	}
+
+	return value
+}
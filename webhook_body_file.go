@@ -0,0 +1,53 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"regexp"
+)
+
+// bodyFileHashSuffix matches the content hash appended to body_file in state
+var bodyFileHashSuffix = regexp.MustCompile(`#sha256:[0-9a-f]{64}$`)
+
+// bodyFileStateFunc stores body_file as the path followed by a hash of the file's content, so that
+// changing the file (not just its path) shows up in a plan
+func bodyFileStateFunc(v interface{}) string {
+	path := bodyFilePath(v.(string))
+	if path == "" {
+		return ""
+	}
+
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		// Reported by validateBodyFile, and again when the body is sent
+		logDebug("", nil, "unable to read webhook body file", "path", path, "error", err)
+		return path
+	}
+
+	sum := sha256.Sum256(content)
+	return path + "#sha256:" + hex.EncodeToString(sum[:])
+}
+
+// bodyFilePath returns the path of the body file, without the content hash held in state
+func bodyFilePath(v string) string {
+	return bodyFileHashSuffix.ReplaceAllString(v, "")
+}
+
+func validateBodyFile(val interface{}, key string) (warns []string, errs []error) {
+	if _, err := ioutil.ReadFile(bodyFilePath(val.(string))); err != nil {
+		errs = append(errs, fmt.Errorf("%q must be a readable file: %w", key, err))
+	}
+	return
+}
+
+// readBodyFile reads the webhook body from the body file
+func readBodyFile(v string) (string, error) {
+	content, err := ioutil.ReadFile(bodyFilePath(v))
+	if err != nil {
+		return "", fmt.Errorf("error reading webhook body file: %w", err)
+	}
+
+	return string(content), nil
+}
@@ -0,0 +1,189 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+// The targets a webhook body can be checked against with target_type
+const (
+	slackTargetType        = "slack"
+	msTeamsTargetType      = "ms_teams"
+	githubStatusTargetType = "github_status"
+)
+
+var allowedTargetTypes = []string{
+	slackTargetType,
+	msTeamsTargetType,
+	githubStatusTargetType,
+}
+
+// Limits documented by each target, beyond which the request is rejected or the message truncated
+const (
+	slackMaxTextLength        = 40000
+	slackMaxBlocks            = 50
+	slackMaxSectionTextLength = 3000
+	slackMaxHeaderTextLength  = 150
+	msTeamsMaxBodyBytes       = 28 * 1024
+	githubMaxDescription      = 140
+)
+
+var githubStatusStates = []string{"error", "failure", "pending", "success"}
+
+// webhookTargetTypeCustomizeDiff checks the body against what the target accepts, so payloads the
+// target would reject fail the plan instead of failing silently each time the webhook fires
+func webhookTargetTypeCustomizeDiff(d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("request") {
+		return nil
+	}
+
+	requests, _ := d.Get("request").([]interface{})
+	if len(requests) != 1 {
+		return nil
+	}
+
+	request, _ := requests[0].(map[string]interface{})
+	targetType, _ := request["target_type"].(string)
+	body, _ := request["body"].(string)
+	if targetType == "" {
+		return nil
+	}
+
+	if file, _ := request["body_file"].(string); file != "" {
+		content, err := readBodyFile(file)
+		if err != nil {
+			// Reported by validateBodyFile
+			return nil
+		}
+		body = content
+	}
+
+	if err := validateWebhookTargetBody(targetType, body); err != nil {
+		return fmt.Errorf("request.0.body: the body is not valid for a %s webhook: %w", targetType, err)
+	}
+
+	return nil
+}
+
+func validateWebhookTargetBody(targetType, body string) error {
+	decoded, err := decodeJSON(body)
+	if err != nil {
+		return fmt.Errorf("must be a JSON document: %w", err)
+	}
+
+	doc, ok := decoded.(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("must be a JSON object")
+	}
+
+	switch targetType {
+	case slackTargetType:
+		return validateSlackBody(doc)
+	case msTeamsTargetType:
+		if len(body) > msTeamsMaxBodyBytes {
+			return fmt.Errorf("must be at most %d bytes, got %d", msTeamsMaxBodyBytes, len(body))
+		}
+		return validateMSTeamsBody(doc)
+	case githubStatusTargetType:
+		return validateGitHubStatusBody(doc)
+	}
+
+	return nil
+}
+
+func validateSlackBody(doc map[string]interface{}) error {
+	text, hasText := doc["text"].(string)
+	blocks, hasBlocks := doc["blocks"].([]interface{})
+	_, hasAttachments := doc["attachments"].([]interface{})
+
+	if !hasText && !hasBlocks && !hasAttachments {
+		return fmt.Errorf("must have text, blocks or attachments")
+	}
+	if len(text) > slackMaxTextLength {
+		return fmt.Errorf("text must be at most %d characters, got %d", slackMaxTextLength, len(text))
+	}
+	if len(blocks) > slackMaxBlocks {
+		return fmt.Errorf("blocks must have at most %d blocks, got %d", slackMaxBlocks, len(blocks))
+	}
+
+	for i, b := range blocks {
+		block, ok := b.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("blocks.%d must be an object", i)
+		}
+		blockType, _ := block["type"].(string)
+		if blockType == "" {
+			return fmt.Errorf("blocks.%d must have a type", i)
+		}
+
+		blockText := ""
+		if t, ok := block["text"].(map[string]interface{}); ok {
+			blockText, _ = t["text"].(string)
+		}
+
+		switch {
+		case blockType == "section" && len(blockText) > slackMaxSectionTextLength:
+			return fmt.Errorf("blocks.%d section text must be at most %d characters, got %d", i, slackMaxSectionTextLength, len(blockText))
+		case blockType == "header" && len(blockText) > slackMaxHeaderTextLength:
+			return fmt.Errorf("blocks.%d header text must be at most %d characters, got %d", i, slackMaxHeaderTextLength, len(blockText))
+		}
+	}
+
+	return nil
+}
+
+// Teams accepts either a legacy message card, or a message with adaptive card attachments
+func validateMSTeamsBody(doc map[string]interface{}) error {
+	if cardType, _ := doc["@type"].(string); cardType != "" {
+		if cardType != "MessageCard" {
+			return fmt.Errorf("@type must be MessageCard, got %q", cardType)
+		}
+		_, hasText := doc["text"].(string)
+		_, hasSummary := doc["summary"].(string)
+		if !hasText && !hasSummary {
+			return fmt.Errorf("a MessageCard must have text or a summary")
+		}
+		return nil
+	}
+
+	if messageType, _ := doc["type"].(string); messageType != "message" {
+		return fmt.Errorf("must be a MessageCard (with @type) or a message (type = \"message\") with adaptive card attachments")
+	}
+
+	attachments, _ := doc["attachments"].([]interface{})
+	if len(attachments) == 0 {
+		return fmt.Errorf("a message must have at least one attachment")
+	}
+
+	for i, a := range attachments {
+		attachment, _ := a.(map[string]interface{})
+		if contentType, _ := attachment["contentType"].(string); contentType != "application/vnd.microsoft.card.adaptive" {
+			return fmt.Errorf("attachments.%d contentType must be application/vnd.microsoft.card.adaptive, got %q", i, contentType)
+		}
+		content, _ := attachment["content"].(map[string]interface{})
+		if cardType, _ := content["type"].(string); cardType != "AdaptiveCard" {
+			return fmt.Errorf("attachments.%d content must be an AdaptiveCard", i)
+		}
+	}
+
+	return nil
+}
+
+func validateGitHubStatusBody(doc map[string]interface{}) error {
+	state, _ := doc["state"].(string)
+	if state == "" {
+		return fmt.Errorf("must have a state")
+	}
+	// A template parameter (e.g. ${pactbroker.githubVerificationStatus}) is only resolved when the webhook fires
+	if !strings.Contains(state, "${") && !stringContains(githubStatusStates, state) {
+		return fmt.Errorf("state must be one of %v, got %q", githubStatusStates, state)
+	}
+
+	if description, _ := doc["description"].(string); len(description) > githubMaxDescription {
+		return fmt.Errorf("description must be at most %d characters, got %d", githubMaxDescription, len(description))
+	}
+
+	return nil
+}